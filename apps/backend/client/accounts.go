@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// ListAccounts calls GET /api/v2/accounts
+func (c *Client) ListAccounts(ctx context.Context) ([]models.AccountV2, error) {
+	var accounts []models.AccountV2
+	response := models.ResponseV2{Data: &accounts}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/accounts"}, &response); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// GetAccount calls GET /api/v2/accounts/{id}
+func (c *Client) GetAccount(ctx context.Context, id string) (*models.AccountV2, error) {
+	var account models.AccountV2
+	response := models.ResponseV2{Data: &account}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/accounts/" + escape(id)}, &response); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// RefreshAccount calls POST /api/v2/accounts/{id}/refresh and returns the refreshed account
+func (c *Client) RefreshAccount(ctx context.Context, id string) (*models.AccountV2, error) {
+	var account models.AccountV2
+	response := models.ResponseV2{Data: &account}
+	if err := c.do(ctx, request{method: http.MethodPost, path: apiPrefix + "/accounts/" + escape(id) + "/refresh"}, &response); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// RefreshAccountAtVersion refreshes the account only if it is still at version, as read
+// from AccountV2.Version. A newer account fails with ErrVersionConflict.
+func (c *Client) RefreshAccountAtVersion(ctx context.Context, id string, version int64) (*models.AccountV2, error) {
+	var account models.AccountV2
+	response := models.ResponseV2{Data: &account}
+	body := models.AccountRefreshRequest{Version: version}
+	if err := c.do(ctx, request{method: http.MethodPost, path: apiPrefix + "/accounts/" + escape(id) + "/refresh", body: body}, &response); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
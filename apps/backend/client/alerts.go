@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// ListAlerts calls GET /api/v2/alerts. Empty ruleID or accountID match every rule or account.
+func (c *Client) ListAlerts(ctx context.Context, ruleID, accountID string) ([]models.Alert, error) {
+	query := url.Values{}
+	setString(query, "rule_id", ruleID)
+	setString(query, "account_id", accountID)
+
+	var alerts []models.Alert
+	response := models.APIResponse{Data: &alerts}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/alerts", query: query}, &response); err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// ListAlertRules calls GET /api/v2/alerts/rules
+func (c *Client) ListAlertRules(ctx context.Context) ([]models.AlertRule, error) {
+	var rules []models.AlertRule
+	response := models.APIResponse{Data: &rules}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/alerts/rules"}, &response); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// CreateAlertRule calls POST /api/v2/alerts/rules
+func (c *Client) CreateAlertRule(ctx context.Context, rule models.AlertRuleRequest) (*models.AlertRule, error) {
+	return c.alertRule(ctx, request{method: http.MethodPost, path: apiPrefix + "/alerts/rules", body: rule})
+}
+
+// GetAlertRule calls GET /api/v2/alerts/rules/{id}
+func (c *Client) GetAlertRule(ctx context.Context, id string) (*models.AlertRule, error) {
+	return c.alertRule(ctx, request{method: http.MethodGet, path: apiPrefix + "/alerts/rules/" + escape(id)})
+}
+
+// DeleteAlertRule calls DELETE /api/v2/alerts/rules/{id}
+func (c *Client) DeleteAlertRule(ctx context.Context, id string) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: apiPrefix + "/alerts/rules/" + escape(id)}, nil)
+}
+
+// alertRule performs req and decodes a single rule
+func (c *Client) alertRule(ctx context.Context, req request) (*models.AlertRule, error) {
+	var rule models.AlertRule
+	response := models.APIResponse{Data: &rule}
+	if err := c.do(ctx, req, &response); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
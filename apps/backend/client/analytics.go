@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// Cashflow calls GET /api/v2/analytics/cashflow. Zero dates and an empty GroupBy are not
+// sent, so the server defaults to the current month to date, grouped by category.
+func (c *Client) Cashflow(ctx context.Context, query models.CashflowQuery) (*models.CashflowReport, error) {
+	values := url.Values{}
+	if !query.From.IsZero() {
+		values.Set("from", query.From.Format("2006-01-02"))
+	}
+	if !query.To.IsZero() {
+		values.Set("to", query.To.Format("2006-01-02"))
+	}
+	setString(values, "group_by", query.GroupBy)
+	if query.IncludeTransfers {
+		values.Set("include_transfers", strconv.FormatBool(true))
+	}
+
+	var report models.CashflowReport
+	response := models.APIResponse{Data: &report}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/analytics/cashflow", query: values}, &response); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// ListMerchants calls GET /api/v2/merchants
+func (c *Client) ListMerchants(ctx context.Context, filter models.MerchantFilter) ([]models.MerchantSpend, error) {
+	values := url.Values{}
+	setString(values, "account_id", filter.AccountID)
+	if filter.From != nil {
+		values.Set("from", filter.From.Format("2006-01-02"))
+	}
+	if filter.To != nil {
+		values.Set("to", filter.To.Format("2006-01-02"))
+	}
+	if filter.Limit > 0 {
+		values.Set("limit", strconv.Itoa(filter.Limit))
+	}
+
+	var merchants []models.MerchantSpend
+	response := models.APIResponse{Data: &merchants}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/merchants", query: values}, &response); err != nil {
+		return nil, err
+	}
+	return merchants, nil
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// AttachmentContent is a downloaded attachment. The caller must close Body.
+type AttachmentContent struct {
+	FileName    string
+	ContentType string
+	Size        int64 // -1 when the server did not send a length
+	Body        io.ReadCloser
+}
+
+// UploadAttachment calls POST /api/v2/transactions/{id}/attachments with content as the
+// file. The content is buffered so the upload can be retried.
+func (c *Client) UploadAttachment(ctx context.Context, transactionID, fileName string, content io.Reader) (*models.Attachment, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("file", fileName)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(part, content); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var attachment models.Attachment
+	response := models.APIResponse{Data: &attachment}
+	req := request{
+		method:      http.MethodPost,
+		path:        apiPrefix + "/transactions/" + escape(transactionID) + "/attachments",
+		raw:         body.Bytes(),
+		contentType: writer.FormDataContentType(),
+	}
+	if err := c.do(ctx, req, &response); err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// GetAttachment calls GET /api/v2/transactions/{id}/attachments/{attachmentId} and returns
+// the content as it streams in
+func (c *Client) GetAttachment(ctx context.Context, transactionID, attachmentID string) (*AttachmentContent, error) {
+	response, err := c.send(ctx, request{
+		method: http.MethodGet,
+		path:   apiPrefix + "/transactions/" + escape(transactionID) + "/attachments/" + escape(attachmentID),
+		accept: "*/*",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	content := &AttachmentContent{
+		ContentType: response.Header.Get("Content-Type"),
+		Size:        response.ContentLength,
+		Body:        response.Body,
+	}
+	if _, params, err := mime.ParseMediaType(response.Header.Get("Content-Disposition")); err == nil {
+		content.FileName = params["filename"]
+	}
+	return content, nil
+}
+
+// DeleteAttachment calls DELETE /api/v2/transactions/{id}/attachments/{attachmentId}
+func (c *Client) DeleteAttachment(ctx context.Context, transactionID, attachmentID string) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: apiPrefix + "/transactions/" + escape(transactionID) + "/attachments/" + escape(attachmentID)}, nil)
+}
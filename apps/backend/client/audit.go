@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// AuditPage is one page of audit log entries
+type AuditPage struct {
+	Entries []models.AuditEntry
+	Meta    models.PaginationMeta
+}
+
+// ListAuditEntries calls GET /api/v2/audit and returns one page
+func (c *Client) ListAuditEntries(ctx context.Context, filter models.AuditFilter) (*AuditPage, error) {
+	page := &AuditPage{}
+	response := struct {
+		Data *[]models.AuditEntry   `json:"data"`
+		Meta *models.PaginationMeta `json:"meta"`
+	}{Data: &page.Entries, Meta: &page.Meta}
+
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/audit", query: auditValues(filter)}, &response); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// AuditEntries iterates over every audit entry matching filter, starting at filter.Offset
+func (c *Client) AuditEntries(filter models.AuditFilter) *Iterator[models.AuditEntry] {
+	return newIterator(func(ctx context.Context) ([]models.AuditEntry, bool, error) {
+		page, err := c.ListAuditEntries(ctx, filter)
+		if err != nil {
+			return nil, false, err
+		}
+
+		filter.Offset += len(page.Entries)
+		return page.Entries, len(page.Entries) > 0 && filter.Offset < page.Meta.Total, nil
+	})
+}
+
+// VerifyAuditLog calls GET /api/v2/audit/verify
+func (c *Client) VerifyAuditLog(ctx context.Context) (*models.AuditVerification, error) {
+	var verification models.AuditVerification
+	response := models.APIResponse{Data: &verification}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/audit/verify"}, &response); err != nil {
+		return nil, err
+	}
+	return &verification, nil
+}
+
+// auditValues encodes an audit filter, skipping zero values
+func auditValues(filter models.AuditFilter) url.Values {
+	values := url.Values{}
+	setString(values, "entity_type", filter.EntityType)
+	setString(values, "entity_id", filter.EntityID)
+	setString(values, "action", filter.Action)
+	setString(values, "actor", filter.Actor)
+	setString(values, "request_id", filter.RequestID)
+	if filter.Limit > 0 {
+		values.Set("limit", strconv.Itoa(filter.Limit))
+	}
+	if filter.Offset > 0 {
+		values.Set("offset", strconv.Itoa(filter.Offset))
+	}
+	return values
+}
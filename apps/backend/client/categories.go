@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// ListCategories calls GET /api/v2/categories and returns the tree depth first
+func (c *Client) ListCategories(ctx context.Context) ([]models.Category, error) {
+	var categories []models.Category
+	response := models.APIResponse{Data: &categories}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/categories"}, &response); err != nil {
+		return nil, err
+	}
+	return categories, nil
+}
+
+// CreateCategory calls POST /api/v2/categories
+func (c *Client) CreateCategory(ctx context.Context, category models.CategoryRequest) (*models.Category, error) {
+	return c.category(ctx, request{method: http.MethodPost, path: apiPrefix + "/categories", body: category})
+}
+
+// GetCategory calls GET /api/v2/categories/{id}
+func (c *Client) GetCategory(ctx context.Context, id string) (*models.Category, error) {
+	return c.category(ctx, request{method: http.MethodGet, path: apiPrefix + "/categories/" + escape(id)})
+}
+
+// UpdateCategory calls PATCH /api/v2/categories/{id}
+func (c *Client) UpdateCategory(ctx context.Context, id string, update models.CategoryUpdate) (*models.Category, error) {
+	return c.category(ctx, request{method: http.MethodPatch, path: apiPrefix + "/categories/" + escape(id), body: update})
+}
+
+// DeleteCategory calls DELETE /api/v2/categories/{id}
+func (c *Client) DeleteCategory(ctx context.Context, id string) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: apiPrefix + "/categories/" + escape(id)}, nil)
+}
+
+// MergeCategory calls POST /api/v2/categories/{id}/merge
+func (c *Client) MergeCategory(ctx context.Context, id, into string) (*models.CategoryMergeResult, error) {
+	var result models.CategoryMergeResult
+	response := models.APIResponse{Data: &result}
+	req := request{method: http.MethodPost, path: apiPrefix + "/categories/" + escape(id) + "/merge", body: models.CategoryMerge{Into: into}}
+	if err := c.do(ctx, req, &response); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CategoryReport calls GET /api/v2/analytics/categories. Zero dates are not sent, so the
+// server defaults to the current month to date.
+func (c *Client) CategoryReport(ctx context.Context, query models.CategoryReportQuery) (*models.CategoryReport, error) {
+	values := url.Values{}
+	if !query.From.IsZero() {
+		values.Set("from", query.From.Format("2006-01-02"))
+	}
+	if !query.To.IsZero() {
+		values.Set("to", query.To.Format("2006-01-02"))
+	}
+	setString(values, "account_id", query.AccountID)
+	if query.IncludeTransfers {
+		values.Set("include_transfers", strconv.FormatBool(true))
+	}
+
+	var report models.CategoryReport
+	response := models.APIResponse{Data: &report}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/analytics/categories", query: values}, &response); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// category performs req and decodes a single category
+func (c *Client) category(ctx context.Context, req request) (*models.Category, error) {
+	var category models.Category
+	response := models.APIResponse{Data: &category}
+	if err := c.do(ctx, req, &response); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
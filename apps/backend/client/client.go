@@ -0,0 +1,268 @@
+// Package client is the Go SDK for the Financial Aggregator API. It talks to the v2 API,
+// retries transient failures and returns the server's models.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	mathrand "math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// apiPrefix is the versioned path every API call is made under
+const apiPrefix = "/api/v2"
+
+// Config controls how the client reaches the API and retries failures
+type Config struct {
+	BaseURL     string        // scheme and host, such as http://localhost:8080
+	HTTPClient  *http.Client  // defaults to http.DefaultClient
+	MaxRetries  int           // retries after the first attempt; 0 disables retrying
+	BaseBackoff time.Duration // delay before the first retry, doubled on each retry
+	MaxBackoff  time.Duration // upper bound for a single delay, including Retry-After
+	UserAgent   string
+}
+
+// DefaultConfig returns the configuration used when nothing is overridden
+func DefaultConfig(baseURL string) Config {
+	return Config{
+		BaseURL:     baseURL,
+		HTTPClient:  http.DefaultClient,
+		MaxRetries:  3,
+		BaseBackoff: 200 * time.Millisecond,
+		MaxBackoff:  5 * time.Second,
+		UserAgent:   "finagg-go-client",
+	}
+}
+
+// Client calls the Financial Aggregator API. It is safe for concurrent use.
+type Client struct {
+	baseURL string
+	config  Config
+
+	// sleep waits between retries; replaced in tests
+	sleep func(ctx context.Context, delay time.Duration) error
+}
+
+// NewClient creates a new Client instance
+func NewClient(config Config) *Client {
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+
+	return &Client{
+		baseURL: strings.TrimRight(config.BaseURL, "/"),
+		config:  config,
+		sleep:   sleepContext,
+	}
+}
+
+// request describes a single API call
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	body   interface{}
+	accept string
+	header http.Header
+
+	// raw is sent as is with contentType instead of body, for uploads
+	raw         []byte
+	contentType string
+}
+
+// do sends req, retrying transient failures, and decodes a successful response into out
+func (c *Client) do(ctx context.Context, req request, out interface{}) error {
+	response, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if out == nil {
+		_, err = io.Copy(io.Discard, response.Body)
+		return err
+	}
+	return json.NewDecoder(response.Body).Decode(out)
+}
+
+// send performs req and returns the first successful response. The caller closes its body.
+func (c *Client) send(ctx context.Context, req request) (*http.Response, error) {
+	body, contentType := req.raw, req.contentType
+	if req.body != nil {
+		encoded, err := json.Marshal(req.body)
+		if err != nil {
+			return nil, err
+		}
+		body, contentType = encoded, "application/json"
+	}
+
+	// POSTs carry an Idempotency-Key so a retry is replayed instead of applied twice
+	var idempotencyKey string
+	if req.method == http.MethodPost {
+		idempotencyKey = newIdempotencyKey()
+	}
+
+	target := c.baseURL + req.path
+	if len(req.query) > 0 {
+		target += "?" + req.query.Encode()
+	}
+
+	for attempt := 0; ; attempt++ {
+		httpRequest, err := http.NewRequestWithContext(ctx, req.method, target, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		if body != nil {
+			httpRequest.Header.Set("Content-Type", contentType)
+		}
+		if req.accept != "" {
+			httpRequest.Header.Set("Accept", req.accept)
+		} else {
+			httpRequest.Header.Set("Accept", "application/json, application/problem+json")
+		}
+		for name, values := range req.header {
+			httpRequest.Header[name] = values
+		}
+		if idempotencyKey != "" {
+			httpRequest.Header.Set("Idempotency-Key", idempotencyKey)
+		}
+		if c.config.UserAgent != "" {
+			httpRequest.Header.Set("User-Agent", c.config.UserAgent)
+		}
+
+		response, err := c.config.HTTPClient.Do(httpRequest)
+		if err != nil {
+			// Transport errors are retried; a cancelled context is not
+			if ctx.Err() != nil || attempt >= c.config.MaxRetries {
+				return nil, err
+			}
+			if err := c.sleep(ctx, c.backoff(attempt, "")); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if response.StatusCode < 400 {
+			return response, nil
+		}
+
+		apiErr := readError(response)
+		if !retryable(response.StatusCode) || attempt >= c.config.MaxRetries {
+			return nil, apiErr
+		}
+		if err := c.sleep(ctx, c.backoff(attempt, response.Header.Get("Retry-After"))); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// readError decodes and closes an error response
+func readError(response *http.Response) *Error {
+	defer response.Body.Close()
+
+	data, _ := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+
+	var problem models.ProblemDetails
+	var envelope models.APIResponse
+	_ = json.Unmarshal(data, &problem)
+	_ = json.Unmarshal(data, &envelope)
+
+	apiErr := newError(response.StatusCode, problem, envelope)
+	apiErr.body = data
+	return apiErr
+}
+
+// retryable reports whether a status is worth retrying
+func retryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// backoff returns the delay before retry attempt+1, honouring Retry-After when the server sent one
+func (c *Client) backoff(attempt int, retryAfter string) time.Duration {
+	if delay, ok := parseRetryAfter(retryAfter); ok {
+		if c.config.MaxBackoff > 0 && delay > c.config.MaxBackoff {
+			return c.config.MaxBackoff
+		}
+		return delay
+	}
+
+	delay := c.config.BaseBackoff << uint(attempt)
+	if delay <= 0 || (c.config.MaxBackoff > 0 && delay > c.config.MaxBackoff) {
+		delay = c.config.MaxBackoff
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	// Full jitter spreads out clients that failed at the same moment
+	return time.Duration(mathrand.Int63n(int64(delay))) // #nosec G404 -- jitter does not need crypto randomness
+}
+
+// parseRetryAfter reads a Retry-After value in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// sleepContext waits for delay or until ctx is done
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// newIdempotencyKey returns a random key for one logical POST
+func newIdempotencyKey() string {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(key)
+}
+
+// escape escapes an ID for use as a path segment
+func escape(id string) string {
+	return url.PathEscape(id)
+}
+
+// Health calls GET /health and returns nil when the server is healthy
+func (c *Client) Health(ctx context.Context) error {
+	return c.do(ctx, request{method: http.MethodGet, path: "/health"}, nil)
+}
+
+// OpenAPI returns the server's OpenAPI document from GET /openapi.json
+func (c *Client) OpenAPI(ctx context.Context) (json.RawMessage, error) {
+	var document json.RawMessage
+	if err := c.do(ctx, request{method: http.MethodGet, path: "/openapi.json"}, &document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
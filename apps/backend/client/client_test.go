@@ -0,0 +1,615 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"financial-aggregator-api/backend/internal"
+	"financial-aggregator-api/backend/models"
+)
+
+// newTestClient returns a client for a fresh server, wrapping its handler with wrap if given
+func newTestClient(t *testing.T, wrap func(http.Handler) http.Handler) (*Client, *[]time.Duration) {
+	t.Helper()
+
+	// Each server gets its own audit log and attachments, kept out of the source tree
+	dir := t.TempDir()
+	t.Setenv("AUDIT_LOG_PATH", filepath.Join(dir, "audit.jsonl"))
+	t.Setenv("ATTACHMENT_DIR", filepath.Join(dir, "attachments"))
+	t.Setenv("WEBHOOK_ALLOW_PRIVATE_HOSTS", "true")
+
+	var handler http.Handler = internal.NewServer().GetRouter()
+	if wrap != nil {
+		handler = wrap(handler)
+	}
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	// Record retry delays instead of sleeping
+	var delays []time.Duration
+	c := NewClient(DefaultConfig(server.URL))
+	c.sleep = func(ctx context.Context, delay time.Duration) error {
+		delays = append(delays, delay)
+		return ctx.Err()
+	}
+	return c, &delays
+}
+
+func TestClient_Accounts(t *testing.T) {
+	c, _ := newTestClient(t, nil)
+	ctx := context.Background()
+
+	accounts, err := c.ListAccounts(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 7 {
+		t.Errorf("Expected 7 accounts, got %d", len(accounts))
+	}
+
+	account, err := c.GetAccount(ctx, "acc_003")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.Balance.Amount != "-1200.50" || account.CreditLimit == nil {
+		t.Errorf("Unexpected account: %+v", account)
+	}
+
+	refreshed, err := c.RefreshAccountAtVersion(ctx, "acc_001", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if refreshed.ID != "acc_001" || refreshed.Version != 2 {
+		t.Errorf("Expected acc_001 at version 2, got %+v", refreshed)
+	}
+	if _, err := c.RefreshAccountAtVersion(ctx, "acc_001", 1); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("Expected version_conflict for a stale refresh, got %v", err)
+	}
+
+	_, err = c.GetAccount(ctx, "missing")
+	if !errors.Is(err, ErrAccountNotFound) || !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected account_not_found, got %v", err)
+	}
+}
+
+func TestClient_TransactionIterators(t *testing.T) {
+	c, _ := newTestClient(t, nil)
+	ctx := context.Background()
+
+	seen := make(map[string]bool)
+	it := c.Transactions(&TransactionQuery{Limit: 3})
+	for it.Next(ctx) {
+		transaction := it.Value()
+		if seen[transaction.ID] {
+			t.Errorf("Transaction %s returned twice", transaction.ID)
+		}
+		seen[transaction.ID] = true
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(seen) != 16 {
+		t.Errorf("Expected 16 transactions, got %d", len(seen))
+	}
+
+	count := 0
+	accountIt := c.AccountTransactions("acc_001", 2)
+	for accountIt.Next(ctx) {
+		if accountIt.Value().AccountID != "acc_001" {
+			t.Errorf("Expected only acc_001 transactions, got %s", accountIt.Value().AccountID)
+		}
+		count++
+	}
+	if accountIt.Err() != nil || count != 5 {
+		t.Errorf("Expected 5 acc_001 transactions, got %d (%v)", count, accountIt.Err())
+	}
+
+	transaction, err := c.GetTransaction(ctx, "txn_002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transaction.Amount.Amount != "5000.00" {
+		t.Errorf("Expected amount 5000.00, got %s", transaction.Amount.Amount)
+	}
+
+	// Updates carry the version last read; a stale version is a conflict
+	if _, err := c.CreateCategory(ctx, models.CategoryRequest{Name: "Payroll", ParentID: "income"}); err != nil {
+		t.Fatal(err)
+	}
+	category := "payroll"
+	updated, err := c.UpdateTransaction(ctx, "txn_002", models.TransactionUpdate{Version: transaction.Version, Category: &category})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Category != "payroll" || updated.Version != transaction.Version+1 {
+		t.Errorf("Expected payroll at the next version, got %+v", updated)
+	}
+	_, err = c.UpdateTransaction(ctx, "txn_002", models.TransactionUpdate{Version: transaction.Version, Category: &category})
+	if !errors.Is(err, ErrVersionConflict) || !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected version_conflict, got %v", err)
+	}
+
+	// Validation errors carry the server's field errors
+	_, err = c.ListTransactions(ctx, &TransactionQuery{Type: "refund"})
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || !errors.Is(err, ErrValidation) {
+		t.Fatalf("Expected a validation error, got %v", err)
+	}
+	if len(apiErr.Fields) != 1 || apiErr.Fields[0].Field != "type" {
+		t.Errorf("Expected a type field error, got %+v", apiErr.Fields)
+	}
+}
+
+func TestClient_WebhooksAlertsAudit(t *testing.T) {
+	c, _ := newTestClient(t, nil)
+	ctx := context.Background()
+
+	webhook, err := c.CreateWebhook(ctx, models.WebhookSubscriptionRequest{
+		URL:        "http://127.0.0.1:1/hook",
+		EventTypes: []string{models.EventTransactionCreated},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if webhook.Secret == "" {
+		t.Error("Expected the created webhook to include its secret")
+	}
+
+	if _, err := c.SendWebhookTest(ctx, webhook.ID); err != nil {
+		t.Fatal(err)
+	}
+	deliveries, err := c.ListWebhookDeliveries(ctx, webhook.ID)
+	if err != nil || len(deliveries) != 1 {
+		t.Fatalf("Expected one delivery, got %d (%v)", len(deliveries), err)
+	}
+	if err := c.DeleteWebhook(ctx, webhook.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetWebhook(ctx, webhook.ID); !errors.Is(err, ErrWebhookNotFound) {
+		t.Errorf("Expected webhook_not_found after delete, got %v", err)
+	}
+
+	rule, err := c.CreateAlertRule(ctx, models.AlertRuleRequest{Name: "Low balance", Type: models.AlertBalanceBelow, Threshold: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules, err := c.ListAlertRules(ctx)
+	if err != nil || len(rules) != 1 || rules[0].ID != rule.ID {
+		t.Errorf("Expected the created rule, got %+v (%v)", rules, err)
+	}
+	if err := c.DeleteAlertRule(ctx, rule.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// Refreshing three accounts records three audit entries, read back two per page
+	for _, id := range []string{"acc_001", "acc_002", "acc_003"} {
+		if _, err := c.RefreshAccount(ctx, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entries := 0
+	auditIt := c.AuditEntries(models.AuditFilter{Action: models.AuditAccountRefreshed, Limit: 2})
+	for auditIt.Next(ctx) {
+		entries++
+	}
+	if auditIt.Err() != nil || entries != 3 {
+		t.Errorf("Expected 3 audit entries, got %d (%v)", entries, auditIt.Err())
+	}
+
+	verification, err := c.VerifyAuditLog(ctx)
+	if err != nil || !verification.Valid {
+		t.Errorf("Expected a valid audit chain, got %+v (%v)", verification, err)
+	}
+}
+
+func TestClient_Analytics(t *testing.T) {
+	c, _ := newTestClient(t, nil)
+	ctx := context.Background()
+
+	today := time.Now().UTC()
+	report, err := c.Cashflow(ctx, models.CashflowQuery{
+		From:    today.AddDate(0, 0, -30),
+		To:      today,
+		GroupBy: models.CashflowByMonth,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.GroupBy != models.CashflowByMonth || len(report.Buckets) == 0 || report.Totals.Count == 0 {
+		t.Errorf("Expected monthly buckets over the mock transactions, got %+v", report)
+	}
+
+	_, err = c.Cashflow(ctx, models.CashflowQuery{From: today, To: today.AddDate(0, 0, -1)})
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected a validation error when to is before from, got %v", err)
+	}
+
+	merchants, err := c.ListMerchants(ctx, models.MerchantFilter{Limit: 3})
+	if err != nil || len(merchants) != 3 || merchants[0].Merchant == "" {
+		t.Errorf("Expected the three biggest merchants, got %+v (%v)", merchants, err)
+	}
+}
+
+func TestClient_Investments(t *testing.T) {
+	c, _ := newTestClient(t, nil)
+	ctx := context.Background()
+
+	holdings, err := c.GetHoldings(ctx, "acc_004")
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := c.GetAccount(ctx, "acc_004")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := strconv.FormatFloat(holdings.TotalValue, 'f', 2, 64); account.Balance.Amount != want {
+		t.Errorf("Expected the balance to be the holdings total %s, got %s", want, account.Balance.Amount)
+	}
+
+	if _, err := c.GetHoldings(ctx, "acc_001"); !errors.Is(err, ErrNotInvestmentAccount) {
+		t.Errorf("Expected not_investment_account, got %v", err)
+	}
+
+	performance, err := c.GetPerformance(ctx, "acc_004", models.PerformanceQuery{Method: models.CostBasisAverage})
+	if err != nil || performance.Method != models.CostBasisAverage || len(performance.Securities) == 0 {
+		t.Errorf("Expected average cost performance, got %+v (%v)", performance, err)
+	}
+	if _, err := c.GetPerformance(ctx, "acc_004", models.PerformanceQuery{Method: "lifo"}); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected a validation error for an unknown method, got %v", err)
+	}
+}
+
+func TestClient_Liabilities(t *testing.T) {
+	c, _ := newTestClient(t, nil)
+	ctx := context.Background()
+
+	report, err := c.ListLiabilities(ctx)
+	if err != nil || len(report.Liabilities) != 2 {
+		t.Fatalf("Expected the card and the loan, got %+v (%v)", report, err)
+	}
+
+	loan, err := c.GetLiability(ctx, "acc_007")
+	if err != nil {
+		t.Fatal(err)
+	}
+	extra := 0.0
+	updated, err := c.UpdateLiability(ctx, "acc_007", models.LiabilityUpdate{Version: loan.Version, MinimumPayment: &extra})
+	if err != nil || updated.Version != loan.Version+1 || updated.MinimumPayment != 0 {
+		t.Errorf("Expected the payment to be cleared, got %+v (%v)", updated, err)
+	}
+	if _, err := c.UpdateLiability(ctx, "acc_007", models.LiabilityUpdate{Version: loan.Version, MinimumPayment: &extra}); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("Expected version_conflict, got %v", err)
+	}
+
+	// Without a minimum payment the schedule falls back to the principal and term
+	schedule, err := c.GetAmortization(ctx, "acc_007", models.AmortizationQuery{ExtraPayment: 50})
+	if err != nil || schedule.Payment <= 0 || schedule.ExtraPayment != 50 || schedule.InterestSaved == nil {
+		t.Errorf("Unexpected schedule %+v (%v)", schedule, err)
+	}
+	if _, err := c.GetAmortization(ctx, "acc_003", models.AmortizationQuery{}); !errors.Is(err, ErrNotLoanAccount) {
+		t.Errorf("Expected not_loan_account, got %v", err)
+	}
+	if _, err := c.GetLiability(ctx, "acc_001"); !errors.Is(err, ErrNotLiabilityAccount) {
+		t.Errorf("Expected not_liability_account, got %v", err)
+	}
+}
+
+func TestClient_Forecast(t *testing.T) {
+	c, _ := newTestClient(t, nil)
+	ctx := context.Background()
+
+	start := time.Now().AddDate(0, 0, 1)
+	item, err := c.CreateScheduledItem(ctx, models.ScheduledItemRequest{
+		AccountID: "acc_001", Description: "Tuition", Amount: -5000, Frequency: models.FrequencyOnce, StartDate: start,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	forecast, err := c.GetForecast(ctx, models.ForecastQuery{Days: 30, AccountID: "acc_001"})
+	if err != nil || len(forecast.Accounts) != 1 {
+		t.Fatalf("Expected a forecast for acc_001, got %+v (%v)", forecast, err)
+	}
+	if account := forecast.Accounts[0]; account.FirstBelowZero != start.Format("2006-01-02") || len(account.Days) != 30 {
+		t.Errorf("Expected acc_001 below zero tomorrow, got %+v", account)
+	}
+
+	if items, err := c.ListScheduledItems(ctx); err != nil || len(items) != 1 {
+		t.Errorf("Expected one scheduled item, got %+v (%v)", items, err)
+	}
+	if err := c.DeleteScheduledItem(ctx, item.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteScheduledItem(ctx, item.ID); !errors.Is(err, ErrScheduledItemNotFound) {
+		t.Errorf("Expected scheduled_item_not_found, got %v", err)
+	}
+}
+
+func TestClient_Goals(t *testing.T) {
+	c, _ := newTestClient(t, nil)
+	ctx := context.Background()
+
+	request := models.GoalRequest{Name: "Emergency fund", TargetAmount: 30000, Deadline: time.Now().AddDate(0, 8, 0), AccountIDs: []string{"acc_006"}}
+	goal, err := c.CreateGoal(ctx, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if goal.Progress.Remaining != 5000 || goal.Progress.RequiredMonthlyContribution <= 0 {
+		t.Errorf("Unexpected progress %+v", goal.Progress)
+	}
+
+	request.TargetAmount = 20000
+	updated, err := c.UpdateGoal(ctx, goal.ID, request)
+	if err != nil || updated.Progress.Status != models.GoalAchieved {
+		t.Errorf("Expected a lower target to be achieved, got %+v (%v)", updated, err)
+	}
+	if goals, err := c.ListGoals(ctx); err != nil || len(goals) != 1 {
+		t.Errorf("Expected one goal, got %+v (%v)", goals, err)
+	}
+	if history, err := c.GetGoalHistory(ctx, goal.ID); err != nil || len(history) != 1 || history[0].PercentComplete != 100 {
+		t.Errorf("Expected today's snapshot at 100%%, got %+v (%v)", history, err)
+	}
+
+	if err := c.DeleteGoal(ctx, goal.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetGoal(ctx, goal.ID); !errors.Is(err, ErrGoalNotFound) {
+		t.Errorf("Expected goal_not_found, got %v", err)
+	}
+}
+
+func TestClient_Categories(t *testing.T) {
+	c, _ := newTestClient(t, nil)
+	ctx := context.Background()
+
+	category, err := c.CreateCategory(ctx, models.CategoryRequest{Name: "Side gigs", ParentID: "income"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if category.ID != "side_gigs" || category.Kind != models.CategoryIncome {
+		t.Errorf("Unexpected category %+v", category)
+	}
+	if _, err := c.CreateCategory(ctx, models.CategoryRequest{Name: "Side gigs", ParentID: "income"}); !errors.Is(err, ErrCategoryExists) || !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected category_exists, got %v", err)
+	}
+
+	// Renaming business moves its transaction along
+	newID := "freelance"
+	if _, err := c.UpdateCategory(ctx, "business", models.CategoryUpdate{ID: &newID}); err != nil {
+		t.Fatal(err)
+	}
+	if transaction, err := c.GetTransaction(ctx, "txn_008"); err != nil || transaction.Category != "freelance" {
+		t.Errorf("Expected txn_008 in freelance, got %+v (%v)", transaction, err)
+	}
+
+	result, err := c.MergeCategory(ctx, "freelance", "side_gigs")
+	if err != nil || result.TransactionsUpdated != 1 {
+		t.Errorf("Expected one transaction merged, got %+v (%v)", result, err)
+	}
+	if err := c.DeleteCategory(ctx, "side_gigs"); !errors.Is(err, ErrCategoryInUse) {
+		t.Errorf("Expected category_in_use, got %v", err)
+	}
+	if err := c.DeleteCategory(ctx, "refunds"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetCategory(ctx, "refunds"); !errors.Is(err, ErrCategoryNotFound) {
+		t.Errorf("Expected category_not_found, got %v", err)
+	}
+	if categories, err := c.ListCategories(ctx); err != nil || categories[0].ID != "income" {
+		t.Errorf("Expected the tree to start with income, got %+v (%v)", categories, err)
+	}
+
+	today := time.Now().UTC()
+	report, err := c.CategoryReport(ctx, models.CategoryReportQuery{From: today.AddDate(0, 0, -10), To: today})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Categories) == 0 || report.Categories[0].ID != "income" || report.Totals.Income != 7650 {
+		t.Errorf("Unexpected report %+v", report)
+	}
+}
+
+func TestClient_Reconciliation(t *testing.T) {
+	c, _ := newTestClient(t, nil)
+	ctx := context.Background()
+
+	reconciliation, err := c.GetReconciliation(ctx, "acc_002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reconciliation.Status != models.ReconciliationBalanced || reconciliation.Anchor.Source != models.AnchorOpening {
+		t.Errorf("Expected a balanced account at startup, got %+v", reconciliation)
+	}
+
+	// The mock refresh moves the balance without adding a transaction
+	refreshed, err := c.RefreshAccount(ctx, "acc_002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reconciliation, err = c.GetReconciliation(ctx, "acc_002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strconv.FormatFloat(reconciliation.ReportedBalance, 'f', 2, 64) != refreshed.Balance.Amount || (reconciliation.Discrepancy != 0) != (reconciliation.DiscrepancySince != nil) {
+		t.Errorf("Unexpected reconciliation after a refresh %+v", reconciliation)
+	}
+
+	balance := reconciliation.Anchor.Balance
+	statement, err := c.ReconcileStatement(ctx, "acc_002", models.StatementRequest{EndDate: time.Now().UTC().AddDate(0, 0, -10).Format("2006-01-02"), Balance: &balance})
+	if err != nil || statement.Adjustment != 0 {
+		t.Errorf("Expected a statement without adjustment, got %+v (%v)", statement, err)
+	}
+	if statements, err := c.ListStatements(ctx, "acc_002"); err != nil || len(statements) != 1 {
+		t.Errorf("Expected one statement, got %+v (%v)", statements, err)
+	}
+	if _, err := c.GetReconciliation(ctx, "acc_004"); !errors.Is(err, ErrNotReconcilableAccount) || !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected not_reconcilable_account, got %v", err)
+	}
+}
+
+func TestClient_TagsAndAttachments(t *testing.T) {
+	c, _ := newTestClient(t, nil)
+	ctx := context.Background()
+
+	tags := []string{"Work", "reimbursable"}
+	notes := "Client dinner"
+	updated, err := c.UpdateTransaction(ctx, "txn_003", models.TransactionUpdate{Version: 1, Tags: &tags, Notes: &notes})
+	if err != nil || len(updated.Tags) != 2 || updated.Tags[1] != "work" || updated.Notes != notes {
+		t.Fatalf("Unexpected update %+v (%v)", updated, err)
+	}
+	page, err := c.ListTransactions(ctx, &TransactionQuery{Tag: "work"})
+	if err != nil || len(page.Transactions) != 1 || page.Transactions[0].ID != "txn_003" {
+		t.Errorf("Expected only txn_003 tagged work, got %+v (%v)", page, err)
+	}
+
+	receipt := "%PDF-1.7\n%dinner\n"
+	attachment, err := c.UploadAttachment(ctx, "txn_003", "dinner.pdf", strings.NewReader(receipt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attachment.ContentType != "application/pdf" || attachment.Size != int64(len(receipt)) {
+		t.Errorf("Unexpected attachment %+v", attachment)
+	}
+
+	content, err := c.GetAttachment(ctx, "txn_003", attachment.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(content.Body)
+	content.Body.Close()
+	if string(data) != receipt || content.FileName != "dinner.pdf" || content.ContentType != "application/pdf" {
+		t.Errorf("Unexpected content %q of %+v", data, content)
+	}
+
+	if err := c.DeleteAttachment(ctx, "txn_003", attachment.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetAttachment(ctx, "txn_003", attachment.ID); !errors.Is(err, ErrAttachmentNotFound) {
+		t.Errorf("Expected attachment_not_found, got %v", err)
+	}
+	if _, err := c.UploadAttachment(ctx, "txn_003", "notes.txt", strings.NewReader("plain text")); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected a validation error for text, got %v", err)
+	}
+}
+
+func TestClient_Retries(t *testing.T) {
+	var mutex sync.Mutex
+	failures := map[string]int{}
+	keys := map[string][]string{}
+
+	// Fail the first two attempts at each path: one 503, then one 429 with Retry-After
+	c, delays := newTestClient(t, func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			attempt := failures[r.URL.Path]
+			failures[r.URL.Path]++
+			keys[r.URL.Path] = append(keys[r.URL.Path], r.Header.Get("Idempotency-Key"))
+			mutex.Unlock()
+
+			switch attempt {
+			case 0:
+				w.WriteHeader(http.StatusServiceUnavailable)
+			case 1:
+				w.Header().Set("Retry-After", "2")
+				w.WriteHeader(http.StatusTooManyRequests)
+			default:
+				next.ServeHTTP(w, r)
+			}
+		})
+	})
+	ctx := context.Background()
+
+	if _, err := c.RefreshAccount(ctx, "acc_001"); err != nil {
+		t.Fatalf("Expected the refresh to succeed after retries, got %v", err)
+	}
+
+	path := "/api/v2/accounts/acc_001/refresh"
+	if failures[path] != 3 {
+		t.Errorf("Expected 3 attempts, got %d", failures[path])
+	}
+	if key := keys[path][0]; key == "" || keys[path][1] != key || keys[path][2] != key {
+		t.Errorf("Expected every attempt to send the same Idempotency-Key, got %v", keys[path])
+	}
+	if len(*delays) != 2 || (*delays)[1] != 2*time.Second {
+		t.Errorf("Expected a backoff then the Retry-After delay, got %v", *delays)
+	}
+
+	// With retries disabled the first failure is returned as is
+	c.config.MaxRetries = 0
+	_, err := c.GetAccount(ctx, "acc_002")
+	if !errors.Is(err, ErrUpstreamUnavailable) || failures["/api/v2/accounts/acc_002"] != 1 {
+		t.Errorf("Expected one failed attempt, got %d (%v)", failures["/api/v2/accounts/acc_002"], err)
+	}
+}
+
+func TestClient_StreamEvents(t *testing.T) {
+	c, _ := newTestClient(t, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := c.StreamEvents(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stream.Close()
+
+	if _, err := c.RefreshAccount(ctx, "acc_002"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The transaction behind the balance change, if any, comes first
+	if !stream.Next() {
+		t.Fatalf("Expected an event, got %v", stream.Err())
+	}
+	event := stream.Event()
+	if event.Type == models.EventTransactionCreated {
+		if !stream.Next() {
+			t.Fatalf("Expected an event, got %v", stream.Err())
+		}
+		event = stream.Event()
+	}
+	if event.Type != models.EventAccountUpdated {
+		t.Fatalf("Expected account.updated, got %s", event.Type)
+	}
+
+	var account models.Account
+	if err := json.Unmarshal(event.Data.(json.RawMessage), &account); err != nil || account.ID != "acc_002" {
+		t.Errorf("Expected acc_002 in event data, got %+v (%v)", account, err)
+	}
+	if stream.LastEventID() != event.ID {
+		t.Errorf("Expected LastEventID %d, got %d", event.ID, stream.LastEventID())
+	}
+}
+
+func TestClient_GraphQL(t *testing.T) {
+	c, _ := newTestClient(t, nil)
+	ctx := context.Background()
+
+	var data struct {
+		Account struct {
+			Name string `json:"name"`
+		} `json:"account"`
+	}
+	err := c.GraphQL(ctx, `query($id: ID!) { account(id: $id) { name } }`, map[string]interface{}{"id": "acc_006"}, &data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Account.Name != "Emergency Fund" {
+		t.Errorf("Expected Emergency Fund, got %q", data.Account.Name)
+	}
+
+	var graphQLErr *GraphQLError
+	if err := c.GraphQL(ctx, `{ nope }`, nil, nil); !errors.As(err, &graphQLErr) {
+		t.Errorf("Expected a GraphQLError, got %v", err)
+	}
+}
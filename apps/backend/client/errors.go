@@ -0,0 +1,114 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// Error kinds, mirroring the server's. Every *Error wraps one of these (when its status
+// has a kind), so callers can branch with errors.Is without matching on codes.
+var (
+	ErrNotFound            = errors.New("not found")
+	ErrConflict            = errors.New("conflict")
+	ErrValidation          = errors.New("validation failed")
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+	ErrRateLimited         = errors.New("rate limited")
+)
+
+// Error is an error response from the API
+type Error struct {
+	StatusCode int
+	Code       string // stable identifier such as account_not_found
+	Message    string
+	Fields     []models.FieldError // invalid fields, for validation errors
+
+	body []byte // raw response, for endpoints with their own error format
+}
+
+// Error returns the status, code and message
+func (e *Error) Error() string {
+	return fmt.Sprintf("finagg: %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// Unwrap exposes the error kind implied by the status code
+func (e *Error) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusBadRequest:
+		return ErrValidation
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict, http.StatusPreconditionFailed, http.StatusUnprocessableEntity:
+		return ErrConflict
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return ErrUpstreamUnavailable
+	default:
+		return nil
+	}
+}
+
+// Is matches errors of the same code, so errors.Is(err, ErrAccountNotFound) works
+func (e *Error) Is(target error) bool {
+	other, ok := target.(*Error)
+	return ok && other.Code == e.Code
+}
+
+// Errors for each code the server returns
+var (
+	ErrInvalidRequest             = &Error{Code: "invalid_request"}
+	ErrValidationFailed           = &Error{Code: "validation_failed"}
+	ErrResourceNotFound           = &Error{Code: "not_found"}
+	ErrAccountNotFound            = &Error{Code: "account_not_found"}
+	ErrTransactionNotFound        = &Error{Code: "transaction_not_found"}
+	ErrAttachmentNotFound         = &Error{Code: "attachment_not_found"}
+	ErrWebhookNotFound            = &Error{Code: "webhook_not_found"}
+	ErrAlertRuleNotFound          = &Error{Code: "alert_rule_not_found"}
+	ErrScheduledItemNotFound      = &Error{Code: "scheduled_item_not_found"}
+	ErrGoalNotFound               = &Error{Code: "goal_not_found"}
+	ErrCategoryNotFound           = &Error{Code: "category_not_found"}
+	ErrResourceConflict           = &Error{Code: "conflict"}
+	ErrTransactionExists          = &Error{Code: "transaction_exists"}
+	ErrCategoryExists             = &Error{Code: "category_exists"}
+	ErrCategoryInUse              = &Error{Code: "category_in_use"}
+	ErrPreconditionFailed         = &Error{Code: "precondition_failed"}
+	ErrVersionConflict            = &Error{Code: "version_conflict"}
+	ErrNotInvestmentAccount       = &Error{Code: "not_investment_account"}
+	ErrNotLiabilityAccount        = &Error{Code: "not_liability_account"}
+	ErrNotLoanAccount             = &Error{Code: "not_loan_account"}
+	ErrNotReconcilableAccount     = &Error{Code: "not_reconcilable_account"}
+	ErrIdempotencyKeyInvalid      = &Error{Code: "idempotency_key_invalid"}
+	ErrIdempotencyKeyReused       = &Error{Code: "idempotency_key_reused"}
+	ErrIdempotencyKeyInUse        = &Error{Code: "idempotency_key_in_use"}
+	ErrRequestTooLarge            = &Error{Code: "request_too_large"}
+	ErrTooManyRequests            = &Error{Code: "rate_limited"}
+	ErrServiceUnavailable         = &Error{Code: "upstream_unavailable"}
+	ErrProviderUnavailable        = &Error{Code: "provider_unavailable"}
+	ErrAttachmentStoreUnavailable = &Error{Code: "attachment_store_unavailable"}
+	ErrInternal                   = &Error{Code: "internal_error"}
+)
+
+// newError builds an Error from a problem details or envelope response body
+func newError(statusCode int, problem models.ProblemDetails, envelope models.APIResponse) *Error {
+	err := &Error{StatusCode: statusCode, Code: problem.Code, Message: problem.Detail, Fields: problem.Errors}
+
+	if err.Code == "" {
+		err.Code = envelope.Code
+	}
+	if err.Message == "" {
+		err.Message = problem.Title
+	}
+	if err.Message == "" {
+		err.Message = envelope.Error
+	}
+	if err.Message == "" {
+		err.Message = http.StatusText(statusCode)
+	}
+	if len(err.Fields) == 0 {
+		err.Fields = envelope.Errors
+	}
+	return err
+}
@@ -0,0 +1,102 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// EventStream reads change events from GET /api/v2/events
+type EventStream struct {
+	body        io.ReadCloser
+	scanner     *bufio.Scanner
+	event       models.Event
+	lastEventID uint64
+	err         error
+}
+
+// StreamEvents opens the event stream. A non-zero lastEventID resumes after that event
+// if the server still has it buffered. The stream ends when ctx is cancelled or Close is
+// called; reconnect with LastEventID to resume.
+func (c *Client) StreamEvents(ctx context.Context, lastEventID uint64) (*EventStream, error) {
+	header := http.Header{}
+	if lastEventID > 0 {
+		header.Set("Last-Event-ID", strconv.FormatUint(lastEventID, 10))
+	}
+
+	response, err := c.send(ctx, request{
+		method: http.MethodGet,
+		path:   apiPrefix + "/events",
+		accept: "text/event-stream",
+		header: header,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(response.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+
+	return &EventStream{body: response.Body, scanner: scanner, lastEventID: lastEventID}, nil
+}
+
+// Next blocks until the next event arrives. It returns false when the stream ends.
+func (s *EventStream) Next() bool {
+	var data strings.Builder
+	for s.scanner.Scan() {
+		line := s.scanner.Text()
+
+		if line == "" {
+			// A blank line ends the event; comments and retry hints carry no data
+			if data.Len() == 0 {
+				continue
+			}
+			var event struct {
+				models.Event
+				Data json.RawMessage `json:"data"`
+			}
+			if err := json.Unmarshal([]byte(data.String()), &event); err != nil {
+				s.err = err
+				return false
+			}
+			s.event = event.Event
+			s.event.Data = event.Data
+			s.lastEventID = event.ID
+			return true
+		}
+
+		if value, found := strings.CutPrefix(line, "data:"); found {
+			data.WriteString(strings.TrimPrefix(value, " "))
+		}
+	}
+
+	s.err = s.scanner.Err()
+	return false
+}
+
+// Event returns the current event. Its Data is a json.RawMessage to unmarshal into the
+// model matching Type, such as models.Account for account.updated.
+func (s *EventStream) Event() models.Event {
+	return s.event
+}
+
+// LastEventID returns the ID of the last event read, for resuming with StreamEvents
+func (s *EventStream) LastEventID() uint64 {
+	return s.lastEventID
+}
+
+// Err returns the error that ended the stream, if any
+func (s *EventStream) Err() error {
+	return s.err
+}
+
+// Close ends the stream
+func (s *EventStream) Close() error {
+	return s.body.Close()
+}
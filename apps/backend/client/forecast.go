@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// GetForecast calls GET /api/v2/forecast. A zero query.Days uses the server default of 90.
+func (c *Client) GetForecast(ctx context.Context, query models.ForecastQuery) (*models.Forecast, error) {
+	values := url.Values{}
+	if query.Days > 0 {
+		values.Set("days", strconv.Itoa(query.Days))
+	}
+	if query.Threshold > 0 {
+		values.Set("threshold", strconv.FormatFloat(query.Threshold, 'f', -1, 64))
+	}
+	setString(values, "account_id", query.AccountID)
+
+	var forecast models.Forecast
+	response := models.APIResponse{Data: &forecast}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/forecast", query: values}, &response); err != nil {
+		return nil, err
+	}
+	return &forecast, nil
+}
+
+// ListScheduledItems calls GET /api/v2/forecast/items
+func (c *Client) ListScheduledItems(ctx context.Context) ([]*models.ScheduledItem, error) {
+	var items []*models.ScheduledItem
+	response := models.APIResponse{Data: &items}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/forecast/items"}, &response); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// CreateScheduledItem calls POST /api/v2/forecast/items
+func (c *Client) CreateScheduledItem(ctx context.Context, item models.ScheduledItemRequest) (*models.ScheduledItem, error) {
+	var created models.ScheduledItem
+	response := models.APIResponse{Data: &created}
+	if err := c.do(ctx, request{method: http.MethodPost, path: apiPrefix + "/forecast/items", body: item}, &response); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// DeleteScheduledItem calls DELETE /api/v2/forecast/items/{id}
+func (c *Client) DeleteScheduledItem(ctx context.Context, id string) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: apiPrefix + "/forecast/items/" + escape(id)}, nil)
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// ListGoals calls GET /api/v2/goals
+func (c *Client) ListGoals(ctx context.Context) ([]models.Goal, error) {
+	var goals []models.Goal
+	response := models.APIResponse{Data: &goals}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/goals"}, &response); err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
+
+// CreateGoal calls POST /api/v2/goals
+func (c *Client) CreateGoal(ctx context.Context, goal models.GoalRequest) (*models.Goal, error) {
+	return c.goal(ctx, request{method: http.MethodPost, path: apiPrefix + "/goals", body: goal})
+}
+
+// GetGoal calls GET /api/v2/goals/{id}
+func (c *Client) GetGoal(ctx context.Context, id string) (*models.Goal, error) {
+	return c.goal(ctx, request{method: http.MethodGet, path: apiPrefix + "/goals/" + escape(id)})
+}
+
+// UpdateGoal calls PUT /api/v2/goals/{id}
+func (c *Client) UpdateGoal(ctx context.Context, id string, goal models.GoalRequest) (*models.Goal, error) {
+	return c.goal(ctx, request{method: http.MethodPut, path: apiPrefix + "/goals/" + escape(id), body: goal})
+}
+
+// DeleteGoal calls DELETE /api/v2/goals/{id}
+func (c *Client) DeleteGoal(ctx context.Context, id string) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: apiPrefix + "/goals/" + escape(id)}, nil)
+}
+
+// GetGoalHistory calls GET /api/v2/goals/{id}/history
+func (c *Client) GetGoalHistory(ctx context.Context, id string) ([]models.GoalSnapshot, error) {
+	var history []models.GoalSnapshot
+	response := models.APIResponse{Data: &history}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/goals/" + escape(id) + "/history"}, &response); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// goal performs req and decodes a single goal
+func (c *Client) goal(ctx context.Context, req request) (*models.Goal, error) {
+	var goal models.Goal
+	response := models.APIResponse{Data: &goal}
+	if err := c.do(ctx, req, &response); err != nil {
+		return nil, err
+	}
+	return &goal, nil
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// GraphQLError lists the errors of a GraphQL response
+type GraphQLError struct {
+	Messages []string
+}
+
+// Error joins the messages
+func (e *GraphQLError) Error() string {
+	return "finagg: graphql: " + strings.Join(e.Messages, "; ")
+}
+
+// graphQLResponse is the body returned by /graphql
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// GraphQL runs query against POST /graphql and unmarshals its data into out. When the
+// server returns partial data with errors, out is filled and a *GraphQLError is returned.
+func (c *Client) GraphQL(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	body := map[string]interface{}{"query": query}
+	if len(variables) > 0 {
+		body["variables"] = variables
+	}
+
+	var response graphQLResponse
+	err := c.do(ctx, request{method: http.MethodPost, path: "/graphql", body: body}, &response)
+
+	// Rejected queries come back as 400 with GraphQL errors rather than problem details
+	var apiErr *Error
+	if errors.As(err, &apiErr) && apiErr.Code == "" {
+		if json.Unmarshal(apiErr.body, &response) != nil || len(response.Errors) == 0 {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	if out != nil && len(response.Data) > 0 && string(response.Data) != "null" {
+		if err := json.Unmarshal(response.Data, out); err != nil {
+			return err
+		}
+	}
+
+	if len(response.Errors) > 0 {
+		graphQLErr := &GraphQLError{}
+		for _, item := range response.Errors {
+			graphQLErr.Messages = append(graphQLErr.Messages, item.Message)
+		}
+		return graphQLErr
+	}
+	return nil
+}
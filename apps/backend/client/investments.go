@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// GetHoldings calls GET /api/v2/accounts/{id}/holdings
+func (c *Client) GetHoldings(ctx context.Context, accountID string) (*models.Holdings, error) {
+	var holdings models.Holdings
+	response := models.APIResponse{Data: &holdings}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/accounts/" + escape(accountID) + "/holdings"}, &response); err != nil {
+		return nil, err
+	}
+	return &holdings, nil
+}
+
+// GetPerformance calls GET /api/v2/accounts/{id}/performance. Zero dates and an empty Method
+// are not sent, so the server defaults to the whole history with FIFO lot matching.
+func (c *Client) GetPerformance(ctx context.Context, accountID string, query models.PerformanceQuery) (*models.Performance, error) {
+	values := url.Values{}
+	if !query.From.IsZero() {
+		values.Set("from", query.From.Format("2006-01-02"))
+	}
+	if !query.To.IsZero() {
+		values.Set("to", query.To.Format("2006-01-02"))
+	}
+	setString(values, "method", query.Method)
+
+	var performance models.Performance
+	response := models.APIResponse{Data: &performance}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/accounts/" + escape(accountID) + "/performance", query: values}, &response); err != nil {
+		return nil, err
+	}
+	return &performance, nil
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+)
+
+// Iterator walks every item of a paginated listing, fetching pages as it goes:
+//
+//	it := c.Transactions(&client.TransactionQuery{AccountID: "acc_001"})
+//	for it.Next(ctx) {
+//		transaction := it.Value()
+//	}
+//	if err := it.Err(); err != nil { ... }
+type Iterator[T any] struct {
+	fetch func(ctx context.Context) (items []T, more bool, err error)
+	page  []T
+	index int
+	more  bool
+	value T
+	err   error
+}
+
+// newIterator creates an Iterator that calls fetch for each page until it reports no more
+func newIterator[T any](fetch func(ctx context.Context) ([]T, bool, error)) *Iterator[T] {
+	return &Iterator[T]{fetch: fetch, more: true}
+}
+
+// Next advances to the next item, fetching the next page when needed. It returns false
+// when the listing is exhausted or a request failed; check Err to tell them apart.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	for it.index >= len(it.page) {
+		if it.err != nil || !it.more {
+			return false
+		}
+
+		page, more, err := it.fetch(ctx)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page, it.index, it.more = page, 0, more
+	}
+
+	it.value = it.page[it.index]
+	it.index++
+	return true
+}
+
+// Value returns the current item
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
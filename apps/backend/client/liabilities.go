@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// ListLiabilities calls GET /api/v2/liabilities
+func (c *Client) ListLiabilities(ctx context.Context) (*models.LiabilityReport, error) {
+	var report models.LiabilityReport
+	response := models.APIResponse{Data: &report}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/liabilities"}, &response); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// GetLiability calls GET /api/v2/accounts/{id}/liability
+func (c *Client) GetLiability(ctx context.Context, accountID string) (*models.LiabilityDetails, error) {
+	var details models.LiabilityDetails
+	response := models.APIResponse{Data: &details}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/accounts/" + escape(accountID) + "/liability"}, &response); err != nil {
+		return nil, err
+	}
+	return &details, nil
+}
+
+// UpdateLiability calls PATCH /api/v2/accounts/{id}/liability. update.Version must be the
+// account version last read, or ErrVersionConflict is returned; nil fields are left unchanged.
+func (c *Client) UpdateLiability(ctx context.Context, accountID string, update models.LiabilityUpdate) (*models.LiabilityDetails, error) {
+	var details models.LiabilityDetails
+	response := models.APIResponse{Data: &details}
+	if err := c.do(ctx, request{method: http.MethodPatch, path: apiPrefix + "/accounts/" + escape(accountID) + "/liability", body: update}, &response); err != nil {
+		return nil, err
+	}
+	return &details, nil
+}
+
+// GetAmortization calls GET /api/v2/accounts/{id}/amortization
+func (c *Client) GetAmortization(ctx context.Context, accountID string, query models.AmortizationQuery) (*models.AmortizationSchedule, error) {
+	values := url.Values{}
+	if query.ExtraPayment > 0 {
+		values.Set("extra_payment", strconv.FormatFloat(query.ExtraPayment, 'f', -1, 64))
+	}
+
+	var schedule models.AmortizationSchedule
+	response := models.APIResponse{Data: &schedule}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/accounts/" + escape(accountID) + "/amortization", query: values}, &response); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// GetReconciliation calls GET /api/v2/accounts/{id}/reconciliation
+func (c *Client) GetReconciliation(ctx context.Context, accountID string) (*models.Reconciliation, error) {
+	var reconciliation models.Reconciliation
+	response := models.APIResponse{Data: &reconciliation}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/accounts/" + escape(accountID) + "/reconciliation"}, &response); err != nil {
+		return nil, err
+	}
+	return &reconciliation, nil
+}
+
+// ListStatements calls GET /api/v2/accounts/{id}/statements
+func (c *Client) ListStatements(ctx context.Context, accountID string) ([]models.Statement, error) {
+	var statements []models.Statement
+	response := models.APIResponse{Data: &statements}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/accounts/" + escape(accountID) + "/statements"}, &response); err != nil {
+		return nil, err
+	}
+	return statements, nil
+}
+
+// ReconcileStatement calls POST /api/v2/accounts/{id}/statements
+func (c *Client) ReconcileStatement(ctx context.Context, accountID string, statement models.StatementRequest) (*models.Statement, error) {
+	var reconciled models.Statement
+	response := models.APIResponse{Data: &reconciled}
+	if err := c.do(ctx, request{method: http.MethodPost, path: apiPrefix + "/accounts/" + escape(accountID) + "/statements", body: statement}, &response); err != nil {
+		return nil, err
+	}
+	return &reconciled, nil
+}
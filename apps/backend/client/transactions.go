@@ -0,0 +1,139 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// TransactionQuery filters a transaction listing. Zero fields are not sent.
+type TransactionQuery struct {
+	AccountID string
+	Type      string // debit, credit, transfer
+	Category  string
+	Tag       string
+	Status    string // pending, completed, failed, cancelled
+	StartDate *time.Time
+	EndDate   *time.Time
+	Limit     int    // page size, 1 to 1000; the server defaults to 50
+	Cursor    string // NextCursor of the previous page
+}
+
+// values encodes the query string
+func (q *TransactionQuery) values() url.Values {
+	values := url.Values{}
+	if q == nil {
+		return values
+	}
+
+	setString(values, "account_id", q.AccountID)
+	setString(values, "type", q.Type)
+	setString(values, "category", q.Category)
+	setString(values, "tag", q.Tag)
+	setString(values, "status", q.Status)
+	if q.StartDate != nil {
+		values.Set("start_date", q.StartDate.Format("2006-01-02"))
+	}
+	if q.EndDate != nil {
+		values.Set("end_date", q.EndDate.Format("2006-01-02"))
+	}
+	if q.Limit > 0 {
+		values.Set("limit", strconv.Itoa(q.Limit))
+	}
+	setString(values, "cursor", q.Cursor)
+	return values
+}
+
+// TransactionPage is one page of a transaction listing
+type TransactionPage struct {
+	Transactions []models.TransactionV2
+	NextCursor   string // empty on the last page
+}
+
+// ListTransactions calls GET /api/v2/transactions and returns one page
+func (c *Client) ListTransactions(ctx context.Context, query *TransactionQuery) (*TransactionPage, error) {
+	return c.transactionPage(ctx, apiPrefix+"/transactions", query.values())
+}
+
+// Transactions iterates over every transaction matching query, newest first
+func (c *Client) Transactions(query *TransactionQuery) *Iterator[models.TransactionV2] {
+	return c.transactionIterator(apiPrefix+"/transactions", query.values())
+}
+
+// GetTransaction calls GET /api/v2/transactions/{id}
+func (c *Client) GetTransaction(ctx context.Context, id string) (*models.TransactionV2, error) {
+	var transaction models.TransactionV2
+	response := models.ResponseV2{Data: &transaction}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/transactions/" + escape(id)}, &response); err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// UpdateTransaction calls PATCH /api/v2/transactions/{id}. update.Version must be the
+// version last read; if the transaction has changed since, it fails with ErrVersionConflict.
+func (c *Client) UpdateTransaction(ctx context.Context, id string, update models.TransactionUpdate) (*models.TransactionV2, error) {
+	var transaction models.TransactionV2
+	response := models.ResponseV2{Data: &transaction}
+	if err := c.do(ctx, request{method: http.MethodPatch, path: apiPrefix + "/transactions/" + escape(id), body: update}, &response); err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// ListAccountTransactions calls GET /api/v2/accounts/{id}/transactions and returns one page
+func (c *Client) ListAccountTransactions(ctx context.Context, accountID string, limit int, cursor string) (*TransactionPage, error) {
+	return c.transactionPage(ctx, apiPrefix+"/accounts/"+escape(accountID)+"/transactions", pageValues(limit, cursor))
+}
+
+// AccountTransactions iterates over every transaction of an account, newest first
+func (c *Client) AccountTransactions(accountID string, limit int) *Iterator[models.TransactionV2] {
+	return c.transactionIterator(apiPrefix+"/accounts/"+escape(accountID)+"/transactions", pageValues(limit, ""))
+}
+
+// transactionPage fetches one cursor page from path
+func (c *Client) transactionPage(ctx context.Context, path string, query url.Values) (*TransactionPage, error) {
+	page := &TransactionPage{}
+	response := models.ResponseV2{Data: &page.Transactions}
+	if err := c.do(ctx, request{method: http.MethodGet, path: path, query: query}, &response); err != nil {
+		return nil, err
+	}
+	if response.Page != nil {
+		page.NextCursor = response.Page.NextCursor
+	}
+	return page, nil
+}
+
+// transactionIterator follows next cursors from path until the last page
+func (c *Client) transactionIterator(path string, query url.Values) *Iterator[models.TransactionV2] {
+	return newIterator(func(ctx context.Context) ([]models.TransactionV2, bool, error) {
+		page, err := c.transactionPage(ctx, path, query)
+		if err != nil {
+			return nil, false, err
+		}
+
+		query.Set("cursor", page.NextCursor)
+		return page.Transactions, page.NextCursor != "", nil
+	})
+}
+
+// pageValues encodes limit and cursor, skipping zero values
+func pageValues(limit int, cursor string) url.Values {
+	values := url.Values{}
+	if limit > 0 {
+		values.Set("limit", strconv.Itoa(limit))
+	}
+	setString(values, "cursor", cursor)
+	return values
+}
+
+// setString sets key when value is not empty
+func setString(values url.Values, key, value string) {
+	if value != "" {
+		values.Set(key, value)
+	}
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// ListWebhooks calls GET /api/v2/webhooks
+func (c *Client) ListWebhooks(ctx context.Context) ([]models.WebhookSubscription, error) {
+	var subscriptions []models.WebhookSubscription
+	response := models.APIResponse{Data: &subscriptions}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/webhooks"}, &response); err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// CreateWebhook calls POST /api/v2/webhooks. The returned subscription includes its signing secret.
+func (c *Client) CreateWebhook(ctx context.Context, subscription models.WebhookSubscriptionRequest) (*models.WebhookSubscription, error) {
+	return c.webhook(ctx, request{method: http.MethodPost, path: apiPrefix + "/webhooks", body: subscription})
+}
+
+// GetWebhook calls GET /api/v2/webhooks/{id}
+func (c *Client) GetWebhook(ctx context.Context, id string) (*models.WebhookSubscription, error) {
+	return c.webhook(ctx, request{method: http.MethodGet, path: apiPrefix + "/webhooks/" + escape(id)})
+}
+
+// UpdateWebhook calls PUT /api/v2/webhooks/{id}
+func (c *Client) UpdateWebhook(ctx context.Context, id string, subscription models.WebhookSubscriptionRequest) (*models.WebhookSubscription, error) {
+	return c.webhook(ctx, request{method: http.MethodPut, path: apiPrefix + "/webhooks/" + escape(id), body: subscription})
+}
+
+// DeleteWebhook calls DELETE /api/v2/webhooks/{id}
+func (c *Client) DeleteWebhook(ctx context.Context, id string) error {
+	return c.do(ctx, request{method: http.MethodDelete, path: apiPrefix + "/webhooks/" + escape(id)}, nil)
+}
+
+// ListWebhookDeliveries calls GET /api/v2/webhooks/{id}/deliveries
+func (c *Client) ListWebhookDeliveries(ctx context.Context, id string) ([]models.WebhookDelivery, error) {
+	var deliveries []models.WebhookDelivery
+	response := models.APIResponse{Data: &deliveries}
+	if err := c.do(ctx, request{method: http.MethodGet, path: apiPrefix + "/webhooks/" + escape(id) + "/deliveries"}, &response); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// SendWebhookTest calls POST /api/v2/webhooks/{id}/test and returns the queued delivery
+func (c *Client) SendWebhookTest(ctx context.Context, id string) (*models.WebhookDelivery, error) {
+	var delivery models.WebhookDelivery
+	response := models.APIResponse{Data: &delivery}
+	if err := c.do(ctx, request{method: http.MethodPost, path: apiPrefix + "/webhooks/" + escape(id) + "/test"}, &response); err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// webhook performs req and decodes a single subscription
+func (c *Client) webhook(ctx context.Context, req request) (*models.WebhookSubscription, error) {
+	var subscription models.WebhookSubscription
+	response := models.APIResponse{Data: &subscription}
+	if err := c.do(ctx, req, &response); err != nil {
+		return nil, err
+	}
+	return &subscription, nil
+}
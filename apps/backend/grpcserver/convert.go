@@ -0,0 +1,144 @@
+package grpcserver
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+	aggregatorv1 "financial-aggregator-api/backend/proto/aggregator/v1"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// toMoney formats amount with two decimal places, matching the v2 REST representation
+func toMoney(amount float64, currency string) *aggregatorv1.Money {
+	rounded := math.Round(amount*100) / 100
+	if rounded == 0 {
+		rounded = 0 // avoid "-0.00"
+	}
+	return &aggregatorv1.Money{Amount: strconv.FormatFloat(rounded, 'f', 2, 64), Currency: currency}
+}
+
+// toTimestamp converts t, leaving zero times unset
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// toAccount converts an account to its protobuf message
+func toAccount(account *models.Account) *aggregatorv1.Account {
+	message := &aggregatorv1.Account{
+		Id:          account.ID,
+		Name:        account.Name,
+		Bank:        account.Bank,
+		AccountType: account.AccountType,
+		Balance:     toMoney(account.Balance, account.Currency),
+		LastUpdated: toTimestamp(account.LastUpdated),
+		IsActive:    account.IsActive,
+		Version:     account.Version,
+	}
+	if account.CreditLimit > 0 {
+		message.CreditLimit = toMoney(account.CreditLimit, account.Currency)
+	}
+	if liability := account.Liability; liability != nil {
+		message.Liability = &aggregatorv1.Liability{
+			Apr:          liability.APR,
+			StatementDay: int32(liability.StatementDay),
+			TermMonths:   int32(liability.TermMonths),
+		}
+		if liability.DueDate != nil {
+			message.Liability.DueDate = toTimestamp(*liability.DueDate)
+		}
+		if liability.MinimumPayment > 0 {
+			message.Liability.MinimumPayment = toMoney(liability.MinimumPayment, account.Currency)
+		}
+		if liability.Principal > 0 {
+			message.Liability.Principal = toMoney(liability.Principal, account.Currency)
+		}
+		if liability.OriginatedOn != nil {
+			message.Liability.OriginatedOn = toTimestamp(*liability.OriginatedOn)
+		}
+	}
+	return message
+}
+
+// toTransaction converts a transaction to its protobuf message
+func toTransaction(transaction *models.Transaction) *aggregatorv1.Transaction {
+	message := &aggregatorv1.Transaction{
+		Id:          transaction.ID,
+		AccountId:   transaction.AccountID,
+		Amount:      toMoney(transaction.Amount, transaction.Currency),
+		Type:        transaction.Type,
+		Category:    transaction.Category,
+		Description: transaction.Description,
+		Merchant:    transaction.Merchant,
+		Tags:        transaction.Tags,
+		Notes:       transaction.Notes,
+		Date:        toTimestamp(transaction.Date),
+		Status:      transaction.Status,
+		Reference:   transaction.Reference,
+		Symbol:      transaction.Symbol,
+		Quantity:    transaction.Quantity,
+		Version:     transaction.Version,
+	}
+	if transaction.Price != 0 {
+		message.Price = toMoney(transaction.Price, transaction.Currency)
+	}
+	return message
+}
+
+// toRefreshResult converts a refresh response to its protobuf message
+func toRefreshResult(response *models.AccountRefreshResponse, currency string) *aggregatorv1.RefreshResult {
+	result := &aggregatorv1.RefreshResult{
+		AccountId:   response.AccountID,
+		Success:     response.Success,
+		Message:     response.Message,
+		LastUpdated: toTimestamp(response.LastUpdated),
+		Version:     response.Version,
+	}
+	if response.Success {
+		result.NewBalance = toMoney(response.NewBalance, currency)
+	}
+	return result
+}
+
+// toAlert converts a triggered alert to its protobuf message
+func toAlert(alert *models.Alert) *aggregatorv1.Alert {
+	return &aggregatorv1.Alert{
+		Id:            alert.ID,
+		RuleId:        alert.RuleID,
+		RuleName:      alert.RuleName,
+		Type:          alert.Type,
+		AccountId:     alert.AccountID,
+		TransactionId: alert.TransactionID,
+		Message:       alert.Message,
+		Value:         alert.Value,
+		Threshold:     alert.Threshold,
+		TriggeredAt:   toTimestamp(alert.TriggeredAt),
+	}
+}
+
+// toChangeEvent converts a bus event; payloads of unknown types are left unset
+func (s *Server) toChangeEvent(event models.Event) *aggregatorv1.ChangeEvent {
+	message := &aggregatorv1.ChangeEvent{
+		Id:        event.ID,
+		Type:      event.Type,
+		Timestamp: toTimestamp(event.Timestamp),
+	}
+
+	switch data := event.Data.(type) {
+	case models.Account:
+		message.Payload = &aggregatorv1.ChangeEvent_Account{Account: toAccount(&data)}
+	case models.Transaction:
+		message.Payload = &aggregatorv1.ChangeEvent_Transaction{Transaction: toTransaction(&data)}
+	case models.AccountRefreshResponse:
+		message.Payload = &aggregatorv1.ChangeEvent_Refresh{Refresh: toRefreshResult(&data, s.accountCurrency(data.AccountID))}
+	case models.Alert:
+		message.Payload = &aggregatorv1.ChangeEvent_Alert{Alert: toAlert(&data)}
+	}
+
+	return message
+}
@@ -0,0 +1,82 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+
+	"financial-aggregator-api/backend/services"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain identifies this API in ErrorInfo details
+const errorDomain = "finagg"
+
+// statusError converts a service error to a gRPC status. The REST error code is sent as
+// the ErrorInfo reason, and validation errors list their fields in a BadRequest detail.
+func statusError(err error) error {
+	code := statusCode(err)
+
+	message := err.Error()
+	var domainErr *services.Error
+	if errors.As(err, &domainErr) {
+		message = domainErr.Message
+	}
+	if code == codes.Internal {
+		message = "internal error"
+	}
+
+	st := status.New(code, message)
+
+	reason := services.ErrorCode(err)
+	if reason == "" {
+		return st.Err()
+	}
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain}}
+
+	var validation *services.ValidationError
+	if errors.As(err, &validation) {
+		badRequest := &errdetails.BadRequest{}
+		for _, field := range validation.Fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Code + ": " + field.Message,
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	if withDetails, detailErr := st.WithDetails(details...); detailErr == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// statusCode picks the gRPC code for an error kind, mirroring the HTTP status mapping
+func statusCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, services.ErrValidation):
+		return codes.InvalidArgument
+	case errors.Is(err, services.ErrVersionConflict):
+		// The client should re-read and retry the whole read-modify-write
+		return codes.Aborted
+	case errors.Is(err, services.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, services.ErrConflict):
+		return codes.AlreadyExists
+	case errors.Is(err, services.ErrRateLimited):
+		return codes.ResourceExhausted
+	case errors.Is(err, services.ErrUpstreamUnavailable):
+		return codes.Unavailable
+	case errors.Is(err, context.Canceled):
+		return codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return codes.DeadlineExceeded
+	default:
+		return codes.Internal
+	}
+}
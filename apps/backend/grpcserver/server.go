@@ -0,0 +1,225 @@
+package grpcserver
+
+import (
+	"context"
+	"sort"
+
+	"financial-aggregator-api/backend/models"
+	aggregatorv1 "financial-aggregator-api/backend/proto/aggregator/v1"
+	"financial-aggregator-api/backend/services"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxPageSize matches the REST limit on transaction pages
+const maxPageSize = 1000
+
+// Server implements AggregatorService on top of the services package
+type Server struct {
+	aggregatorv1.UnimplementedAggregatorServiceServer
+
+	accountService     *services.AccountService
+	transactionService *services.TransactionService
+	eventBus           *services.EventBus
+}
+
+// NewServer creates a new Server instance
+func NewServer(accountService *services.AccountService, transactionService *services.TransactionService, eventBus *services.EventBus) *Server {
+	return &Server{
+		accountService:     accountService,
+		transactionService: transactionService,
+		eventBus:           eventBus,
+	}
+}
+
+// NewGRPCServer creates a gRPC server with AggregatorService registered
+func NewGRPCServer(server *Server, opts ...grpc.ServerOption) *grpc.Server {
+	grpcServer := grpc.NewServer(opts...)
+	aggregatorv1.RegisterAggregatorServiceServer(grpcServer, server)
+	return grpcServer
+}
+
+// ListAccounts returns every account, ordered by ID
+func (s *Server) ListAccounts(ctx context.Context, request *aggregatorv1.ListAccountsRequest) (*aggregatorv1.ListAccountsResponse, error) {
+	accounts, err := s.accountService.GetAllAccounts()
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].ID < accounts[j].ID })
+
+	response := &aggregatorv1.ListAccountsResponse{Accounts: make([]*aggregatorv1.Account, 0, len(accounts))}
+	for _, account := range accounts {
+		response.Accounts = append(response.Accounts, toAccount(account))
+	}
+	return response, nil
+}
+
+// GetAccount returns a single account
+func (s *Server) GetAccount(ctx context.Context, request *aggregatorv1.GetAccountRequest) (*aggregatorv1.Account, error) {
+	if err := requireID(request.GetId()); err != nil {
+		return nil, statusError(err)
+	}
+
+	account, err := s.accountService.GetAccountByID(request.GetId())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return toAccount(account), nil
+}
+
+// RefreshAccount fetches the latest balance and returns the refreshed account
+func (s *Server) RefreshAccount(ctx context.Context, request *aggregatorv1.RefreshAccountRequest) (*aggregatorv1.RefreshAccountResponse, error) {
+	if err := requireID(request.GetId()); err != nil {
+		return nil, statusError(err)
+	}
+
+	refresh, err := s.accountService.RefreshAccountAtVersion(ctx, request.GetId(), request.GetExpectedVersion())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &aggregatorv1.RefreshAccountResponse{
+		Result:  toRefreshResult(refresh, refresh.Account.Currency),
+		Account: toAccount(refresh.Account),
+	}, nil
+}
+
+// ListTransactions returns a page of transactions, newest first
+func (s *Server) ListTransactions(ctx context.Context, request *aggregatorv1.ListTransactionsRequest) (*aggregatorv1.ListTransactionsResponse, error) {
+	filter, err := transactionFilter(request)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	transactions, nextCursor, err := s.transactionService.GetTransactionsPage(filter, request.GetPageToken())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	response := &aggregatorv1.ListTransactionsResponse{
+		Transactions:  make([]*aggregatorv1.Transaction, 0, len(transactions)),
+		NextPageToken: nextCursor,
+	}
+	for _, transaction := range transactions {
+		response.Transactions = append(response.Transactions, toTransaction(transaction))
+	}
+	return response, nil
+}
+
+// WatchChanges streams change events, replaying buffered events after last_event_id first
+func (s *Server) WatchChanges(request *aggregatorv1.WatchChangesRequest, stream aggregatorv1.AggregatorService_WatchChangesServer) error {
+	wanted := make(map[string]bool, len(request.GetEventTypes()))
+	for _, eventType := range request.GetEventTypes() {
+		wanted[eventType] = true
+	}
+
+	send := func(event models.Event) error {
+		if len(wanted) > 0 && !wanted[event.Type] {
+			return nil
+		}
+		return stream.Send(s.toChangeEvent(event))
+	}
+
+	replay, events, unsubscribe := s.eventBus.Subscribe(request.GetLastEventId())
+	defer unsubscribe()
+
+	for _, event := range replay {
+		if err := send(event); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, open := <-events:
+			if !open {
+				// Dropped for falling behind, or shutting down; the client resumes with last_event_id
+				return status.Error(codes.Unavailable, "event stream closed, reconnect with last_event_id")
+			}
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// accountCurrency returns the currency of an account, defaulting to USD if it is gone
+func (s *Server) accountCurrency(accountID string) string {
+	if account, err := s.accountService.GetAccountByID(accountID); err == nil {
+		return account.Currency
+	}
+	return "USD"
+}
+
+// requireID reports a missing id field
+func requireID(id string) error {
+	validation := &services.ValidationError{}
+	if id == "" {
+		validation.Add("id", services.CodeRequired, "id is required")
+	}
+	return validation.Err()
+}
+
+// transactionFilter validates a ListTransactions request the same way the REST query is validated
+func transactionFilter(request *aggregatorv1.ListTransactionsRequest) (*models.TransactionFilter, error) {
+	validation := &services.ValidationError{}
+
+	filter := &models.TransactionFilter{
+		AccountID: request.GetAccountId(),
+		Type:      request.GetType(),
+		Category:  request.GetCategory(),
+		Tag:       request.GetTag(),
+		Status:    request.GetStatus(),
+		Limit:     int(request.GetPageSize()),
+	}
+
+	if filter.Type != "" && !oneOf(filter.Type, "debit", "credit", "transfer", "buy", "sell", "dividend", "fee") {
+		validation.Add("type", services.CodeNotAllowed, "type must be one of debit, credit, transfer, buy, sell, dividend, fee")
+	}
+	if filter.Status != "" && !oneOf(filter.Status, "pending", "completed", "failed", "cancelled") {
+		validation.Add("status", services.CodeNotAllowed, "status must be one of pending, completed, failed, cancelled")
+	}
+	if filter.Limit < 0 || filter.Limit > maxPageSize {
+		validation.Addf("page_size", services.CodeOutOfRange, "page_size must be between 1 and %d", maxPageSize)
+	}
+
+	if request.GetStartDate() != nil {
+		if err := request.GetStartDate().CheckValid(); err != nil {
+			validation.Add("start_date", services.CodeInvalidFormat, "start_date is not a valid timestamp")
+		} else {
+			startDate := request.GetStartDate().AsTime()
+			filter.StartDate = &startDate
+		}
+	}
+	if request.GetEndDate() != nil {
+		if err := request.GetEndDate().CheckValid(); err != nil {
+			validation.Add("end_date", services.CodeInvalidFormat, "end_date is not a valid timestamp")
+		} else {
+			endDate := request.GetEndDate().AsTime()
+			filter.EndDate = &endDate
+		}
+	}
+	if filter.StartDate != nil && filter.EndDate != nil && filter.EndDate.Before(*filter.StartDate) {
+		validation.Add("end_date", services.CodeOutOfRange, "end_date must not be before start_date")
+	}
+
+	if err := validation.Err(); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// oneOf reports whether value is one of allowed
+func oneOf(value string, allowed ...string) bool {
+	for _, candidate := range allowed {
+		if value == candidate {
+			return true
+		}
+	}
+	return false
+}
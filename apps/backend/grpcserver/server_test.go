@@ -0,0 +1,215 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+	aggregatorv1 "financial-aggregator-api/backend/proto/aggregator/v1"
+	"financial-aggregator-api/backend/services"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type testServer struct {
+	client       aggregatorv1.AggregatorServiceClient
+	transactions *services.TransactionService
+	eventBus     *services.EventBus
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	accountService := services.NewAccountService()
+	transactionService := services.NewTransactionService()
+	eventBus := services.NewEventBus(16)
+	accountService.SetEventBus(eventBus)
+	transactionService.SetEventBus(eventBus)
+
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := NewGRPCServer(NewServer(accountService, transactionService, eventBus))
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return &testServer{
+		client:       aggregatorv1.NewAggregatorServiceClient(conn),
+		transactions: transactionService,
+		eventBus:     eventBus,
+	}
+}
+
+func TestServer_Accounts(t *testing.T) {
+	server := newTestServer(t)
+	ctx := context.Background()
+
+	list, err := server.client.ListAccounts(ctx, &aggregatorv1.ListAccountsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.GetAccounts()) != 7 || list.GetAccounts()[0].GetId() != "acc_001" {
+		t.Fatalf("Expected 7 accounts ordered by ID, got %v", list.GetAccounts())
+	}
+
+	account, err := server.client.GetAccount(ctx, &aggregatorv1.GetAccountRequest{Id: "acc_003"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.GetBalance().GetAmount() != "-1200.50" || account.GetCreditLimit().GetAmount() != "5000.00" {
+		t.Errorf("Unexpected money fields: %v %v", account.GetBalance(), account.GetCreditLimit())
+	}
+	if liability := account.GetLiability(); liability.GetApr() != 24.99 || liability.GetMinimumPayment().GetAmount() != "35.00" || liability.GetDueDate() == nil {
+		t.Errorf("Unexpected liability %v", liability)
+	}
+
+	refreshed, err := server.client.RefreshAccount(ctx, &aggregatorv1.RefreshAccountRequest{Id: "acc_001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !refreshed.GetResult().GetSuccess() || refreshed.GetAccount().GetId() != "acc_001" {
+		t.Errorf("Unexpected refresh response: %v", refreshed)
+	}
+	if refreshed.GetAccount().GetVersion() != 2 || refreshed.GetResult().GetVersion() != 2 {
+		t.Errorf("Expected the refresh to move acc_001 to version 2, got %v", refreshed)
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	server := newTestServer(t)
+	ctx := context.Background()
+
+	_, err := server.client.GetAccount(ctx, &aggregatorv1.GetAccountRequest{Id: "missing"})
+	st := status.Convert(err)
+	if st.Code() != codes.NotFound {
+		t.Fatalf("Expected NotFound, got %v", st.Code())
+	}
+	if reason := errorReason(st); reason != "account_not_found" {
+		t.Errorf("Expected reason account_not_found, got %q", reason)
+	}
+
+	// acc_002 starts at version 1, so expecting version 7 is stale
+	_, err = server.client.RefreshAccount(ctx, &aggregatorv1.RefreshAccountRequest{Id: "acc_002", ExpectedVersion: 7})
+	st = status.Convert(err)
+	if st.Code() != codes.Aborted || errorReason(st) != "version_conflict" {
+		t.Errorf("Expected Aborted with reason version_conflict, got %v %q", st.Code(), errorReason(st))
+	}
+
+	_, err = server.client.ListTransactions(ctx, &aggregatorv1.ListTransactionsRequest{Type: "refund", PageSize: 5000})
+	st = status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument, got %v", st.Code())
+	}
+
+	fields := make(map[string]bool)
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.GetFieldViolations() {
+				fields[violation.GetField()] = true
+			}
+		}
+	}
+	if !fields["type"] || !fields["page_size"] {
+		t.Errorf("Expected type and page_size violations, got %v", fields)
+	}
+}
+
+func TestServer_ListTransactionsPages(t *testing.T) {
+	server := newTestServer(t)
+	ctx := context.Background()
+
+	seen := make(map[string]bool)
+	request := &aggregatorv1.ListTransactionsRequest{PageSize: 3}
+	for pages := 0; ; pages++ {
+		if pages > 10 {
+			t.Fatal("Pagination did not terminate")
+		}
+
+		page, err := server.client.ListTransactions(ctx, request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, transaction := range page.GetTransactions() {
+			if seen[transaction.GetId()] {
+				t.Errorf("Transaction %s returned twice", transaction.GetId())
+			}
+			seen[transaction.GetId()] = true
+		}
+
+		if page.GetNextPageToken() == "" {
+			break
+		}
+		request.PageToken = page.GetNextPageToken()
+	}
+
+	if len(seen) != 16 {
+		t.Errorf("Expected 16 transactions across pages, got %d", len(seen))
+	}
+}
+
+func TestServer_WatchChanges(t *testing.T) {
+	server := newTestServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Resuming after the first event means later ones arrive by replay or live, whichever
+	// side of the subscription they land on
+	first := server.eventBus.Publish(models.EventAccountUpdated, models.Account{ID: "acc_001", Currency: "USD"})
+
+	stream, err := server.client.WatchChanges(ctx, &aggregatorv1.WatchChangesRequest{
+		LastEventId: first.ID,
+		EventTypes:  []string{models.EventTransactionCreated},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The account event is filtered out
+	server.eventBus.Publish(models.EventAccountUpdated, models.Account{ID: "acc_002", Currency: "USD"})
+	if _, err := server.transactions.CreateTransaction(ctx, &models.Transaction{ID: "txn_watch", AccountID: "acc_001", Amount: -12.5, Currency: "USD"}); err != nil {
+		t.Fatal(err)
+	}
+
+	event, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.GetType() != models.EventTransactionCreated || event.GetTransaction().GetId() != "txn_watch" {
+		t.Fatalf("Expected transaction.created for txn_watch, got %v", event)
+	}
+	if event.GetTransaction().GetAmount().GetAmount() != "-12.50" {
+		t.Errorf("Expected amount -12.50, got %v", event.GetTransaction().GetAmount())
+	}
+
+	// Closing the bus ends the stream so the server can stop gracefully
+	server.eventBus.Close()
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable after the bus closes, got %v", err)
+	}
+}
+
+// errorReason returns the ErrorInfo reason attached to st
+func errorReason(st *status.Status) string {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
+	}
+	return ""
+}
@@ -1,7 +1,6 @@
 package handlers
 
 import (
-	"encoding/json"
 	"net/http"
 
 	"financial-aggregator-api/backend/models"
@@ -26,7 +25,7 @@ func NewAccountHandler(accountService *services.AccountService) *AccountHandler
 func (h *AccountHandler) GetAccounts(w http.ResponseWriter, r *http.Request) {
 	accounts, err := h.accountService.GetAllAccounts()
 	if err != nil {
-		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch accounts", err)
+		writeServiceError(w, r, "Failed to fetch accounts", err)
 		return
 	}
 
@@ -36,20 +35,28 @@ func (h *AccountHandler) GetAccounts(w http.ResponseWriter, r *http.Request) {
 		Data:    accounts,
 	}
 
-	h.writeJSONResponse(w, http.StatusOK, response)
+	if notModified(w, r, entityTag(r, response), latestUpdate(accounts)) {
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
 }
 
 // GetAccountByID handles GET /api/accounts/:id
 func (h *AccountHandler) GetAccountByID(w http.ResponseWriter, r *http.Request) {
 	accountID := chi.URLParam(r, "id")
 	if accountID == "" {
-		h.writeErrorResponse(w, http.StatusBadRequest, "Account ID is required", nil)
+		writeErrorResponse(w, r, http.StatusBadRequest, "Account ID is required", nil)
 		return
 	}
 
 	account, err := h.accountService.GetAccountByID(accountID)
 	if err != nil {
-		h.writeErrorResponse(w, http.StatusNotFound, "Account not found", err)
+		writeServiceError(w, r, "Account not found", err)
+		return
+	}
+
+	if notModified(w, r, entityTag(r, account), account.LastUpdated) {
 		return
 	}
 
@@ -59,20 +66,25 @@ func (h *AccountHandler) GetAccountByID(w http.ResponseWriter, r *http.Request)
 		Data:    account,
 	}
 
-	h.writeJSONResponse(w, http.StatusOK, response)
+	writeJSONResponse(w, http.StatusOK, response)
 }
 
 // RefreshAccount handles POST /api/accounts/:id/refresh
 func (h *AccountHandler) RefreshAccount(w http.ResponseWriter, r *http.Request) {
 	accountID := chi.URLParam(r, "id")
 	if accountID == "" {
-		h.writeErrorResponse(w, http.StatusBadRequest, "Account ID is required", nil)
+		writeErrorResponse(w, r, http.StatusBadRequest, "Account ID is required", nil)
+		return
+	}
+
+	version, ok := h.refreshVersion(w, r, accountID)
+	if !ok {
 		return
 	}
 
-	refreshResponse, err := h.accountService.RefreshAccount(accountID)
+	refreshResponse, err := h.accountService.RefreshAccountAtVersion(r.Context(), accountID, version)
 	if err != nil {
-		h.writeErrorResponse(w, http.StatusNotFound, "Account not found", err)
+		writeServiceError(w, r, "Failed to refresh account", err)
 		return
 	}
 
@@ -87,26 +99,36 @@ func (h *AccountHandler) RefreshAccount(w http.ResponseWriter, r *http.Request)
 		Data:    refreshResponse,
 	}
 
-	h.writeJSONResponse(w, statusCode, response)
+	writeJSONResponse(w, statusCode, response)
 }
 
-// writeJSONResponse writes a JSON response to the client
-func (h *AccountHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(data)
-}
+// refreshVersion returns the account version a refresh must apply to: the version in the
+// optional request body, or the version whose ETag matched If-Match; 0 when neither is
+// given. It writes the error response and returns false when the request cannot proceed.
+func (h *AccountHandler) refreshVersion(w http.ResponseWriter, r *http.Request, accountID string) (int64, bool) {
+	var request models.AccountRefreshRequest
+	if r.ContentLength != 0 {
+		if err := decodeJSONBody(r, &request); err != nil {
+			writeServiceError(w, r, "Invalid request body", err)
+			return 0, false
+		}
+	}
 
-// writeErrorResponse writes an error response to the client
-func (h *AccountHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, message string, err error) {
-	response := models.APIResponse{
-		Success: false,
-		Message: message,
+	if r.Header.Get("If-Match") == "" {
+		return request.Version, true
 	}
 
+	// A missing account is left for the refresh to report
+	account, err := h.accountService.GetAccountByID(accountID)
 	if err != nil {
-		response.Error = err.Error()
+		return request.Version, true
 	}
-
-	h.writeJSONResponse(w, statusCode, response)
+	if preconditionFailed(w, r, entityTag(r, account)) {
+		return 0, false
+	}
+	if request.Version == 0 {
+		// Pass the matched version on, so a change made after the check still fails the refresh
+		return account.Version, true
+	}
+	return request.Version, true
 }
@@ -146,6 +146,15 @@ func TestAccountHandler_RefreshAccount(t *testing.T) {
 	if status := rr.Code; status != http.StatusNotFound {
 		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNotFound)
 	}
+
+	response = models.APIResponse{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	if response.Code != "account_not_found" {
+		t.Errorf("Expected code account_not_found, got %q", response.Code)
+	}
 }
 
 func TestAccountHandler_RefreshAccountWithBody(t *testing.T) {
@@ -188,3 +197,33 @@ func TestAccountHandler_RefreshAccountWithBody(t *testing.T) {
 		t.Errorf("Expected success to be true, got %v", response.Success)
 	}
 }
+
+func TestAccountHandler_RefreshAccountStaleVersion(t *testing.T) {
+	handler := NewAccountHandler(services.NewAccountService())
+	r := chi.NewRouter()
+	r.Post("/api/accounts/{id}/refresh", handler.RefreshAccount)
+
+	refresh := func(version int64) *httptest.ResponseRecorder {
+		jsonData, _ := json.Marshal(models.AccountRefreshRequest{Version: version})
+		req := httptest.NewRequest("POST", "/api/accounts/acc_001/refresh", bytes.NewBuffer(jsonData))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	if rr := refresh(1); rr.Code != http.StatusOK {
+		t.Fatalf("Expected a refresh at the current version to succeed, got %d", rr.Code)
+	}
+
+	rr := refresh(1)
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("Expected 409 for a stale version, got %d", rr.Code)
+	}
+	var response models.APIResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Code != "version_conflict" {
+		t.Errorf("Expected code version_conflict, got %q", response.Code)
+	}
+}
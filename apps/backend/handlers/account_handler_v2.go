@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+
+	"financial-aggregator-api/backend/models"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GetAccountsV2 handles GET /api/v2/accounts
+func (h *AccountHandler) GetAccountsV2(w http.ResponseWriter, r *http.Request) {
+	accounts, err := h.accountService.GetAllAccounts()
+	if err != nil {
+		writeServiceError(w, r, "Failed to fetch accounts", err)
+		return
+	}
+
+	data := make([]models.AccountV2, 0, len(accounts))
+	for _, account := range accounts {
+		data = append(data, toAccountV2(account))
+	}
+
+	response := models.ResponseV2{Data: data}
+	if notModified(w, r, entityTag(r, response), latestUpdate(accounts)) {
+		return
+	}
+
+	writeV2Response(w, r, http.StatusOK, response)
+}
+
+// GetAccountByIDV2 handles GET /api/v2/accounts/:id
+func (h *AccountHandler) GetAccountByIDV2(w http.ResponseWriter, r *http.Request) {
+	account, err := h.accountService.GetAccountByID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeServiceError(w, r, "Account not found", err)
+		return
+	}
+
+	if notModified(w, r, entityTag(r, account), account.LastUpdated) {
+		return
+	}
+
+	writeV2Response(w, r, http.StatusOK, models.ResponseV2{Data: toAccountV2(account)})
+}
+
+// RefreshAccountV2 handles POST /api/v2/accounts/:id/refresh and returns the refreshed account
+func (h *AccountHandler) RefreshAccountV2(w http.ResponseWriter, r *http.Request) {
+	accountID := chi.URLParam(r, "id")
+	version, ok := h.refreshVersion(w, r, accountID)
+	if !ok {
+		return
+	}
+
+	// The refreshed account is returned as the refresh left it, not as a later change did
+	refresh, err := h.accountService.RefreshAccountAtVersion(r.Context(), accountID, version)
+	if err != nil {
+		writeServiceError(w, r, "Failed to refresh account", err)
+		return
+	}
+
+	writeV2Response(w, r, http.StatusOK, models.ResponseV2{Data: toAccountV2(refresh.Account)})
+}
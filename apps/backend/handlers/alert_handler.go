@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"net/http"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// AlertHandler handles alert and alert rule HTTP requests
+type AlertHandler struct {
+	alertService *services.AlertService
+}
+
+// NewAlertHandler creates a new AlertHandler instance
+func NewAlertHandler(alertService *services.AlertService) *AlertHandler {
+	return &AlertHandler{
+		alertService: alertService,
+	}
+}
+
+// GetAlerts handles GET /api/alerts
+func (h *AlertHandler) GetAlerts(w http.ResponseWriter, r *http.Request) {
+	alerts, err := h.alertService.GetAlerts(r.URL.Query().Get("rule_id"), r.URL.Query().Get("account_id"))
+	if err != nil {
+		writeServiceError(w, r, "Failed to fetch alerts", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Alerts retrieved successfully",
+		Data:    alerts,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetAlertRules handles GET /api/alerts/rules
+func (h *AlertHandler) GetAlertRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.alertService.GetAllRules()
+	if err != nil {
+		writeServiceError(w, r, "Failed to fetch alert rules", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Alert rules retrieved successfully",
+		Data:    rules,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// CreateAlertRule handles POST /api/alerts/rules
+func (h *AlertHandler) CreateAlertRule(w http.ResponseWriter, r *http.Request) {
+	var request models.AlertRuleRequest
+	if err := decodeJSONBody(r, &request); err != nil {
+		writeServiceError(w, r, "Invalid request body", err)
+		return
+	}
+
+	rule, err := h.alertService.CreateRule(&request)
+	if err != nil {
+		writeServiceError(w, r, "Invalid alert rule", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Alert rule created successfully",
+		Data:    rule,
+	}
+
+	writeJSONResponse(w, http.StatusCreated, response)
+}
+
+// GetAlertRuleByID handles GET /api/alerts/rules/:id
+func (h *AlertHandler) GetAlertRuleByID(w http.ResponseWriter, r *http.Request) {
+	rule, err := h.alertService.GetRuleByID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeServiceError(w, r, "Alert rule not found", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Alert rule retrieved successfully",
+		Data:    rule,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// DeleteAlertRule handles DELETE /api/alerts/rules/:id
+func (h *AlertHandler) DeleteAlertRule(w http.ResponseWriter, r *http.Request) {
+	if err := h.alertService.DeleteRule(chi.URLParam(r, "id")); err != nil {
+		writeServiceError(w, r, "Alert rule not found", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Alert rule deleted successfully",
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
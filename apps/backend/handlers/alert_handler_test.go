@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestAlertHandler_CreateRuleAndListAlerts(t *testing.T) {
+	alertService := services.NewAlertService(nil, services.NewAccountService())
+	handler := NewAlertHandler(alertService)
+
+	r := chi.NewRouter()
+	r.Get("/api/alerts", handler.GetAlerts)
+	r.Post("/api/alerts/rules", handler.CreateAlertRule)
+	r.Get("/api/alerts/rules/{id}", handler.GetAlertRuleByID)
+	r.Delete("/api/alerts/rules/{id}", handler.DeleteAlertRule)
+
+	// Every mock account has a balance above -1,000,000, so this fires immediately
+	body, _ := json.Marshal(models.AlertRuleRequest{Type: models.AlertBalanceAbove, Threshold: -1000000})
+	req, err := http.NewRequest("POST", "/api/alerts/rules", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+
+	req, _ = http.NewRequest("GET", "/api/alerts?account_id=acc_001", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	var response models.APIResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if alerts, ok := response.Data.([]interface{}); !ok || len(alerts) != 1 {
+		t.Errorf("Expected one alert for acc_001, got %v", response.Data)
+	}
+
+	// Unknown rule types are rejected
+	body, _ = json.Marshal(models.AlertRuleRequest{Type: "balance_sideways"})
+	req, _ = http.NewRequest("POST", "/api/alerts/rules", bytes.NewBuffer(body))
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+
+	req, _ = http.NewRequest("DELETE", "/api/alerts/rules/missing", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusNotFound {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNotFound)
+	}
+}
+
+func TestAlertHandler_TransactionUpdateFiresRule(t *testing.T) {
+	bus := services.NewEventBus(16)
+	transactionService := services.NewTransactionService()
+	transactionService.SetEventBus(bus)
+	alertService := services.NewAlertService(bus, services.NewAccountService())
+	alertService.Start()
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = alertService.Stop(ctx)
+	}()
+
+	_, events, unsubscribe := bus.Subscribe(0)
+	defer unsubscribe()
+
+	alertHandler := NewAlertHandler(alertService)
+	transactionHandler := NewTransactionHandler(transactionService)
+
+	r := chi.NewRouter()
+	r.Get("/api/alerts", alertHandler.GetAlerts)
+	r.Post("/api/alerts/rules", alertHandler.CreateAlertRule)
+	r.Patch("/api/transactions/{id}", transactionHandler.UpdateTransaction)
+
+	body, _ := json.Marshal(models.AlertRuleRequest{Type: models.AlertTransactionCategory, Category: "travel"})
+	req, _ := http.NewRequest("POST", "/api/alerts/rules", bytes.NewBuffer(body))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+
+	// Moving txn_003 into travel fires the rule; a later edit of it does not fire it again
+	time.Sleep(20 * time.Millisecond)
+	category, description := "travel", "Train tickets"
+	for _, update := range []models.TransactionUpdate{
+		{Version: 1, Category: &category},
+		{Version: 2, Description: &description},
+	} {
+		body, _ = json.Marshal(update)
+		req, _ = http.NewRequest("PATCH", "/api/transactions/txn_003", bytes.NewBuffer(body))
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		if status := rr.Code; status != http.StatusOK {
+			t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+		}
+	}
+
+	updates := 0
+	timeout := time.After(2 * time.Second)
+	for updates < 2 {
+		select {
+		case event := <-events:
+			if event.Type == models.EventTransactionUpdated {
+				updates++
+			}
+		case <-timeout:
+			t.Fatal("Timed out waiting for transaction.updated events")
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	req, _ = http.NewRequest("GET", "/api/alerts", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	var response models.APIResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if alerts, ok := response.Data.([]interface{}); !ok || len(alerts) != 1 {
+		t.Errorf("Expected one alert for the recategorized transaction, got %v", response.Data)
+	}
+}
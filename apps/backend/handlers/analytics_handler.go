@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+)
+
+// AnalyticsHandler handles analytics HTTP requests
+type AnalyticsHandler struct {
+	analyticsService *services.AnalyticsService
+}
+
+// NewAnalyticsHandler creates a new AnalyticsHandler instance
+func NewAnalyticsHandler(analyticsService *services.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsService: analyticsService,
+	}
+}
+
+// GetCashflow handles GET /api/analytics/cashflow. The period defaults to the current month
+// to date, grouped by category.
+func (h *AnalyticsHandler) GetCashflow(w http.ResponseWriter, r *http.Request) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	cashflowQuery := models.CashflowQuery{
+		From:    today.AddDate(0, 0, 1-today.Day()),
+		To:      today,
+		GroupBy: models.CashflowByCategory,
+	}
+
+	query := newQueryValidator(r)
+	if from := query.Date("from"); from != nil {
+		cashflowQuery.From = *from
+	}
+	if to := query.Date("to"); to != nil {
+		cashflowQuery.To = *to
+	}
+	if groupBy := query.Enum("group_by", models.CashflowByCategory, models.CashflowByMonth, models.CashflowByWeek, models.CashflowByAccount); groupBy != "" {
+		cashflowQuery.GroupBy = groupBy
+	}
+	cashflowQuery.IncludeTransfers = query.Bool("include_transfers", false)
+	if err := query.Err(); err != nil {
+		writeErrorResponse(w, r, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	report, err := h.analyticsService.Cashflow(cashflowQuery)
+	if err != nil {
+		writeServiceError(w, r, "Invalid cashflow query", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Cashflow retrieved successfully",
+		Data:    report,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetMerchants handles GET /api/merchants
+func (h *AnalyticsHandler) GetMerchants(w http.ResponseWriter, r *http.Request) {
+	query := newQueryValidator(r)
+	filter := models.MerchantFilter{
+		AccountID: query.String("account_id"),
+		From:      query.Date("from"),
+		To:        query.Date("to"),
+		Limit:     query.Int("limit", 1, maxPageLimit, 0),
+	}
+	if err := query.Err(); err != nil {
+		writeErrorResponse(w, r, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	merchants, err := h.analyticsService.MerchantSpend(filter)
+	if err != nil {
+		writeServiceError(w, r, "Invalid merchant query", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Merchants retrieved successfully",
+		Data:    merchants,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestAnalyticsHandler_GetCashflow(t *testing.T) {
+	handler := NewAnalyticsHandler(services.NewAnalyticsService(services.NewTransactionService()))
+	r := chi.NewRouter()
+	r.Get("/api/analytics/cashflow", handler.GetCashflow)
+
+	// Without parameters the report covers the current month to date, by category
+	req, _ := http.NewRequest("GET", "/api/analytics/cashflow", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+
+	var response struct {
+		Data models.CashflowReport `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	today := time.Now().UTC()
+	if response.Data.GroupBy != models.CashflowByCategory || response.Data.To != today.Format("2006-01-02") ||
+		response.Data.From != today.AddDate(0, 0, 1-today.Day()).Format("2006-01-02") {
+		t.Errorf("Unexpected default period %+v", response.Data)
+	}
+
+	// Every invalid parameter is reported
+	req, _ = http.NewRequest("GET", "/api/analytics/cashflow?from=March&group_by=day&include_transfers=maybe", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusBadRequest)
+	}
+	var failure models.APIResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &failure); err != nil {
+		t.Fatal(err)
+	}
+	if len(failure.Errors) != 3 {
+		t.Errorf("Expected three field errors, got %+v", failure.Errors)
+	}
+
+	// Cross-field checks come from the service
+	req, _ = http.NewRequest("GET", "/api/analytics/cashflow?from=2024-03-10&to=2024-03-01", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 when to is before from, got %v", rr.Code)
+	}
+}
+
+func TestAnalyticsHandler_GetMerchants(t *testing.T) {
+	handler := NewAnalyticsHandler(services.NewAnalyticsService(services.NewTransactionService()))
+	r := chi.NewRouter()
+	r.Get("/api/merchants", handler.GetMerchants)
+
+	req, _ := http.NewRequest("GET", "/api/merchants?account_id=acc_001&limit=2", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+
+	var response struct {
+		Data []models.MerchantSpend `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Data) != 2 || response.Data[0].Spending < response.Data[1].Spending {
+		t.Errorf("Expected the two biggest merchants of acc_001, got %+v", response.Data)
+	}
+
+	req, _ = http.NewRequest("GET", "/api/merchants?limit=0&from=yesterday", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusBadRequest)
+	}
+}
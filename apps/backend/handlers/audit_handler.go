@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+)
+
+// AuditHandler handles audit log HTTP requests
+type AuditHandler struct {
+	auditLog *services.AuditLog
+}
+
+// NewAuditHandler creates a new AuditHandler instance
+func NewAuditHandler(auditLog *services.AuditLog) *AuditHandler {
+	return &AuditHandler{
+		auditLog: auditLog,
+	}
+}
+
+// GetAuditEntries handles GET /api/audit
+func (h *AuditHandler) GetAuditEntries(w http.ResponseWriter, r *http.Request) {
+	filter, err := h.buildAuditFilter(r)
+	if err != nil {
+		writeServiceError(w, r, "Invalid query parameters", err)
+		return
+	}
+
+	entries, total := h.auditLog.GetEntries(filter)
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	response := models.PaginatedResponse{
+		Success: true,
+		Data:    entries,
+		Meta: models.PaginationMeta{
+			Total:  total,
+			Limit:  limit,
+			Offset: filter.Offset,
+			Pages:  (total + limit - 1) / limit,
+		},
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// VerifyAuditLog handles GET /api/audit/verify
+func (h *AuditHandler) VerifyAuditLog(w http.ResponseWriter, r *http.Request) {
+	verification := h.auditLog.Verify()
+
+	message := "Audit log hash chain is intact"
+	if !verification.Valid {
+		message = "Audit log hash chain is broken"
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: message,
+		Data:    verification,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// buildAuditFilter builds an AuditFilter from query parameters, reporting every invalid parameter
+func (h *AuditHandler) buildAuditFilter(r *http.Request) (*models.AuditFilter, error) {
+	query := newQueryValidator(r)
+	filter := &models.AuditFilter{
+		EntityType: query.String("entity_type"),
+		EntityID:   query.String("entity_id"),
+		Action:     query.String("action"),
+		Actor:      query.String("actor"),
+		RequestID:  query.String("request_id"),
+		Limit:      query.Int("limit", 1, maxPageLimit, 0),
+		Offset:     query.Int("offset", 0, math.MaxInt32, 0),
+	}
+
+	if err := query.Err(); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
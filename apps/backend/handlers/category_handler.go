@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// CategoryHandler handles category HTTP requests
+type CategoryHandler struct {
+	categoryService *services.CategoryService
+}
+
+// NewCategoryHandler creates a new CategoryHandler instance
+func NewCategoryHandler(categoryService *services.CategoryService) *CategoryHandler {
+	return &CategoryHandler{
+		categoryService: categoryService,
+	}
+}
+
+// GetCategories handles GET /api/categories
+func (h *CategoryHandler) GetCategories(w http.ResponseWriter, r *http.Request) {
+	response := models.APIResponse{
+		Success: true,
+		Message: "Categories retrieved successfully",
+		Data:    h.categoryService.GetAllCategories(),
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// CreateCategory handles POST /api/categories
+func (h *CategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	var request models.CategoryRequest
+	if err := decodeJSONBody(r, &request); err != nil {
+		writeServiceError(w, r, "Invalid request body", err)
+		return
+	}
+
+	category, err := h.categoryService.CreateCategory(&request)
+	if err != nil {
+		writeServiceError(w, r, "Invalid category", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Category created successfully",
+		Data:    category,
+	}
+
+	writeJSONResponse(w, http.StatusCreated, response)
+}
+
+// GetCategoryByID handles GET /api/categories/:id
+func (h *CategoryHandler) GetCategoryByID(w http.ResponseWriter, r *http.Request) {
+	category, err := h.categoryService.GetCategoryByID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeServiceError(w, r, "Category not found", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Category retrieved successfully",
+		Data:    category,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// UpdateCategory handles PATCH /api/categories/:id
+func (h *CategoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	var update models.CategoryUpdate
+	if err := decodeJSONBody(r, &update); err != nil {
+		writeServiceError(w, r, "Invalid request body", err)
+		return
+	}
+
+	category, err := h.categoryService.UpdateCategory(r.Context(), chi.URLParam(r, "id"), &update)
+	if err != nil {
+		writeServiceError(w, r, "Failed to update category", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Category updated successfully",
+		Data:    category,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// DeleteCategory handles DELETE /api/categories/:id
+func (h *CategoryHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	if err := h.categoryService.DeleteCategory(chi.URLParam(r, "id")); err != nil {
+		writeServiceError(w, r, "Failed to delete category", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Category deleted successfully",
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// MergeCategory handles POST /api/categories/:id/merge
+func (h *CategoryHandler) MergeCategory(w http.ResponseWriter, r *http.Request) {
+	var merge models.CategoryMerge
+	if err := decodeJSONBody(r, &merge); err != nil {
+		writeServiceError(w, r, "Invalid request body", err)
+		return
+	}
+
+	result, err := h.categoryService.MergeCategory(r.Context(), chi.URLParam(r, "id"), &merge)
+	if err != nil {
+		writeServiceError(w, r, "Failed to merge category", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Category merged successfully",
+		Data:    result,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetCategoryReport handles GET /api/analytics/categories. The period defaults to the
+// current month to date.
+func (h *CategoryHandler) GetCategoryReport(w http.ResponseWriter, r *http.Request) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	reportQuery := models.CategoryReportQuery{
+		From: today.AddDate(0, 0, 1-today.Day()),
+		To:   today,
+	}
+
+	query := newQueryValidator(r)
+	if from := query.Date("from"); from != nil {
+		reportQuery.From = *from
+	}
+	if to := query.Date("to"); to != nil {
+		reportQuery.To = *to
+	}
+	reportQuery.AccountID = query.String("account_id")
+	reportQuery.IncludeTransfers = query.Bool("include_transfers", false)
+	if err := query.Err(); err != nil {
+		writeErrorResponse(w, r, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	report, err := h.categoryService.Report(reportQuery)
+	if err != nil {
+		writeServiceError(w, r, "Invalid category report query", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Category report retrieved successfully",
+		Data:    report,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func newCategoryRouter() chi.Router {
+	handler := NewCategoryHandler(services.NewCategoryService(services.NewTransactionService()))
+	r := chi.NewRouter()
+	r.Get("/api/categories", handler.GetCategories)
+	r.Post("/api/categories", handler.CreateCategory)
+	r.Get("/api/categories/{id}", handler.GetCategoryByID)
+	r.Patch("/api/categories/{id}", handler.UpdateCategory)
+	r.Delete("/api/categories/{id}", handler.DeleteCategory)
+	r.Post("/api/categories/{id}/merge", handler.MergeCategory)
+	r.Get("/api/analytics/categories", handler.GetCategoryReport)
+	return r
+}
+
+func TestCategoryHandler_Categories(t *testing.T) {
+	r := newCategoryRouter()
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := serve("POST", "/api/categories", `{"name":"Pets","kind":"expense","icon":"🐾"}`)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("handler returned wrong status code: got %v want %v: %s", rr.Code, http.StatusCreated, rr.Body.String())
+	}
+	var created struct {
+		Data models.Category `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &created); err != nil {
+		t.Fatal(err)
+	}
+	if created.Data.ID != "pets" || !created.Data.Custom {
+		t.Errorf("Unexpected category %+v", created.Data)
+	}
+
+	// Steps run in order: each depends on the one before
+	for _, step := range []struct {
+		method, path, body string
+		want               int
+	}{
+		{"POST", "/api/categories", `{"name":"Pets","kind":"expense"}`, http.StatusConflict},
+		{"POST", "/api/categories", `{"name":"Vet","parent_id":"pets","kind":"income"}`, http.StatusBadRequest},
+		{"POST", "/api/categories", `{"name":"Vet","parent_id":"pets"}`, http.StatusCreated},
+		{"GET", "/api/categories/vet", "", http.StatusOK},
+		{"PATCH", "/api/categories/vet", `{"parent_id":"vet"}`, http.StatusBadRequest},
+		{"PATCH", "/api/categories/pets", `{"name":"Pet care"}`, http.StatusOK},
+		{"DELETE", "/api/categories/pets", "", http.StatusConflict},
+		{"POST", "/api/categories/pets/merge", `{"into":"vet"}`, http.StatusBadRequest},
+		{"POST", "/api/categories/vet/merge", `{"into":"healthcare"}`, http.StatusOK},
+		{"DELETE", "/api/categories/pets", "", http.StatusOK},
+		{"GET", "/api/categories/pets", "", http.StatusNotFound},
+	} {
+		if rr := serve(step.method, step.path, step.body); rr.Code != step.want {
+			t.Errorf("%s %s: got %d, want %d: %s", step.method, step.path, rr.Code, step.want, rr.Body.String())
+		}
+	}
+
+	rr = serve("GET", "/api/categories", "")
+	var list struct {
+		Data []models.Category `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Data) == 0 || list.Data[0].ID != "income" {
+		t.Errorf("Expected the tree to start with income, got %+v", list.Data)
+	}
+}
+
+func TestCategoryHandler_GetCategoryReport(t *testing.T) {
+	r := newCategoryRouter()
+
+	// Without parameters the report covers the current month to date
+	req, _ := http.NewRequest("GET", "/api/analytics/categories", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+	var response struct {
+		Data models.CategoryReport `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	today := time.Now().UTC()
+	if response.Data.To != today.Format("2006-01-02") || response.Data.From != today.AddDate(0, 0, 1-today.Day()).Format("2006-01-02") {
+		t.Errorf("Unexpected default period %+v", response.Data)
+	}
+
+	for _, path := range []string{
+		"/api/analytics/categories?from=March&include_transfers=maybe",
+		"/api/analytics/categories?from=2024-03-10&to=2024-03-01",
+	} {
+		req, _ = http.NewRequest("GET", path, nil)
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: got %d, want %d", path, rr.Code, http.StatusBadRequest)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// entityTag returns a strong ETag for value as served to r. The API version is part of the
+// tag because v1 and v2 represent the same state with different bytes.
+func entityTag(r *http.Request, value interface{}) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+
+	version := APIVersion1
+	if isV2Request(r) {
+		version = APIVersion2
+	}
+
+	sum := sha256.Sum256(data)
+	return fmt.Sprintf(`"v%d-%s"`, version, hex.EncodeToString(sum[:16]))
+}
+
+// notModified sets the ETag and Last-Modified validators and reports whether the client's
+// cached copy is current, in which case a 304 has already been written
+func notModified(w http.ResponseWriter, r *http.Request, etag string, lastModified time.Time) bool {
+	if etag != "" {
+		w.Header().Set("ETag", etag)
+	}
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+	// Caches may store the response but must revalidate it before reuse
+	w.Header().Set("Cache-Control", "private, no-cache")
+
+	// If-None-Match takes precedence over If-Modified-Since (RFC 9110 section 13.2.2)
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		if etag == "" || !etagListMatches(ifNoneMatch, etag, false) {
+			return false
+		}
+	} else {
+		since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+		if err != nil || lastModified.IsZero() || lastModified.Truncate(time.Second).After(since) {
+			return false
+		}
+	}
+
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// preconditionFailed checks If-Match against the current ETag of the target resource and
+// writes a 412 when it does not match, so a client cannot overwrite changes it has not seen
+func preconditionFailed(w http.ResponseWriter, r *http.Request, etag string) bool {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" || etagListMatches(ifMatch, etag, true) {
+		return false
+	}
+
+	w.Header().Set("ETag", etag)
+	WriteError(w, r, http.StatusPreconditionFailed, "precondition_failed",
+		"Resource has changed since it was retrieved", nil)
+	return true
+}
+
+// etagListMatches reports whether a comma-separated list of entity tags, or "*", contains
+// etag. Strong comparison ignores weak tags; weak comparison ignores the W/ prefix.
+func etagListMatches(list, etag string, strong bool) bool {
+	for _, candidate := range strings.Split(list, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if strings.HasPrefix(candidate, "W/") {
+			if strong {
+				continue
+			}
+			candidate = strings.TrimPrefix(candidate, "W/")
+		}
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// latestUpdate returns the most recent LastUpdated of accounts
+func latestUpdate(accounts []*models.Account) time.Time {
+	var latest time.Time
+	for _, account := range accounts {
+		if account.LastUpdated.After(latest) {
+			latest = account.LastUpdated
+		}
+	}
+	return latest
+}
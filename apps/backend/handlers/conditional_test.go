@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// serveConditional performs a request with the given headers
+func serveConditional(r http.Handler, method, path string, body []byte, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewReader(body))
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestConditional_AccountsNotModified(t *testing.T) {
+	handler := NewAccountHandler(services.NewAccountService())
+	r := chi.NewRouter()
+	r.Get("/api/accounts", handler.GetAccounts)
+	r.Get("/api/accounts/{id}", handler.GetAccountByID)
+	r.Get("/api/v2/accounts/{id}", handler.GetAccountByIDV2)
+	r.Post("/api/accounts/{id}/refresh", handler.RefreshAccount)
+
+	for _, path := range []string{"/api/accounts", "/api/accounts/acc_001"} {
+		first := serveConditional(r, "GET", path, nil, nil)
+		etag := first.Header().Get("ETag")
+		if first.Code != http.StatusOK || etag == "" || first.Header().Get("Last-Modified") == "" {
+			t.Fatalf("%s: expected 200 with ETag and Last-Modified, got %d %v", path, first.Code, first.Header())
+		}
+
+		// The list is served in a stable order, so the tag does not change between requests
+		if again := serveConditional(r, "GET", path, nil, nil); again.Header().Get("ETag") != etag {
+			t.Errorf("%s: ETag changed without a change in content", path)
+		}
+
+		rr := serveConditional(r, "GET", path, nil, map[string]string{"If-None-Match": `"other", W/` + etag})
+		if rr.Code != http.StatusNotModified || rr.Body.Len() != 0 {
+			t.Errorf("%s: expected an empty 304 for a matching If-None-Match, got %d", path, rr.Code)
+		}
+
+		rr = serveConditional(r, "GET", path, nil, map[string]string{"If-Modified-Since": first.Header().Get("Last-Modified")})
+		if rr.Code != http.StatusNotModified {
+			t.Errorf("%s: expected 304 for an unchanged If-Modified-Since, got %d", path, rr.Code)
+		}
+
+		// If-None-Match takes precedence over If-Modified-Since
+		rr = serveConditional(r, "GET", path, nil, map[string]string{
+			"If-None-Match":     `"stale"`,
+			"If-Modified-Since": first.Header().Get("Last-Modified"),
+		})
+		if rr.Code != http.StatusOK {
+			t.Errorf("%s: expected 200 for a stale If-None-Match, got %d", path, rr.Code)
+		}
+	}
+
+	// v1 and v2 representations have different tags
+	v1 := serveConditional(r, "GET", "/api/accounts/acc_001", nil, nil).Header().Get("ETag")
+	v2 := serveConditional(r, "GET", "/api/v2/accounts/acc_001", nil, nil).Header().Get("ETag")
+	if v1 == v2 {
+		t.Errorf("Expected v1 and v2 ETags to differ, both were %s", v1)
+	}
+
+	// A refresh changes the account, so the old tag is no longer current
+	if rr := serveConditional(r, "POST", "/api/accounts/acc_001/refresh", nil, nil); rr.Code != http.StatusOK {
+		t.Fatalf("Expected refresh to succeed, got %d", rr.Code)
+	}
+	if rr := serveConditional(r, "GET", "/api/accounts/acc_001", nil, map[string]string{"If-None-Match": v1}); rr.Code != http.StatusOK {
+		t.Errorf("Expected 200 after the account changed, got %d", rr.Code)
+	}
+}
+
+func TestConditional_TransactionsNotModified(t *testing.T) {
+	handler := NewTransactionHandler(services.NewTransactionService())
+	r := chi.NewRouter()
+	r.Get("/api/transactions", handler.GetTransactions)
+	r.Get("/api/v2/accounts/{id}/transactions", handler.GetTransactionsByAccountV2)
+
+	for _, path := range []string{"/api/transactions?limit=5", "/api/v2/accounts/acc_001/transactions"} {
+		first := serveConditional(r, "GET", path, nil, nil)
+		etag := first.Header().Get("ETag")
+		if first.Code != http.StatusOK || etag == "" {
+			t.Fatalf("%s: expected 200 with an ETag, got %d", path, first.Code)
+		}
+
+		rr := serveConditional(r, "GET", path, nil, map[string]string{"If-None-Match": etag})
+		if rr.Code != http.StatusNotModified {
+			t.Errorf("%s: expected 304, got %d", path, rr.Code)
+		}
+	}
+
+	// A different page is a different representation
+	page := serveConditional(r, "GET", "/api/transactions?limit=5", nil, nil).Header().Get("ETag")
+	rr := serveConditional(r, "GET", "/api/transactions?limit=5&offset=5", nil, map[string]string{"If-None-Match": page})
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected 200 for another page, got %d", rr.Code)
+	}
+}
+
+func TestConditional_IfMatchPreventsLostUpdates(t *testing.T) {
+	r := newWebhookRouter()
+
+	body, _ := json.Marshal(models.WebhookSubscriptionRequest{
+		URL:        "http://127.0.0.1:1/hook",
+		EventTypes: []string{models.EventTransactionCreated},
+	})
+	rr := serveConditional(r, "POST", "/api/webhooks", body, nil)
+	var created struct {
+		Data models.WebhookSubscription `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &created); err != nil {
+		t.Fatal(err)
+	}
+	path := "/api/webhooks/" + created.Data.ID
+	etag := serveConditional(r, "GET", path, nil, nil).Header().Get("ETag")
+
+	// The first writer wins and gets the new tag
+	update, _ := json.Marshal(models.WebhookSubscriptionRequest{
+		URL:        "http://127.0.0.1:1/first",
+		EventTypes: []string{models.EventTransactionCreated},
+	})
+	rr = serveConditional(r, "PUT", path, update, map[string]string{"If-Match": etag})
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected the first update to succeed, got %d: %s", rr.Code, rr.Body.String())
+	}
+	newTag := rr.Header().Get("ETag")
+	if newTag == "" || newTag == etag {
+		t.Fatalf("Expected a new ETag after the update, got %q", newTag)
+	}
+	if current := serveConditional(r, "GET", path, nil, nil).Header().Get("ETag"); current != newTag {
+		t.Errorf("Expected the PUT ETag %s to match a fresh GET, got %s", newTag, current)
+	}
+
+	// A second writer holding the old tag is rejected
+	update, _ = json.Marshal(models.WebhookSubscriptionRequest{
+		URL:        "http://127.0.0.1:1/second",
+		EventTypes: []string{models.EventTransactionCreated},
+	})
+	rr = serveConditional(r, "PUT", path, update, map[string]string{"If-Match": etag})
+	if rr.Code != http.StatusPreconditionFailed || rr.Header().Get("ETag") != newTag {
+		t.Fatalf("Expected 412 with the current ETag, got %d %q", rr.Code, rr.Header().Get("ETag"))
+	}
+	var response models.APIResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil || response.Code != "precondition_failed" {
+		t.Errorf("Expected precondition_failed, got %+v (%v)", response, err)
+	}
+
+	// Weak tags never satisfy If-Match
+	rr = serveConditional(r, "DELETE", path, nil, map[string]string{"If-Match": "W/" + newTag})
+	if rr.Code != http.StatusPreconditionFailed {
+		t.Errorf("Expected 412 for a weak If-Match, got %d", rr.Code)
+	}
+	rr = serveConditional(r, "DELETE", path, nil, map[string]string{"If-Match": newTag})
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected the delete to succeed, got %d", rr.Code)
+	}
+}
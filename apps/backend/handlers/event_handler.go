@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+)
+
+// defaultHeartbeatInterval keeps idle connections alive through proxies
+const defaultHeartbeatInterval = 15 * time.Second
+
+// EventHandler streams change events to clients using Server-Sent Events
+type EventHandler struct {
+	eventBus          *services.EventBus
+	heartbeatInterval time.Duration
+}
+
+// NewEventHandler creates a new EventHandler instance
+func NewEventHandler(eventBus *services.EventBus) *EventHandler {
+	return &EventHandler{
+		eventBus:          eventBus,
+		heartbeatInterval: defaultHeartbeatInterval,
+	}
+}
+
+// StreamEvents handles GET /api/events
+func (h *EventHandler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeErrorResponse(w, r, http.StatusInternalServerError, "Streaming is not supported", nil)
+		return
+	}
+
+	// Resume from the last event the client saw, if it is still buffered
+	var lastEventID uint64
+	if lastID := r.Header.Get("Last-Event-ID"); lastID != "" {
+		if parsedID, err := strconv.ParseUint(lastID, 10, 64); err == nil {
+			lastEventID = parsedID
+		}
+	}
+
+	replay, events, unsubscribe := h.eventBus.Subscribe(lastEventID)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// Tell the browser how long to wait before reconnecting
+	fmt.Fprintf(w, "retry: %d\n\n", 3000)
+	for _, event := range replay {
+		if err := h.writeEvent(w, event); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, open := <-events:
+			if !open {
+				// Dropped for falling behind; the client reconnects with Last-Event-ID
+				return
+			}
+			if err := h.writeEvent(w, event); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeEvent writes a single event in SSE wire format
+func (h *EventHandler) writeEvent(w http.ResponseWriter, event models.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
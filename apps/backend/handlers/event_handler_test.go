@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// readSSEField reads lines from the stream until one starts with prefix
+func readSSEField(t *testing.T, reader *bufio.Reader, prefix string) string {
+	t.Helper()
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Stream ended before %q: %v", prefix, err)
+		}
+		if strings.HasPrefix(line, prefix) {
+			return strings.TrimSpace(strings.TrimPrefix(line, prefix))
+		}
+	}
+}
+
+func TestEventHandler_StreamEvents(t *testing.T) {
+	eventBus := services.NewEventBus(16)
+	accountService := services.NewAccountService()
+	accountService.SetEventBus(eventBus)
+	handler := NewEventHandler(eventBus)
+	handler.heartbeatInterval = 20 * time.Millisecond
+
+	r := chi.NewRouter()
+	r.Get("/api/events", handler.StreamEvents)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	// Published before connecting, so only reachable through Last-Event-ID replay
+	eventBus.Publish(models.EventAccountUpdated, "first")
+	eventBus.Publish(models.EventAccountUpdated, "second")
+
+	req, err := http.NewRequest("GET", server.URL+"/api/events", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Last-Event-ID", "1")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if contentType := resp.Header.Get("Content-Type"); contentType != "text/event-stream" {
+		t.Errorf("Expected text/event-stream content type, got %v", contentType)
+	}
+
+	reader := bufio.NewReader(resp.Body)
+	if id := readSSEField(t, reader, "id:"); id != "2" {
+		t.Errorf("Expected replay to resume at event 2, got %v", id)
+	}
+
+	// Heartbeats keep the connection alive while idle
+	readSSEField(t, reader, ": heartbeat")
+
+	// Live events follow the replay
+	if _, err := accountService.RefreshAccount(context.Background(), "acc_001"); err != nil {
+		t.Fatal(err)
+	}
+	if eventType := readSSEField(t, reader, "event:"); eventType != models.EventAccountUpdated {
+		t.Errorf("Expected %v event, got %v", models.EventAccountUpdated, eventType)
+	}
+	if eventType := readSSEField(t, reader, "event:"); eventType != models.EventRefreshCompleted {
+		t.Errorf("Expected %v event, got %v", models.EventRefreshCompleted, eventType)
+	}
+}
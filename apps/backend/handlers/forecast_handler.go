@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ForecastHandler handles balance forecast and scheduled item HTTP requests
+type ForecastHandler struct {
+	forecastService *services.ForecastService
+}
+
+// NewForecastHandler creates a new ForecastHandler instance
+func NewForecastHandler(forecastService *services.ForecastService) *ForecastHandler {
+	return &ForecastHandler{
+		forecastService: forecastService,
+	}
+}
+
+// GetForecast handles GET /api/forecast
+func (h *ForecastHandler) GetForecast(w http.ResponseWriter, r *http.Request) {
+	query := newQueryValidator(r)
+	forecastQuery := models.ForecastQuery{
+		Days:      query.Int("days", 1, 365, 90),
+		Threshold: query.Float("threshold", 0, 0),
+		AccountID: query.String("account_id"),
+	}
+	if err := query.Err(); err != nil {
+		writeErrorResponse(w, r, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	forecast, err := h.forecastService.Forecast(forecastQuery)
+	if err != nil {
+		writeServiceError(w, r, "Failed to build forecast", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Forecast retrieved successfully",
+		Data:    forecast,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetScheduledItems handles GET /api/forecast/items
+func (h *ForecastHandler) GetScheduledItems(w http.ResponseWriter, r *http.Request) {
+	items, err := h.forecastService.GetScheduledItems()
+	if err != nil {
+		writeServiceError(w, r, "Failed to fetch scheduled items", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Scheduled items retrieved successfully",
+		Data:    items,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// CreateScheduledItem handles POST /api/forecast/items
+func (h *ForecastHandler) CreateScheduledItem(w http.ResponseWriter, r *http.Request) {
+	var request models.ScheduledItemRequest
+	if err := decodeJSONBody(r, &request); err != nil {
+		writeServiceError(w, r, "Invalid request body", err)
+		return
+	}
+
+	item, err := h.forecastService.CreateScheduledItem(&request)
+	if err != nil {
+		writeServiceError(w, r, "Invalid scheduled item", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Scheduled item created successfully",
+		Data:    item,
+	}
+
+	writeJSONResponse(w, http.StatusCreated, response)
+}
+
+// DeleteScheduledItem handles DELETE /api/forecast/items/:id
+func (h *ForecastHandler) DeleteScheduledItem(w http.ResponseWriter, r *http.Request) {
+	if err := h.forecastService.DeleteScheduledItem(chi.URLParam(r, "id")); err != nil {
+		writeServiceError(w, r, "Scheduled item not found", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Scheduled item deleted successfully",
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
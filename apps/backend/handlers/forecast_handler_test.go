@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func newForecastRouter() chi.Router {
+	handler := NewForecastHandler(services.NewForecastService(services.NewAccountService(), services.NewTransactionService()))
+	r := chi.NewRouter()
+	r.Get("/api/forecast", handler.GetForecast)
+	r.Get("/api/forecast/items", handler.GetScheduledItems)
+	r.Post("/api/forecast/items", handler.CreateScheduledItem)
+	r.Delete("/api/forecast/items/{id}", handler.DeleteScheduledItem)
+	return r
+}
+
+func TestForecastHandler_GetForecast(t *testing.T) {
+	r := newForecastRouter()
+
+	// A bill due tomorrow takes the checking account below the threshold
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	body := `{"account_id":"acc_001","description":"Insurance","amount":-600,"frequency":"once","start_date":"` + tomorrow + `T00:00:00Z"}`
+	req, _ := http.NewRequest("POST", "/api/forecast/items", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("handler returned wrong status code: got %v want %v: %s", rr.Code, http.StatusCreated, rr.Body.String())
+	}
+
+	req, _ = http.NewRequest("GET", "/api/forecast?days=14&threshold=2000&account_id=acc_001", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+
+	var response struct {
+		Data models.Forecast `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Data.Days != 14 || len(response.Data.Accounts) != 1 {
+		t.Fatalf("Expected 14 days for acc_001, got %+v", response.Data)
+	}
+	account := response.Data.Accounts[0]
+	if account.EndBalance != 1900.75 || account.FirstBelowThreshold != tomorrow || account.FirstBelowZero != "" {
+		t.Errorf("Expected 1900.75 below the threshold from %s, got %+v", tomorrow, account)
+	}
+
+	for path, want := range map[string]int{
+		"/api/forecast?days=0":                 http.StatusBadRequest,
+		"/api/forecast?threshold=-5":           http.StatusBadRequest,
+		"/api/forecast?account_id=acc_missing": http.StatusNotFound,
+	} {
+		req, _ = http.NewRequest("GET", path, nil)
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != want {
+			t.Errorf("%s: handler returned wrong status code: got %v want %v", path, rr.Code, want)
+		}
+	}
+}
+
+func TestForecastHandler_ScheduledItems(t *testing.T) {
+	r := newForecastRouter()
+
+	req, _ := http.NewRequest("POST", "/api/forecast/items", bytes.NewBufferString(`{"account_id":"acc_001","amount":100,"frequency":"daily"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusBadRequest)
+	}
+
+	req, _ = http.NewRequest("POST", "/api/forecast/items", bytes.NewBufferString(`{"account_id":"acc_002","description":"Bonus","amount":500,"frequency":"yearly","start_date":"2030-12-15T00:00:00Z"}`))
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("handler returned wrong status code: got %v want %v: %s", rr.Code, http.StatusCreated, rr.Body.String())
+	}
+
+	req, _ = http.NewRequest("GET", "/api/forecast/items", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	var response struct {
+		Data []models.ScheduledItem `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Data) != 1 || response.Data[0].ID != "sch_001" {
+		t.Fatalf("Expected sch_001, got %+v", response.Data)
+	}
+
+	for _, want := range []int{http.StatusOK, http.StatusNotFound} {
+		req, _ = http.NewRequest("DELETE", "/api/forecast/items/sch_001", nil)
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != want {
+			t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"net/http"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GoalHandler handles savings goal HTTP requests
+type GoalHandler struct {
+	goalService *services.GoalService
+}
+
+// NewGoalHandler creates a new GoalHandler instance
+func NewGoalHandler(goalService *services.GoalService) *GoalHandler {
+	return &GoalHandler{
+		goalService: goalService,
+	}
+}
+
+// GetGoals handles GET /api/goals
+func (h *GoalHandler) GetGoals(w http.ResponseWriter, r *http.Request) {
+	goals, err := h.goalService.GetAllGoals()
+	if err != nil {
+		writeServiceError(w, r, "Failed to fetch goals", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Goals retrieved successfully",
+		Data:    goals,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// CreateGoal handles POST /api/goals
+func (h *GoalHandler) CreateGoal(w http.ResponseWriter, r *http.Request) {
+	var request models.GoalRequest
+	if err := decodeJSONBody(r, &request); err != nil {
+		writeServiceError(w, r, "Invalid request body", err)
+		return
+	}
+
+	goal, err := h.goalService.CreateGoal(&request)
+	if err != nil {
+		writeServiceError(w, r, "Invalid goal", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Goal created successfully",
+		Data:    goal,
+	}
+
+	writeJSONResponse(w, http.StatusCreated, response)
+}
+
+// GetGoalByID handles GET /api/goals/:id
+func (h *GoalHandler) GetGoalByID(w http.ResponseWriter, r *http.Request) {
+	goal, err := h.goalService.GetGoalByID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeServiceError(w, r, "Goal not found", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Goal retrieved successfully",
+		Data:    goal,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// UpdateGoal handles PUT /api/goals/:id
+func (h *GoalHandler) UpdateGoal(w http.ResponseWriter, r *http.Request) {
+	var request models.GoalRequest
+	if err := decodeJSONBody(r, &request); err != nil {
+		writeServiceError(w, r, "Invalid request body", err)
+		return
+	}
+
+	goal, err := h.goalService.UpdateGoal(chi.URLParam(r, "id"), &request)
+	if err != nil {
+		writeServiceError(w, r, "Failed to update goal", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Goal updated successfully",
+		Data:    goal,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// DeleteGoal handles DELETE /api/goals/:id
+func (h *GoalHandler) DeleteGoal(w http.ResponseWriter, r *http.Request) {
+	if err := h.goalService.DeleteGoal(chi.URLParam(r, "id")); err != nil {
+		writeServiceError(w, r, "Goal not found", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Goal deleted successfully",
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetGoalHistory handles GET /api/goals/:id/history
+func (h *GoalHandler) GetGoalHistory(w http.ResponseWriter, r *http.Request) {
+	history, err := h.goalService.GetGoalHistory(chi.URLParam(r, "id"))
+	if err != nil {
+		writeServiceError(w, r, "Goal not found", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Goal history retrieved successfully",
+		Data:    history,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
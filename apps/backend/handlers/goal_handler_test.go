@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func newGoalRouter() chi.Router {
+	handler := NewGoalHandler(services.NewGoalService(nil, services.NewAccountService()))
+	r := chi.NewRouter()
+	r.Get("/api/goals", handler.GetGoals)
+	r.Post("/api/goals", handler.CreateGoal)
+	r.Get("/api/goals/{id}", handler.GetGoalByID)
+	r.Put("/api/goals/{id}", handler.UpdateGoal)
+	r.Delete("/api/goals/{id}", handler.DeleteGoal)
+	r.Get("/api/goals/{id}/history", handler.GetGoalHistory)
+	return r
+}
+
+func TestGoalHandler_CreateAndGetGoal(t *testing.T) {
+	r := newGoalRouter()
+
+	deadline := time.Now().AddDate(1, 0, 0).Format(time.RFC3339)
+	body := `{"name":"Emergency fund","target_amount":30000,"deadline":"` + deadline + `","account_ids":["acc_006"]}`
+	req, _ := http.NewRequest("POST", "/api/goals", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("handler returned wrong status code: got %v want %v: %s", rr.Code, http.StatusCreated, rr.Body.String())
+	}
+
+	req, _ = http.NewRequest("GET", "/api/goals/goal_001", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	var response struct {
+		Data models.Goal `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Data.Progress.CurrentAmount != 25000 || response.Data.Progress.Status != models.GoalOnTrack {
+		t.Errorf("Unexpected goal %+v", response.Data)
+	}
+
+	// The invalid update must run before the delete
+	for _, step := range []struct {
+		method string
+		want   int
+	}{{"PUT", http.StatusBadRequest}, {"DELETE", http.StatusOK}} {
+		req, _ = http.NewRequest(step.method, "/api/goals/goal_001", bytes.NewBufferString(`{"name":"","target_amount":-1}`))
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != step.want {
+			t.Errorf("%s: handler returned wrong status code: got %v want %v", step.method, rr.Code, step.want)
+		}
+	}
+
+	req, _ = http.NewRequest("GET", "/api/goals/goal_001/history", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"financial-aggregator-api/backend/services"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// maxGraphQLRequestBytes bounds the size of a POSTed GraphQL request
+const maxGraphQLRequestBytes = 1 << 20
+
+// GraphQLHandler serves the GraphQL endpoint over accounts and transactions
+type GraphQLHandler struct {
+	schema       graphql.Schema
+	accounts     graphQLAccountReader
+	transactions graphQLTransactionReader
+}
+
+// graphQLRequest is a GraphQL-over-HTTP request
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// NewGraphQLHandler creates a new GraphQLHandler instance
+func NewGraphQLHandler(accountService *services.AccountService, transactionService *services.TransactionService) *GraphQLHandler {
+	return newGraphQLHandler(accountService, transactionService)
+}
+
+// newGraphQLHandler builds the handler over any account and transaction readers
+func newGraphQLHandler(accounts graphQLAccountReader, transactions graphQLTransactionReader) *GraphQLHandler {
+	schema, err := newGraphQLSchema(accounts, transactions)
+	if err != nil {
+		// The schema is static, so this only fails on a programming error
+		panic("invalid GraphQL schema: " + err.Error())
+	}
+
+	return &GraphQLHandler{
+		schema:       schema,
+		accounts:     accounts,
+		transactions: transactions,
+	}
+}
+
+// ServeGraphQL handles GET and POST /graphql
+func (h *GraphQLHandler) ServeGraphQL(w http.ResponseWriter, r *http.Request) {
+	request, err := h.decodeRequest(w, r)
+	if err != nil {
+		h.writeRequestError(w, err.Error())
+		return
+	}
+	if request.Query == "" {
+		h.writeRequestError(w, "query is required")
+		return
+	}
+
+	document, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(request.Query)})})
+	if err != nil {
+		writeJSONResponse(w, http.StatusBadRequest, graphql.Result{Errors: gqlerrors.FormatErrors(err)})
+		return
+	}
+	if err := checkGraphQLLimits(document, request.OperationName, request.Variables); err != nil {
+		h.writeRequestError(w, err.Error())
+		return
+	}
+
+	// Loaders live for one request so batches never leak data between callers
+	ctx := context.WithValue(r.Context(), graphQLLoadersKey{}, newGraphQLLoaders(h.accounts, h.transactions))
+
+	result := graphql.Do(graphql.Params{
+		Schema:         h.schema,
+		RequestString:  request.Query,
+		OperationName:  request.OperationName,
+		VariableValues: request.Variables,
+		Context:        ctx,
+	})
+
+	// Without data the request never executed, e.g. it failed validation
+	statusCode := http.StatusOK
+	if result.Data == nil && result.HasErrors() {
+		statusCode = http.StatusBadRequest
+	}
+
+	writeJSONResponse(w, statusCode, result)
+}
+
+// decodeRequest reads a request from the query string (GET) or a JSON body (POST)
+func (h *GraphQLHandler) decodeRequest(w http.ResponseWriter, r *http.Request) (*graphQLRequest, error) {
+	request := &graphQLRequest{}
+
+	if r.Method == http.MethodGet {
+		query := r.URL.Query()
+		request.Query = query.Get("query")
+		request.OperationName = query.Get("operationName")
+		if variables := query.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &request.Variables); err != nil {
+				return nil, err
+			}
+		}
+		return request, nil
+	}
+
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxGraphQLRequestBytes)).Decode(request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// writeRequestError writes a GraphQL error response for a request that was not executed
+func (h *GraphQLHandler) writeRequestError(w http.ResponseWriter, message string) {
+	writeJSONResponse(w, http.StatusBadRequest, graphql.Result{
+		Errors: []gqlerrors.FormattedError{{Message: message}},
+	})
+}
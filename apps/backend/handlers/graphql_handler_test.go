@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+)
+
+// countingAccountReader counts service calls so tests can assert batching
+type countingAccountReader struct {
+	*services.AccountService
+	batches int
+}
+
+func (c *countingAccountReader) GetAccountsByIDs(ids []string) (map[string]*models.Account, error) {
+	c.batches++
+	return c.AccountService.GetAccountsByIDs(ids)
+}
+
+type countingTransactionReader struct {
+	*services.TransactionService
+	batches int
+}
+
+func (c *countingTransactionReader) GetTransactionsByAccountIDs(accountIDs []string, filter *models.TransactionFilter) (map[string][]*models.Transaction, error) {
+	c.batches++
+	return c.TransactionService.GetTransactionsByAccountIDs(accountIDs, filter)
+}
+
+type graphQLTestResponse struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func postGraphQL(t *testing.T, handler *GraphQLHandler, query string) (int, graphQLTestResponse) {
+	t.Helper()
+
+	body, _ := json.Marshal(graphQLRequest{Query: query})
+	req, err := http.NewRequest("POST", "/graphql", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeGraphQL(rr, req)
+
+	var response graphQLTestResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid GraphQL response %q: %v", rr.Body.String(), err)
+	}
+	return rr.Code, response
+}
+
+func TestGraphQLHandler_BatchesNestedFields(t *testing.T) {
+	accounts := &countingAccountReader{AccountService: services.NewAccountService()}
+	transactions := &countingTransactionReader{TransactionService: services.NewTransactionService()}
+	handler := newGraphQLHandler(accounts, transactions)
+
+	status, response := postGraphQL(t, handler, `{
+		accounts {
+			id
+			transactions(limit: 5) { id account { id name } }
+		}
+	}`)
+	if status != http.StatusOK || len(response.Errors) != 0 {
+		t.Fatalf("Expected 200 without errors, got %v %+v", status, response.Errors)
+	}
+
+	var data []struct {
+		ID           string `json:"id"`
+		Transactions []struct {
+			ID      string `json:"id"`
+			Account struct {
+				ID string `json:"id"`
+			} `json:"account"`
+		} `json:"transactions"`
+	}
+	if err := json.Unmarshal(response.Data["accounts"], &data); err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 7 {
+		t.Fatalf("Expected 7 accounts, got %d", len(data))
+	}
+	for _, account := range data {
+		for _, transaction := range account.Transactions {
+			if transaction.Account.ID != account.ID {
+				t.Errorf("Transaction %s resolved to account %s, want %s", transaction.ID, transaction.Account.ID, account.ID)
+			}
+		}
+	}
+
+	// Six accounts with nested transactions and accounts cost one batch per level
+	if transactions.batches != 1 || accounts.batches != 1 {
+		t.Errorf("Expected one batch per level, got %d transaction and %d account batches", transactions.batches, accounts.batches)
+	}
+}
+
+func TestGraphQLHandler_FiltersAndSummary(t *testing.T) {
+	handler := NewGraphQLHandler(services.NewAccountService(), services.NewTransactionService())
+
+	status, response := postGraphQL(t, handler, `{
+		transactions(account_id: "acc_001", type: debit) { id type }
+		summary(account_id: "acc_002") { count total_credits net }
+		transaction(id: "missing") { id }
+	}`)
+	if status != http.StatusOK || len(response.Errors) != 0 {
+		t.Fatalf("Expected 200 without errors, got %v %+v", status, response.Errors)
+	}
+
+	var filtered []models.Transaction
+	if err := json.Unmarshal(response.Data["transactions"], &filtered); err != nil {
+		t.Fatal(err)
+	}
+	if len(filtered) != 4 {
+		t.Errorf("Expected 4 debits for acc_001, got %d", len(filtered))
+	}
+	for _, transaction := range filtered {
+		if transaction.Type != "debit" {
+			t.Errorf("Expected only debits, got %s", transaction.Type)
+		}
+	}
+
+	var summary transactionSummary
+	if err := json.Unmarshal(response.Data["summary"], &summary); err != nil {
+		t.Fatal(err)
+	}
+	if summary.Count != 2 || summary.TotalCredits != 1500 || summary.Net != 1500 {
+		t.Errorf("Unexpected summary for acc_002: %+v", summary)
+	}
+
+	if string(response.Data["transaction"]) != "null" {
+		t.Errorf("Expected a missing transaction to resolve to null, got %s", response.Data["transaction"])
+	}
+}
+
+func TestGraphQLHandler_RejectsExpensiveQueries(t *testing.T) {
+	handler := NewGraphQLHandler(services.NewAccountService(), services.NewTransactionService())
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "too deep",
+			query: `{ accounts { transactions { account { transactions { account { transactions { id } } } } } } }`,
+			want:  "depth",
+		},
+		{
+			name:  "too complex",
+			query: `{ accounts { transactions(limit: 1000) { id account { id } } } }`,
+			want:  "complexity",
+		},
+		{
+			name:  "syntax error",
+			query: `{ accounts { id }`,
+			want:  "Syntax Error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, response := postGraphQL(t, handler, tt.query)
+			if status != http.StatusBadRequest {
+				t.Errorf("Expected status %d, got %d", http.StatusBadRequest, status)
+			}
+			if len(response.Errors) == 0 || !strings.Contains(response.Errors[0].Message, tt.want) {
+				t.Errorf("Expected an error mentioning %q, got %+v", tt.want, response.Errors)
+			}
+		})
+	}
+}
+
+func TestGraphQLHandler_GetWithVariables(t *testing.T) {
+	handler := NewGraphQLHandler(services.NewAccountService(), services.NewTransactionService())
+
+	params := url.Values{}
+	params.Set("query", `query Account($id: ID!) { account(id: $id) { name } }`)
+	params.Set("variables", `{"id":"acc_003"}`)
+	req, err := http.NewRequest("GET", "/graphql?"+params.Encode(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeGraphQL(rr, req)
+
+	if status := rr.Code; status != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+	if !strings.Contains(rr.Body.String(), `"name":"Credit Card"`) {
+		t.Errorf("Expected acc_003 in response, got %s", rr.Body.String())
+	}
+}
+
+func TestGraphQLHandler_Liability(t *testing.T) {
+	handler := NewGraphQLHandler(services.NewAccountService(), services.NewTransactionService())
+
+	status, response := postGraphQL(t, handler, `{ card: account(id: "acc_003") { liability { apr minimum_payment principal due_date } } checking: account(id: "acc_001") { liability { apr } } }`)
+	if status != http.StatusOK || len(response.Errors) > 0 {
+		t.Fatalf("Unexpected status %d or errors %v", status, response.Errors)
+	}
+
+	var card struct {
+		Liability struct {
+			APR            float64  `json:"apr"`
+			MinimumPayment float64  `json:"minimum_payment"`
+			Principal      *float64 `json:"principal"`
+			DueDate        *string  `json:"due_date"`
+		} `json:"liability"`
+	}
+	if err := json.Unmarshal(response.Data["card"], &card); err != nil {
+		t.Fatal(err)
+	}
+	// A card has no principal
+	if card.Liability.APR != 24.99 || card.Liability.MinimumPayment != 35 || card.Liability.Principal != nil || card.Liability.DueDate == nil {
+		t.Errorf("Unexpected card liability %+v", card.Liability)
+	}
+	if string(response.Data["checking"]) != `{"liability":null}` {
+		t.Errorf("Expected no liability for a checking account, got %s", response.Data["checking"])
+	}
+}
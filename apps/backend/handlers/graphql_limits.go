@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Query limits, checked before execution so expensive queries never reach the services
+const (
+	maxGraphQLDepth      = 6
+	maxGraphQLComplexity = 2500
+
+	// defaultListSize estimates the length of list fields that take no limit argument
+	defaultListSize = 10
+)
+
+// graphQLListFields are the fields that return lists, with the argument that bounds them
+var graphQLListFields = map[string]string{
+	"accounts":     "",
+	"transactions": "limit",
+	"by_category":  "",
+}
+
+// queryCost is the measured depth and complexity of an operation
+type queryCost struct {
+	depth      int
+	complexity int
+}
+
+// checkGraphQLLimits rejects operations that nest deeper than maxGraphQLDepth or whose
+// estimated cost exceeds maxGraphQLComplexity. Every field costs one, and the cost of a
+// list field's selection is multiplied by its limit argument or defaultListSize.
+// Introspection fields are not counted.
+func checkGraphQLLimits(document *ast.Document, operationName string, variables map[string]interface{}) error {
+	fragments := make(map[string]*ast.FragmentDefinition)
+	var operation *ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		switch typed := definition.(type) {
+		case *ast.FragmentDefinition:
+			fragments[typed.Name.Value] = typed
+		case *ast.OperationDefinition:
+			name := ""
+			if typed.Name != nil {
+				name = typed.Name.Value
+			}
+			if operation == nil || name == operationName {
+				operation = typed
+			}
+		}
+	}
+	if operation == nil {
+		return nil // graphql-go reports the missing operation
+	}
+
+	measure := &costMeasurer{fragments: fragments, variables: variables, visiting: make(map[string]bool)}
+	cost := measure.selectionSet(operation.SelectionSet, 1)
+
+	if cost.depth > maxGraphQLDepth {
+		return fmt.Errorf("query depth %d exceeds the maximum of %d", cost.depth, maxGraphQLDepth)
+	}
+	if cost.complexity > maxGraphQLComplexity {
+		return fmt.Errorf("query complexity %d exceeds the maximum of %d", cost.complexity, maxGraphQLComplexity)
+	}
+	return nil
+}
+
+// costMeasurer walks a selection set, expanding fragments
+type costMeasurer struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	visiting  map[string]bool // fragments on the current path, to stop cycles
+}
+
+// selectionSet measures a selection set whose fields sit at level
+func (m *costMeasurer) selectionSet(set *ast.SelectionSet, level int) queryCost {
+	cost := queryCost{}
+	if set == nil {
+		return cost
+	}
+
+	for _, selection := range set.Selections {
+		var child queryCost
+		switch typed := selection.(type) {
+		case *ast.Field:
+			child = m.field(typed, level)
+		case *ast.InlineFragment:
+			child = m.selectionSet(typed.SelectionSet, level)
+		case *ast.FragmentSpread:
+			name := typed.Name.Value
+			fragment, exists := m.fragments[name]
+			if !exists || m.visiting[name] {
+				continue
+			}
+			m.visiting[name] = true
+			child = m.selectionSet(fragment.SelectionSet, level)
+			delete(m.visiting, name)
+		}
+
+		if child.depth > cost.depth {
+			cost.depth = child.depth
+		}
+		cost.complexity += child.complexity
+	}
+
+	return cost
+}
+
+// field measures one field and its selection
+func (m *costMeasurer) field(field *ast.Field, level int) queryCost {
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return queryCost{}
+	}
+
+	children := m.selectionSet(field.SelectionSet, level+1)
+	cost := queryCost{depth: level, complexity: 1}
+	if children.depth > cost.depth {
+		cost.depth = children.depth
+	}
+
+	multiplier := 1
+	if limitArg, isList := graphQLListFields[field.Name.Value]; isList {
+		multiplier = defaultListSize
+		if limitArg != "" {
+			multiplier = m.intArgument(field, limitArg, 50)
+		}
+	}
+	cost.complexity += multiplier * children.complexity
+
+	return cost
+}
+
+// intArgument returns the value of an integer argument, resolving variables
+func (m *costMeasurer) intArgument(field *ast.Field, name string, fallback int) int {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != name {
+			continue
+		}
+
+		switch value := argument.Value.(type) {
+		case *ast.IntValue:
+			if parsed, err := strconv.Atoi(value.Value); err == nil && parsed > 0 {
+				return parsed
+			}
+		case *ast.Variable:
+			switch number := m.variables[value.Name.Value].(type) {
+			case float64:
+				if number > 0 {
+					return int(number)
+				}
+			case int:
+				if number > 0 {
+					return number
+				}
+			}
+		}
+	}
+	return fallback
+}
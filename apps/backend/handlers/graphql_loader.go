@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// graphQLAccountReader is the subset of AccountService used by the GraphQL schema
+type graphQLAccountReader interface {
+	GetAllAccounts() ([]*models.Account, error)
+	GetAccountByID(id string) (*models.Account, error)
+	GetAccountsByIDs(ids []string) (map[string]*models.Account, error)
+}
+
+// graphQLTransactionReader is the subset of TransactionService used by the GraphQL schema
+type graphQLTransactionReader interface {
+	GetAllTransactions(filter *models.TransactionFilter) ([]*models.Transaction, error)
+	GetTransactionByID(id string) (*models.Transaction, error)
+	GetTransactionsByAccountIDs(accountIDs []string, filter *models.TransactionFilter) (map[string][]*models.Transaction, error)
+}
+
+// graphQLLoadersKey is the context key for the per-request loaders
+type graphQLLoadersKey struct{}
+
+// graphQLLoaders batch nested lookups so a list of N parents costs one service call, not N.
+// Resolvers register a key and return a thunk; graphql-go runs every thunk of a level only
+// after all of its siblings have registered, so the first thunk fetches the whole batch.
+type graphQLLoaders struct {
+	accounts     *accountLoader
+	transactions map[string]*transactionLoader // keyed by filter, since arguments may differ per field
+	reader       graphQLTransactionReader
+	mutex        sync.Mutex
+}
+
+// newGraphQLLoaders creates the loaders for a single request
+func newGraphQLLoaders(accounts graphQLAccountReader, transactions graphQLTransactionReader) *graphQLLoaders {
+	return &graphQLLoaders{
+		accounts:     &accountLoader{reader: accounts, pending: make(map[string]bool), loaded: make(map[string]*models.Account)},
+		transactions: make(map[string]*transactionLoader),
+		reader:       transactions,
+	}
+}
+
+// loadersFromContext returns the loaders of the current request
+func loadersFromContext(ctx context.Context) *graphQLLoaders {
+	return ctx.Value(graphQLLoadersKey{}).(*graphQLLoaders)
+}
+
+// transactionLoaderFor returns the loader shared by every field with the same filter
+func (l *graphQLLoaders) transactionLoaderFor(filter models.TransactionFilter) *transactionLoader {
+	key := fmt.Sprintf("%s|%s|%s|%s|%v|%v|%d|%d", filter.Type, filter.Category, filter.Tag, filter.Status,
+		filter.StartDate, filter.EndDate, filter.Limit, filter.Offset)
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	loader, exists := l.transactions[key]
+	if !exists {
+		loader = &transactionLoader{
+			reader:  l.reader,
+			filter:  filter,
+			pending: make(map[string]bool),
+			loaded:  make(map[string][]*models.Transaction),
+		}
+		l.transactions[key] = loader
+	}
+	return loader
+}
+
+// accountLoader batches account lookups by ID
+type accountLoader struct {
+	reader  graphQLAccountReader
+	pending map[string]bool
+	loaded  map[string]*models.Account
+	mutex   sync.Mutex
+}
+
+// load registers id and returns a thunk resolving to its account, or nil if it does not exist
+func (l *accountLoader) load(id string) func() (interface{}, error) {
+	l.mutex.Lock()
+	if _, done := l.loaded[id]; !done {
+		l.pending[id] = true
+	}
+	l.mutex.Unlock()
+
+	return func() (interface{}, error) {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+
+		if len(l.pending) > 0 {
+			ids := sortedKeys(l.pending)
+			l.pending = make(map[string]bool)
+
+			accounts, err := l.reader.GetAccountsByIDs(ids)
+			if err != nil {
+				return nil, err
+			}
+			for _, batchID := range ids {
+				l.loaded[batchID] = accounts[batchID]
+			}
+		}
+
+		if account := l.loaded[id]; account != nil {
+			return account, nil
+		}
+		return nil, nil
+	}
+}
+
+// transactionLoader batches per-account transaction lookups that share a filter
+type transactionLoader struct {
+	reader  graphQLTransactionReader
+	filter  models.TransactionFilter
+	pending map[string]bool
+	loaded  map[string][]*models.Transaction
+	mutex   sync.Mutex
+}
+
+// load registers accountID and returns a thunk resolving to its transactions
+func (l *transactionLoader) load(accountID string) func() (interface{}, error) {
+	l.mutex.Lock()
+	if _, done := l.loaded[accountID]; !done {
+		l.pending[accountID] = true
+	}
+	l.mutex.Unlock()
+
+	return func() (interface{}, error) {
+		transactions, err := l.get(accountID)
+		if err != nil {
+			return nil, err
+		}
+		return transactions, nil
+	}
+}
+
+// get fetches every pending account on first use and returns accountID's transactions
+func (l *transactionLoader) get(accountID string) ([]*models.Transaction, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if len(l.pending) > 0 {
+		ids := sortedKeys(l.pending)
+		l.pending = make(map[string]bool)
+
+		filter := l.filter
+		grouped, err := l.reader.GetTransactionsByAccountIDs(ids, &filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			l.loaded[id] = grouped[id]
+		}
+	}
+
+	if transactions := l.loaded[accountID]; transactions != nil {
+		return transactions, nil
+	}
+	return []*models.Transaction{}, nil
+}
+
+// sortedKeys returns the keys of set in order, so batches are deterministic
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
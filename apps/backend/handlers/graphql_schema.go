@@ -0,0 +1,393 @@
+package handlers
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"sort"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/graphql-go/graphql"
+)
+
+// transactionSummary totals the completed and pending transactions of a selection
+type transactionSummary struct {
+	Count        int             `json:"count"`
+	TotalCredits float64         `json:"total_credits"`
+	TotalDebits  float64         `json:"total_debits"` // positive; debits are stored as negative amounts
+	Net          float64         `json:"net"`
+	ByCategory   []categoryTotal `json:"by_category"`
+}
+
+// categoryTotal is the net amount and count of one category in a summary
+type categoryTotal struct {
+	Category string  `json:"category"`
+	Count    int     `json:"count"`
+	Total    float64 `json:"total"`
+}
+
+// summarizeTransactions builds a summary, skipping failed and cancelled transactions
+func summarizeTransactions(transactions []*models.Transaction) transactionSummary {
+	summary := transactionSummary{ByCategory: []categoryTotal{}}
+	categories := make(map[string]*categoryTotal)
+
+	for _, transaction := range transactions {
+		if transaction.Status == "failed" || transaction.Status == "cancelled" {
+			continue
+		}
+
+		summary.Count++
+		summary.Net += transaction.Amount
+		if transaction.Amount >= 0 {
+			summary.TotalCredits += transaction.Amount
+		} else {
+			summary.TotalDebits -= transaction.Amount
+		}
+
+		total, exists := categories[transaction.Category]
+		if !exists {
+			total = &categoryTotal{Category: transaction.Category}
+			categories[transaction.Category] = total
+		}
+		total.Count++
+		total.Total += transaction.Amount
+	}
+
+	for _, total := range categories {
+		summary.ByCategory = append(summary.ByCategory, *total)
+	}
+	sort.Slice(summary.ByCategory, func(i, j int) bool {
+		return summary.ByCategory[i].Category < summary.ByCategory[j].Category
+	})
+
+	return summary
+}
+
+// optionalLiabilityField resolves a liability field to null when it is unset
+func optionalLiabilityField(value func(*models.Liability) interface{}) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		if field := value(p.Source.(*models.Liability)); !reflect.ValueOf(field).IsZero() {
+			return field, nil
+		}
+		return nil, nil
+	}
+}
+
+// transactionFilterArgs are the arguments shared by every transaction list field,
+// mirroring models.TransactionFilter
+func transactionFilterArgs(withAccount, withPaging bool) graphql.FieldConfigArgument {
+	args := graphql.FieldConfigArgument{
+		"type":       &graphql.ArgumentConfig{Type: transactionTypeEnum},
+		"category":   &graphql.ArgumentConfig{Type: graphql.String},
+		"tag":        &graphql.ArgumentConfig{Type: graphql.String},
+		"status":     &graphql.ArgumentConfig{Type: transactionStatusEnum},
+		"start_date": &graphql.ArgumentConfig{Type: graphql.String, Description: "Earliest date (YYYY-MM-DD)"},
+		"end_date":   &graphql.ArgumentConfig{Type: graphql.String, Description: "Latest date (YYYY-MM-DD)"},
+	}
+	if withAccount {
+		args["account_id"] = &graphql.ArgumentConfig{Type: graphql.ID}
+	}
+	if withPaging {
+		args["limit"] = &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 50}
+		args["offset"] = &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 0}
+	}
+	return args
+}
+
+// transactionFilterFromArgs validates field arguments into a filter
+func transactionFilterFromArgs(args map[string]interface{}) (models.TransactionFilter, error) {
+	validation := &services.ValidationError{}
+	filter := models.TransactionFilter{}
+
+	filter.AccountID, _ = args["account_id"].(string)
+	filter.Type, _ = args["type"].(string)
+	filter.Category, _ = args["category"].(string)
+	filter.Tag, _ = args["tag"].(string)
+	filter.Status, _ = args["status"].(string)
+
+	for name, target := range map[string]**time.Time{"start_date": &filter.StartDate, "end_date": &filter.EndDate} {
+		raw, ok := args[name].(string)
+		if !ok {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			validation.Addf(name, services.CodeInvalidFormat, "%s must be a date in YYYY-MM-DD format", name)
+			continue
+		}
+		*target = &date
+	}
+	if filter.StartDate != nil && filter.EndDate != nil && filter.EndDate.Before(*filter.StartDate) {
+		validation.Add("end_date", services.CodeOutOfRange, "end_date must not be before start_date")
+	}
+
+	if limit, ok := args["limit"].(int); ok {
+		if limit < 1 || limit > maxPageLimit {
+			validation.Addf("limit", services.CodeOutOfRange, "limit must be between 1 and %d", maxPageLimit)
+		}
+		filter.Limit = limit
+	}
+	if offset, ok := args["offset"].(int); ok {
+		if offset < 0 {
+			validation.Add("offset", services.CodeOutOfRange, "offset must not be negative")
+		}
+		filter.Offset = offset
+	}
+
+	return filter, validation.Err()
+}
+
+var transactionTypeEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "TransactionType",
+	Values: graphql.EnumValueConfigMap{
+		"debit":    &graphql.EnumValueConfig{Value: "debit"},
+		"credit":   &graphql.EnumValueConfig{Value: "credit"},
+		"transfer": &graphql.EnumValueConfig{Value: "transfer"},
+		"buy":      &graphql.EnumValueConfig{Value: "buy"},
+		"sell":     &graphql.EnumValueConfig{Value: "sell"},
+		"dividend": &graphql.EnumValueConfig{Value: "dividend"},
+		"fee":      &graphql.EnumValueConfig{Value: "fee"},
+	},
+})
+
+var transactionStatusEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "TransactionStatus",
+	Values: graphql.EnumValueConfigMap{
+		"pending":   &graphql.EnumValueConfig{Value: "pending"},
+		"completed": &graphql.EnumValueConfig{Value: "completed"},
+		"failed":    &graphql.EnumValueConfig{Value: "failed"},
+		"cancelled": &graphql.EnumValueConfig{Value: "cancelled"},
+	},
+})
+
+// newGraphQLSchema builds the schema over the account and transaction services.
+// Field names follow the JSON names of the REST API.
+func newGraphQLSchema(accounts graphQLAccountReader, transactions graphQLTransactionReader) (graphql.Schema, error) {
+	categoryTotalType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "CategoryTotal",
+		Fields: graphql.Fields{
+			"category": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"count":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"total":    &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+		},
+	})
+
+	summaryType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "TransactionSummary",
+		Description: "Totals over completed and pending transactions",
+		Fields: graphql.Fields{
+			"count":         &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"total_credits": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"total_debits":  &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"net":           &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"by_category":   &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(categoryTotalType)))},
+		},
+	})
+
+	liabilityType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Liability",
+		Description: "Terms of a credit card or loan",
+		Fields: graphql.Fields{
+			"apr":             &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"statement_day":   &graphql.Field{Type: graphql.Int, Resolve: optionalLiabilityField(func(l *models.Liability) interface{} { return l.StatementDay })},
+			"due_date":        &graphql.Field{Type: graphql.DateTime},
+			"minimum_payment": &graphql.Field{Type: graphql.Float, Resolve: optionalLiabilityField(func(l *models.Liability) interface{} { return l.MinimumPayment })},
+			"principal":       &graphql.Field{Type: graphql.Float, Resolve: optionalLiabilityField(func(l *models.Liability) interface{} { return l.Principal })},
+			"term_months":     &graphql.Field{Type: graphql.Int, Resolve: optionalLiabilityField(func(l *models.Liability) interface{} { return l.TermMonths })},
+			"originated_on":   &graphql.Field{Type: graphql.DateTime},
+		},
+	})
+
+	accountType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Account",
+		Fields: graphql.Fields{
+			"id":           &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"bank":         &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"account_type": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"balance":      &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"credit_limit": &graphql.Field{
+				Type:        graphql.Float,
+				Description: "Credit accounts only",
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if account := p.Source.(*models.Account); account.CreditLimit > 0 {
+						return account.CreditLimit, nil
+					}
+					return nil, nil
+				},
+			},
+			"liability": &graphql.Field{
+				Type:        liabilityType,
+				Description: "Credit and loan accounts only",
+			},
+			"currency":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"last_updated": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"is_active":    &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+			"version":      &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	transactionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Transaction",
+		Fields: graphql.Fields{
+			"id":          &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"account_id":  &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"amount":      &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"currency":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"type":        &graphql.Field{Type: graphql.NewNonNull(transactionTypeEnum)},
+			"category":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"description": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"merchant":    &graphql.Field{Type: graphql.String},
+			"date":        &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"status":      &graphql.Field{Type: graphql.NewNonNull(transactionStatusEnum)},
+			"reference":   &graphql.Field{Type: graphql.String},
+			"symbol":      &graphql.Field{Type: graphql.String},
+			"quantity":    &graphql.Field{Type: graphql.Float},
+			"price":       &graphql.Field{Type: graphql.Float},
+			"version":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"notes":       &graphql.Field{Type: graphql.String},
+			"tags": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(graphql.String))),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					if tags := p.Source.(*models.Transaction).Tags; tags != nil {
+						return tags, nil
+					}
+					return []string{}, nil
+				},
+			},
+			"account": &graphql.Field{
+				Type: accountType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					transaction := p.Source.(*models.Transaction)
+					return loadersFromContext(p.Context).accounts.load(transaction.AccountID), nil
+				},
+			},
+		},
+	})
+
+	// Nested account fields are added after Transaction exists to close the cycle
+	accountType.AddFieldConfig("transactions", &graphql.Field{
+		Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(transactionType))),
+		Args: transactionFilterArgs(false, true),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			filter, err := transactionFilterFromArgs(p.Args)
+			if err != nil {
+				return nil, err
+			}
+			account := p.Source.(*models.Account)
+			return loadersFromContext(p.Context).transactionLoaderFor(filter).load(account.ID), nil
+		},
+	})
+	accountType.AddFieldConfig("summary", &graphql.Field{
+		Type: graphql.NewNonNull(summaryType),
+		Args: transactionFilterArgs(false, false),
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			filter, err := transactionFilterFromArgs(p.Args)
+			if err != nil {
+				return nil, err
+			}
+			filter.Limit = math.MaxInt32
+
+			account := p.Source.(*models.Account)
+			loader := loadersFromContext(p.Context).transactionLoaderFor(filter)
+			thunk := loader.load(account.ID)
+			return func() (interface{}, error) {
+				result, err := thunk()
+				if err != nil {
+					return nil, err
+				}
+				return summarizeTransactions(result.([]*models.Transaction)), nil
+			}, nil
+		},
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"accounts": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(accountType))),
+				Args: graphql.FieldConfigArgument{
+					"account_type": &graphql.ArgumentConfig{Type: graphql.String},
+					"is_active":    &graphql.ArgumentConfig{Type: graphql.Boolean},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					all, err := accounts.GetAllAccounts()
+					if err != nil {
+						return nil, err
+					}
+
+					accountType, _ := p.Args["account_type"].(string)
+					isActive, filterActive := p.Args["is_active"].(bool)
+
+					matched := make([]*models.Account, 0, len(all))
+					for _, account := range all {
+						if accountType != "" && account.AccountType != accountType {
+							continue
+						}
+						if filterActive && account.IsActive != isActive {
+							continue
+						}
+						matched = append(matched, account)
+					}
+					sort.Slice(matched, func(i, j int) bool { return matched[i].ID < matched[j].ID })
+					return matched, nil
+				},
+			},
+			"account": &graphql.Field{
+				Type: accountType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFromContext(p.Context).accounts.load(p.Args["id"].(string)), nil
+				},
+			},
+			"transactions": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(transactionType))),
+				Args: transactionFilterArgs(true, true),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					filter, err := transactionFilterFromArgs(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					return transactions.GetAllTransactions(&filter)
+				},
+			},
+			"transaction": &graphql.Field{
+				Type: transactionType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					transaction, err := transactions.GetTransactionByID(p.Args["id"].(string))
+					if errors.Is(err, services.ErrNotFound) {
+						return nil, nil
+					}
+					return transaction, err
+				},
+			},
+			"summary": &graphql.Field{
+				Type: graphql.NewNonNull(summaryType),
+				Args: transactionFilterArgs(true, false),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					filter, err := transactionFilterFromArgs(p.Args)
+					if err != nil {
+						return nil, err
+					}
+					filter.Limit = math.MaxInt32
+
+					selected, err := transactions.GetAllTransactions(&filter)
+					if err != nil {
+						return nil, err
+					}
+					return summarizeTransactions(selected), nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
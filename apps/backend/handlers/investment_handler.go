@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// InvestmentHandler handles investment account HTTP requests
+type InvestmentHandler struct {
+	investmentService *services.InvestmentService
+}
+
+// NewInvestmentHandler creates a new InvestmentHandler instance
+func NewInvestmentHandler(investmentService *services.InvestmentService) *InvestmentHandler {
+	return &InvestmentHandler{
+		investmentService: investmentService,
+	}
+}
+
+// GetHoldings handles GET /api/accounts/:id/holdings
+func (h *InvestmentHandler) GetHoldings(w http.ResponseWriter, r *http.Request) {
+	holdings, err := h.investmentService.Holdings(chi.URLParam(r, "id"))
+	if err != nil {
+		writeServiceError(w, r, "Failed to fetch holdings", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Holdings retrieved successfully",
+		Data:    holdings,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetPerformance handles GET /api/accounts/:id/performance. The period defaults to the
+// account's first transaction through today, with FIFO lot matching.
+func (h *InvestmentHandler) GetPerformance(w http.ResponseWriter, r *http.Request) {
+	performanceQuery := models.PerformanceQuery{Method: models.CostBasisFIFO}
+
+	query := newQueryValidator(r)
+	if from := query.Date("from"); from != nil {
+		performanceQuery.From = *from
+	}
+	if to := query.Date("to"); to != nil {
+		performanceQuery.To = *to
+	}
+	if method := query.Enum("method", models.CostBasisFIFO, models.CostBasisAverage); method != "" {
+		performanceQuery.Method = method
+	}
+	if err := query.Err(); err != nil {
+		writeErrorResponse(w, r, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	performance, err := h.investmentService.Performance(chi.URLParam(r, "id"), performanceQuery)
+	if err != nil {
+		writeServiceError(w, r, "Failed to fetch performance", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Performance retrieved successfully",
+		Data:    performance,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
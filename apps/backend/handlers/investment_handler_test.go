@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestInvestmentHandler_GetHoldings(t *testing.T) {
+	investmentService := services.NewInvestmentService(services.NewAccountService(), services.NewTransactionService(), services.DefaultPriceFeed())
+	handler := NewInvestmentHandler(investmentService)
+	r := chi.NewRouter()
+	r.Get("/api/accounts/{id}/holdings", handler.GetHoldings)
+
+	req, _ := http.NewRequest("GET", "/api/accounts/acc_004/holdings", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+
+	var response struct {
+		Data models.Holdings `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Data.AccountID != "acc_004" || len(response.Data.Holdings) == 0 || response.Data.TotalValue == 0 {
+		t.Errorf("Expected the holdings of acc_004, got %+v", response.Data)
+	}
+
+	for path, want := range map[string]int{
+		"/api/accounts/acc_001/holdings": http.StatusBadRequest,
+		"/api/accounts/missing/holdings": http.StatusNotFound,
+	} {
+		req, _ = http.NewRequest("GET", path, nil)
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != want {
+			t.Errorf("%s: handler returned wrong status code: got %v want %v", path, rr.Code, want)
+		}
+	}
+}
+
+func TestInvestmentHandler_GetPerformance(t *testing.T) {
+	investmentService := services.NewInvestmentService(services.NewAccountService(), services.NewTransactionService(), services.DefaultPriceFeed())
+	handler := NewInvestmentHandler(investmentService)
+	r := chi.NewRouter()
+	r.Get("/api/accounts/{id}/performance", handler.GetPerformance)
+
+	req, _ := http.NewRequest("GET", "/api/accounts/acc_004/performance?method=average", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+
+	var response struct {
+		Data models.Performance `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	// The default period starts with the account's first deposit
+	if response.Data.Method != models.CostBasisAverage || response.Data.StartValue != 0 || response.Data.NetContributions == 0 || len(response.Data.Securities) == 0 {
+		t.Errorf("Expected the performance of acc_004 since its first deposit, got %+v", response.Data)
+	}
+
+	for path, want := range map[string]int{
+		"/api/accounts/acc_004/performance?method=lifo":                   http.StatusBadRequest,
+		"/api/accounts/acc_004/performance?from=2024-03-01&to=2024-02-01": http.StatusBadRequest,
+		"/api/accounts/acc_001/performance":                               http.StatusBadRequest,
+		"/api/accounts/missing/performance":                               http.StatusNotFound,
+	} {
+		req, _ = http.NewRequest("GET", path, nil)
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != want {
+			t.Errorf("%s: handler returned wrong status code: got %v want %v", path, rr.Code, want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"net/http"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// LiabilityHandler handles credit card and loan HTTP requests
+type LiabilityHandler struct {
+	liabilityService *services.LiabilityService
+}
+
+// NewLiabilityHandler creates a new LiabilityHandler instance
+func NewLiabilityHandler(liabilityService *services.LiabilityService) *LiabilityHandler {
+	return &LiabilityHandler{
+		liabilityService: liabilityService,
+	}
+}
+
+// GetLiabilities handles GET /api/liabilities
+func (h *LiabilityHandler) GetLiabilities(w http.ResponseWriter, r *http.Request) {
+	report, err := h.liabilityService.Liabilities()
+	if err != nil {
+		writeServiceError(w, r, "Failed to fetch liabilities", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Liabilities retrieved successfully",
+		Data:    report,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetLiability handles GET /api/accounts/:id/liability
+func (h *LiabilityHandler) GetLiability(w http.ResponseWriter, r *http.Request) {
+	details, err := h.liabilityService.Liability(chi.URLParam(r, "id"))
+	if err != nil {
+		writeServiceError(w, r, "Failed to fetch liability", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Liability retrieved successfully",
+		Data:    details,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// UpdateLiability handles PATCH /api/accounts/:id/liability. Fields left out of the body
+// keep their current values.
+func (h *LiabilityHandler) UpdateLiability(w http.ResponseWriter, r *http.Request) {
+	var update models.LiabilityUpdate
+	if err := decodeJSONBody(r, &update); err != nil {
+		writeServiceError(w, r, "Invalid request body", err)
+		return
+	}
+
+	details, err := h.liabilityService.UpdateLiability(r.Context(), chi.URLParam(r, "id"), &update)
+	if err != nil {
+		writeServiceError(w, r, "Failed to update liability", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Liability updated successfully",
+		Data:    details,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetAmortization handles GET /api/accounts/:id/amortization
+func (h *LiabilityHandler) GetAmortization(w http.ResponseWriter, r *http.Request) {
+	query := newQueryValidator(r)
+	amortizationQuery := models.AmortizationQuery{
+		ExtraPayment: query.Float("extra_payment", 0, 0),
+	}
+	if err := query.Err(); err != nil {
+		writeErrorResponse(w, r, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	schedule, err := h.liabilityService.Amortization(chi.URLParam(r, "id"), amortizationQuery)
+	if err != nil {
+		writeServiceError(w, r, "Failed to build amortization schedule", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Amortization schedule retrieved successfully",
+		Data:    schedule,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func newLiabilityRouter() chi.Router {
+	handler := NewLiabilityHandler(services.NewLiabilityService(services.NewAccountService()))
+	r := chi.NewRouter()
+	r.Get("/api/liabilities", handler.GetLiabilities)
+	r.Get("/api/accounts/{id}/liability", handler.GetLiability)
+	r.Patch("/api/accounts/{id}/liability", handler.UpdateLiability)
+	r.Get("/api/accounts/{id}/amortization", handler.GetAmortization)
+	return r
+}
+
+func TestLiabilityHandler_GetLiabilities(t *testing.T) {
+	r := newLiabilityRouter()
+
+	req, _ := http.NewRequest("GET", "/api/liabilities", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+
+	var response struct {
+		Data models.LiabilityReport `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Data.Liabilities) != 2 || response.Data.Utilization == nil {
+		t.Errorf("Expected the card and the loan with utilization, got %+v", response.Data)
+	}
+}
+
+func TestLiabilityHandler_UpdateLiability(t *testing.T) {
+	r := newLiabilityRouter()
+
+	req, _ := http.NewRequest("PATCH", "/api/accounts/acc_003/liability", bytes.NewBufferString(`{"version":1,"apr":19.99,"credit_limit":8000}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v: %s", rr.Code, http.StatusOK, rr.Body.String())
+	}
+
+	var response struct {
+		Data models.LiabilityDetails `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Data.APR != 19.99 || response.Data.CreditLimit != 8000 || response.Data.Version != 2 {
+		t.Errorf("Expected the new terms at version 2, got %+v", response.Data)
+	}
+
+	for body, want := range map[string]int{
+		`{"version":1,"apr":18}`:   http.StatusConflict,
+		`{"apr":18}`:               http.StatusBadRequest,
+		`{"version":2,"apr":"18"}`: http.StatusBadRequest,
+	} {
+		req, _ = http.NewRequest("PATCH", "/api/accounts/acc_003/liability", bytes.NewBufferString(body))
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != want {
+			t.Errorf("%s: handler returned wrong status code: got %v want %v", body, rr.Code, want)
+		}
+	}
+}
+
+func TestLiabilityHandler_GetAmortization(t *testing.T) {
+	r := newLiabilityRouter()
+
+	req, _ := http.NewRequest("GET", "/api/accounts/acc_007/amortization?extra_payment=100", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+
+	var response struct {
+		Data models.AmortizationSchedule `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Data.ExtraPayment != 100 || response.Data.InterestSaved == nil || len(response.Data.Payments) != response.Data.PaymentsLeft {
+		t.Errorf("Unexpected schedule %+v", response.Data)
+	}
+
+	for path, want := range map[string]int{
+		"/api/accounts/acc_007/amortization?extra_payment=-5":  http.StatusBadRequest,
+		"/api/accounts/acc_007/amortization?extra_payment=abc": http.StatusBadRequest,
+		"/api/accounts/acc_003/amortization":                   http.StatusBadRequest,
+		"/api/accounts/acc_001/liability":                      http.StatusBadRequest,
+		"/api/accounts/missing/amortization":                   http.StatusNotFound,
+	} {
+		req, _ = http.NewRequest("GET", path, nil)
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != want {
+			t.Errorf("%s: handler returned wrong status code: got %v want %v", path, rr.Code, want)
+		}
+	}
+}
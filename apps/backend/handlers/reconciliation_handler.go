@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"net/http"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ReconciliationHandler handles balance reconciliation HTTP requests
+type ReconciliationHandler struct {
+	reconciliationService *services.ReconciliationService
+}
+
+// NewReconciliationHandler creates a new ReconciliationHandler instance
+func NewReconciliationHandler(reconciliationService *services.ReconciliationService) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		reconciliationService: reconciliationService,
+	}
+}
+
+// GetReconciliation handles GET /api/accounts/:id/reconciliation
+func (h *ReconciliationHandler) GetReconciliation(w http.ResponseWriter, r *http.Request) {
+	reconciliation, err := h.reconciliationService.Reconcile(chi.URLParam(r, "id"))
+	if err != nil {
+		writeServiceError(w, r, "Failed to reconcile account", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Reconciliation retrieved successfully",
+		Data:    reconciliation,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetStatements handles GET /api/accounts/:id/statements
+func (h *ReconciliationHandler) GetStatements(w http.ResponseWriter, r *http.Request) {
+	statements, err := h.reconciliationService.GetStatements(chi.URLParam(r, "id"))
+	if err != nil {
+		writeServiceError(w, r, "Failed to fetch statements", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Statements retrieved successfully",
+		Data:    statements,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// ReconcileStatement handles POST /api/accounts/:id/statements
+func (h *ReconciliationHandler) ReconcileStatement(w http.ResponseWriter, r *http.Request) {
+	var request models.StatementRequest
+	if err := decodeJSONBody(r, &request); err != nil {
+		writeServiceError(w, r, "Invalid request body", err)
+		return
+	}
+
+	statement, err := h.reconciliationService.ReconcileStatement(chi.URLParam(r, "id"), &request)
+	if err != nil {
+		writeServiceError(w, r, "Invalid statement", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Statement reconciled successfully",
+		Data:    statement,
+	}
+
+	writeJSONResponse(w, http.StatusCreated, response)
+}
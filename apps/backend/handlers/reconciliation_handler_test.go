@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func TestReconciliationHandler(t *testing.T) {
+	handler := NewReconciliationHandler(services.NewReconciliationService(nil, services.NewAccountService(), services.NewTransactionService()))
+	r := chi.NewRouter()
+	r.Get("/api/accounts/{id}/reconciliation", handler.GetReconciliation)
+	r.Get("/api/accounts/{id}/statements", handler.GetStatements)
+	r.Post("/api/accounts/{id}/statements", handler.ReconcileStatement)
+
+	// An account first seen is taken as balanced
+	req, _ := http.NewRequest("GET", "/api/accounts/acc_001/reconciliation", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+	var response struct {
+		Data models.Reconciliation `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if response.Data.Status != models.ReconciliationBalanced || len(response.Data.History) != 1 {
+		t.Errorf("Unexpected reconciliation %+v", response.Data)
+	}
+
+	endDate := time.Now().UTC().AddDate(0, 0, -10).Format("2006-01-02")
+	for _, step := range []struct {
+		method, path, body string
+		want               int
+	}{
+		{"GET", "/api/accounts/acc_004/reconciliation", "", http.StatusBadRequest},
+		{"GET", "/api/accounts/missing/reconciliation", "", http.StatusNotFound},
+		{"POST", "/api/accounts/acc_001/statements", `{"end_date":"` + endDate + `","balance":-2148.75}`, http.StatusCreated},
+		{"POST", "/api/accounts/acc_001/statements", `{"end_date":"` + endDate + `","balance":-2148.75}`, http.StatusBadRequest},
+		{"POST", "/api/accounts/acc_001/statements", `{"balance":"100"}`, http.StatusBadRequest},
+		{"GET", "/api/accounts/acc_001/statements", "", http.StatusOK},
+		{"GET", "/api/accounts/missing/statements", "", http.StatusNotFound},
+	} {
+		req, _ = http.NewRequest(step.method, step.path, bytes.NewBufferString(step.body))
+		rr = httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != step.want {
+			t.Errorf("%s %s: got %d, want %d: %s", step.method, step.path, rr.Code, step.want, rr.Body.String())
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+)
+
+// problemContentType is the RFC 7807 media type for error responses
+const problemContentType = "application/problem+json"
+
+// writeJSONResponse writes a JSON response to the client
+func writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(data)
+}
+
+// writeServiceError writes err with the HTTP status for its kind
+func writeServiceError(w http.ResponseWriter, r *http.Request, message string, err error) {
+	writeErrorResponse(w, r, statusForError(err), message, err)
+}
+
+// statusForError maps a service error kind to an HTTP status
+func statusForError(err error) int {
+	switch {
+	case errors.Is(err, services.ErrValidation):
+		return http.StatusBadRequest
+	case errors.Is(err, services.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, services.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, services.ErrRateLimited):
+		return http.StatusTooManyRequests
+	case errors.Is(err, services.ErrUpstreamUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// errorCode returns the stable code for err, falling back to one derived from the status
+func errorCode(statusCode int, err error) string {
+	if code := services.ErrorCode(err); code != "" {
+		return code
+	}
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return "invalid_request"
+	case http.StatusNotFound:
+		return "not_found"
+	case http.StatusConflict:
+		return "conflict"
+	case http.StatusPreconditionFailed:
+		return "precondition_failed"
+	case http.StatusTooManyRequests:
+		return "rate_limited"
+	case http.StatusServiceUnavailable:
+		return "upstream_unavailable"
+	default:
+		return "internal_error"
+	}
+}
+
+// writeErrorResponse writes an error response to the client, as problem details
+// for v2 requests or when the Accept header prefers application/problem+json
+func writeErrorResponse(w http.ResponseWriter, r *http.Request, statusCode int, message string, err error) {
+	WriteError(w, r, statusCode, errorCode(statusCode, err), message, err)
+}
+
+// WriteError writes an error response with an explicit code. It is exported for
+// middleware that rejects requests before they reach a versioned route.
+func WriteError(w http.ResponseWriter, r *http.Request, statusCode int, code, message string, err error) {
+	var fieldErrors []models.FieldError
+	var validation *services.ValidationError
+	if errors.As(err, &validation) {
+		fieldErrors = validation.Fields
+	}
+
+	if isV2Request(r) || wantsProblemDetails(r) {
+		problem := models.ProblemDetails{
+			Type:     "about:blank",
+			Title:    http.StatusText(statusCode),
+			Status:   statusCode,
+			Detail:   message,
+			Instance: r.URL.Path,
+			Code:     code,
+			Errors:   fieldErrors,
+		}
+		if err != nil && fieldErrors == nil {
+			problem.Detail = message + ": " + err.Error()
+		}
+
+		w.Header().Set("Content-Type", problemContentType)
+		w.WriteHeader(statusCode)
+		json.NewEncoder(w).Encode(problem)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: false,
+		Message: message,
+		Code:    code,
+		Errors:  fieldErrors,
+	}
+
+	if err != nil {
+		response.Error = err.Error()
+	}
+
+	writeJSONResponse(w, statusCode, response)
+}
+
+// wantsProblemDetails reports whether the client ranks application/problem+json
+// at least as high as application/json
+func wantsProblemDetails(r *http.Request) bool {
+	problemQuality, jsonQuality := 0.0, 0.0
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = parsed
+			}
+		}
+
+		switch mediaType {
+		case problemContentType:
+			problemQuality = quality
+		case "application/json":
+			jsonQuality = quality
+		}
+	}
+
+	return problemQuality > 0 && problemQuality >= jsonQuality
+}
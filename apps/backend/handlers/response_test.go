@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+)
+
+func TestWantsProblemDetails(t *testing.T) {
+	tests := []struct {
+		accept   string
+		expected bool
+	}{
+		{"", false},
+		{"application/json", false},
+		{"application/problem+json", true},
+		{"application/json, application/problem+json", true},
+		{"application/json, application/problem+json;q=0.5", false},
+		{"application/problem+json;q=0.9, application/json;q=0.8", true},
+		{"application/problem+json;q=0", false},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", "/api/transactions", nil)
+		req.Header.Set("Accept", test.accept)
+
+		if got := wantsProblemDetails(req); got != test.expected {
+			t.Errorf("Accept %q: expected %v, got %v", test.accept, test.expected, got)
+		}
+	}
+}
+
+func TestWriteErrorResponse_ProblemDetails(t *testing.T) {
+	validation := &services.ValidationError{}
+	validation.Add("limit", services.CodeInvalidFormat, "limit must be an integer")
+
+	req := httptest.NewRequest("GET", "/api/transactions?limit=abc", nil)
+	req.Header.Set("Accept", "application/problem+json")
+	rr := httptest.NewRecorder()
+
+	writeErrorResponse(rr, req, http.StatusBadRequest, "Invalid query parameters", validation)
+
+	if contentType := rr.Header().Get("Content-Type"); contentType != "application/problem+json" {
+		t.Errorf("Expected problem+json content type, got %q", contentType)
+	}
+
+	var problem models.ProblemDetails
+	if err := json.Unmarshal(rr.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+
+	if problem.Status != http.StatusBadRequest || problem.Title != "Bad Request" || problem.Instance != "/api/transactions" {
+		t.Errorf("Unexpected problem details: %+v", problem)
+	}
+	if len(problem.Errors) != 1 || problem.Errors[0].Field != "limit" {
+		t.Errorf("Expected the limit field error, got %v", problem.Errors)
+	}
+}
+
+func TestWriteErrorResponse_Envelope(t *testing.T) {
+	req := httptest.NewRequest("GET", "/api/accounts/missing", nil)
+	rr := httptest.NewRecorder()
+
+	writeErrorResponse(rr, req, http.StatusNotFound, "Account not found", errors.New("account not found"))
+
+	var response models.APIResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	if response.Success || response.Error != "account not found" || response.Errors != nil {
+		t.Errorf("Unexpected error envelope: %+v", response)
+	}
+}
+
+func TestStatusForError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+		code     string
+	}{
+		{services.ErrAccountNotFound, http.StatusNotFound, "account_not_found"},
+		{services.ErrTransactionExists, http.StatusConflict, "transaction_exists"},
+		{&services.ValidationError{}, http.StatusBadRequest, "validation_failed"},
+		{services.ErrAccountProviderUnavailable.Wrap(errors.New("timeout")), http.StatusServiceUnavailable, "provider_unavailable"},
+		{services.ErrRateLimited, http.StatusTooManyRequests, "rate_limited"},
+		{errors.New("boom"), http.StatusInternalServerError, "internal_error"},
+	}
+
+	for _, test := range tests {
+		status := statusForError(test.err)
+		if status != test.expected {
+			t.Errorf("%v: expected status %d, got %d", test.err, test.expected, status)
+		}
+		if code := errorCode(status, test.err); code != test.code {
+			t.Errorf("%v: expected code %q, got %q", test.err, test.code, code)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// multipartOverhead is allowed on top of the largest attachment for part headers and
+// boundaries
+const multipartOverhead = 64 << 10
+
+// UploadAttachment handles POST /api/transactions/:id/attachments. The file is sent as the
+// "file" field of a multipart/form-data body.
+func (h *TransactionHandler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	transactionID := chi.URLParam(r, "id")
+	limits := h.transactionService.AttachmentLimits()
+	r.Body = http.MaxBytesReader(w, r.Body, UploadBodyLimit(limits))
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		validation := &services.ValidationError{}
+		validation.Add("body", services.CodeInvalidFormat, "request body must be multipart/form-data with a file field")
+		writeServiceError(w, r, "Invalid request body", validation)
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			writeServiceError(w, r, "Invalid request body", uploadError(err, limits))
+			return
+		}
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+
+		attachment, err := h.transactionService.AddAttachment(r.Context(), transactionID, part.FileName(), part)
+		part.Close()
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				err = uploadError(err, limits)
+			}
+			writeServiceError(w, r, "Failed to upload attachment", err)
+			return
+		}
+
+		response := models.APIResponse{
+			Success: true,
+			Message: "Attachment uploaded successfully",
+			Data:    attachment,
+		}
+
+		writeJSONResponse(w, http.StatusCreated, response)
+		return
+	}
+}
+
+// GetAttachment handles GET /api/transactions/:id/attachments/:attachmentId, streaming the
+// attachment's content
+func (h *TransactionHandler) GetAttachment(w http.ResponseWriter, r *http.Request) {
+	attachment, content, err := h.transactionService.GetAttachment(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "attachmentId"))
+	if err != nil {
+		writeServiceError(w, r, "Attachment not found", err)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(w, content)
+}
+
+// DeleteAttachment handles DELETE /api/transactions/:id/attachments/:attachmentId
+func (h *TransactionHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	if err := h.transactionService.DeleteAttachment(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "attachmentId")); err != nil {
+		writeServiceError(w, r, "Failed to delete attachment", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Attachment deleted successfully",
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// UploadBodyLimit is the largest request body an attachment upload accepts
+func UploadBodyLimit(limits services.AttachmentConfig) int64 {
+	return limits.MaxBytes + multipartOverhead
+}
+
+// uploadError reports a multipart body that ended without a file field or exceeded the
+// size limit as a validation error
+func uploadError(err error, limits services.AttachmentConfig) error {
+	validation := &services.ValidationError{}
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		validation.Addf("file", services.CodeOutOfRange, "file must be at most %d bytes", limits.MaxBytes)
+	case errors.Is(err, io.EOF):
+		validation.Add("file", services.CodeRequired, "a file field is required")
+	default:
+		validation.Add("body", services.CodeInvalidFormat, "request body must be valid multipart/form-data: "+err.Error())
+	}
+	return validation
+}
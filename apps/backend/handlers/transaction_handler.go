@@ -1,9 +1,8 @@
 package handlers
 
 import (
-	"encoding/json"
+	"math"
 	"net/http"
-	"strconv"
 	"time"
 
 	"financial-aggregator-api/backend/models"
@@ -26,11 +25,15 @@ func NewTransactionHandler(transactionService *services.TransactionService) *Tra
 
 // GetTransactions handles GET /api/transactions
 func (h *TransactionHandler) GetTransactions(w http.ResponseWriter, r *http.Request) {
-	filter := h.buildTransactionFilter(r)
+	filter, err := h.buildTransactionFilter(r, v1TransactionTypes...)
+	if err != nil {
+		writeServiceError(w, r, "Invalid query parameters", err)
+		return
+	}
 
 	transactions, err := h.transactionService.GetAllTransactions(filter)
 	if err != nil {
-		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch transactions", err)
+		writeServiceError(w, r, "Failed to fetch transactions", err)
 		return
 	}
 
@@ -52,7 +55,7 @@ func (h *TransactionHandler) GetTransactions(w http.ResponseWriter, r *http.Requ
 
 	response := models.PaginatedResponse{
 		Success: true,
-		Data:    transactions,
+		Data:    toTransactionsV1(transactions),
 		Meta: models.PaginationMeta{
 			Total:  total,
 			Limit:  limit,
@@ -61,128 +64,188 @@ func (h *TransactionHandler) GetTransactions(w http.ResponseWriter, r *http.Requ
 		},
 	}
 
-	h.writeJSONResponse(w, http.StatusOK, response)
+	if notModified(w, r, entityTag(r, response), time.Time{}) {
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
 }
 
 // GetTransactionByID handles GET /api/transactions/:id
 func (h *TransactionHandler) GetTransactionByID(w http.ResponseWriter, r *http.Request) {
 	transactionID := chi.URLParam(r, "id")
 	if transactionID == "" {
-		h.writeErrorResponse(w, http.StatusBadRequest, "Transaction ID is required", nil)
+		writeErrorResponse(w, r, http.StatusBadRequest, "Transaction ID is required", nil)
 		return
 	}
 
 	transaction, err := h.transactionService.GetTransactionByID(transactionID)
 	if err != nil {
-		h.writeErrorResponse(w, http.StatusNotFound, "Transaction not found", err)
+		writeServiceError(w, r, "Transaction not found", err)
+		return
+	}
+
+	if notModified(w, r, entityTag(r, transaction), time.Time{}) {
 		return
 	}
 
 	response := models.APIResponse{
 		Success: true,
 		Message: "Transaction retrieved successfully",
-		Data:    transaction,
+		Data:    toTransactionV1(transaction),
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// UpdateTransaction handles PATCH /api/transactions/:id
+func (h *TransactionHandler) UpdateTransaction(w http.ResponseWriter, r *http.Request) {
+	transaction, ok := h.updateTransaction(w, r)
+	if !ok {
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Transaction updated successfully",
+		Data:    toTransactionV1(transaction),
+	}
+
+	w.Header().Set("ETag", entityTag(r, transaction))
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// updateTransaction applies the request's update, writing the error response and returning
+// false when it fails. The body's version makes the update atomic; If-Match, when sent, is
+// checked as well.
+func (h *TransactionHandler) updateTransaction(w http.ResponseWriter, r *http.Request) (*models.Transaction, bool) {
+	transactionID := chi.URLParam(r, "id")
+
+	var update models.TransactionUpdate
+	if err := decodeJSONBody(r, &update); err != nil {
+		writeServiceError(w, r, "Invalid request body", err)
+		return nil, false
+	}
+
+	if r.Header.Get("If-Match") != "" {
+		if current, err := h.transactionService.GetTransactionByID(transactionID); err == nil {
+			if preconditionFailed(w, r, entityTag(r, current)) {
+				return nil, false
+			}
+		}
 	}
 
-	h.writeJSONResponse(w, http.StatusOK, response)
+	transaction, err := h.transactionService.UpdateTransaction(r.Context(), transactionID, &update)
+	if err != nil {
+		writeServiceError(w, r, "Failed to update transaction", err)
+		return nil, false
+	}
+	return transaction, true
 }
 
 // GetTransactionsByAccount handles GET /api/accounts/:id/transactions
 func (h *TransactionHandler) GetTransactionsByAccount(w http.ResponseWriter, r *http.Request) {
 	accountID := chi.URLParam(r, "id")
 	if accountID == "" {
-		h.writeErrorResponse(w, http.StatusBadRequest, "Account ID is required", nil)
+		writeErrorResponse(w, r, http.StatusBadRequest, "Account ID is required", nil)
 		return
 	}
 
-	// Parse limit from query parameter
-	limit := 50
-	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
-		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
-			limit = parsedLimit
-		}
+	query := newQueryValidator(r)
+	limit := query.Int("limit", 1, maxPageLimit, 50)
+	if err := query.Err(); err != nil {
+		writeServiceError(w, r, "Invalid query parameters", err)
+		return
 	}
 
 	transactions, err := h.transactionService.GetTransactionsByAccountID(accountID, limit)
 	if err != nil {
-		h.writeErrorResponse(w, http.StatusInternalServerError, "Failed to fetch transactions", err)
+		writeServiceError(w, r, "Failed to fetch transactions", err)
 		return
 	}
 
 	response := models.APIResponse{
 		Success: true,
 		Message: "Account transactions retrieved successfully",
-		Data:    transactions,
+		Data:    toTransactionsV1(transactions),
 	}
 
-	h.writeJSONResponse(w, http.StatusOK, response)
+	if notModified(w, r, entityTag(r, response), time.Time{}) {
+		return
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
 }
 
-// buildTransactionFilter builds a TransactionFilter from query parameters
-func (h *TransactionHandler) buildTransactionFilter(r *http.Request) *models.TransactionFilter {
-	filter := &models.TransactionFilter{}
+// buildTransactionFilter builds a TransactionFilter from query parameters, accepting the
+// given transaction types and reporting every invalid parameter
+func (h *TransactionHandler) buildTransactionFilter(r *http.Request, types ...string) (*models.TransactionFilter, error) {
+	query := newQueryValidator(r)
 
-	// Parse query parameters
-	if accountID := r.URL.Query().Get("account_id"); accountID != "" {
-		filter.AccountID = accountID
+	filter := &models.TransactionFilter{
+		AccountID: query.String("account_id"),
+		Type:      query.Enum("type", types...),
+		Category:  query.String("category"),
+		Tag:       query.String("tag"),
+		Status:    query.Enum("status", "pending", "completed", "failed", "cancelled"),
+		Limit:     query.Int("limit", 1, maxPageLimit, 0),
+		Offset:    query.Int("offset", 0, math.MaxInt32, 0),
+		StartDate: query.Date("start_date"),
+		EndDate:   query.Date("end_date"),
 	}
 
-	if transactionType := r.URL.Query().Get("type"); transactionType != "" {
-		filter.Type = transactionType
+	if filter.StartDate != nil && filter.EndDate != nil && filter.EndDate.Before(*filter.StartDate) {
+		query.Fail("end_date", services.CodeOutOfRange, "end_date must not be before start_date")
 	}
 
-	if category := r.URL.Query().Get("category"); category != "" {
-		filter.Category = category
+	if err := query.Err(); err != nil {
+		return nil, err
 	}
 
-	if status := r.URL.Query().Get("status"); status != "" {
-		filter.Status = status
+	// A v1 debit or credit also covers the investment types reported as one
+	if aliases := v1TypeAliases[filter.Type]; len(aliases) > 0 {
+		filter.Types = append([]string{filter.Type}, aliases...)
+		filter.Type = ""
 	}
+	return filter, nil
+}
 
-	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
-		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 {
-			filter.Limit = limit
-		}
-	}
+// transactionTypes are the transaction types v2 reports and filters by
+var transactionTypes = []string{"debit", "credit", "transfer", models.TransactionBuy, models.TransactionSell, models.TransactionDividend, models.TransactionFee}
 
-	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
-		if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
-			filter.Offset = offset
-		}
-	}
+// v1TransactionTypes are the transaction types of v1, which predates investment accounts
+var v1TransactionTypes = []string{"debit", "credit", "transfer"}
 
-	if startDateStr := r.URL.Query().Get("start_date"); startDateStr != "" {
-		if startDate, err := time.Parse("2006-01-02", startDateStr); err == nil {
-			filter.StartDate = &startDate
-		}
-	}
+// v1TypeAliases lists the investment types v1 reports as a debit or a credit: buys and fees
+// take cash out, sells and dividends bring it in
+var v1TypeAliases = map[string][]string{
+	"debit":  {models.TransactionBuy, models.TransactionFee},
+	"credit": {models.TransactionSell, models.TransactionDividend},
+}
 
-	if endDateStr := r.URL.Query().Get("end_date"); endDateStr != "" {
-		if endDate, err := time.Parse("2006-01-02", endDateStr); err == nil {
-			filter.EndDate = &endDate
+// toTransactionV1 returns transaction with an investment type replaced by the v1 type it
+// counts as. The symbol, quantity and price are left for clients that know them.
+func toTransactionV1(transaction *models.Transaction) *models.Transaction {
+	for v1Type, aliases := range v1TypeAliases {
+		for _, alias := range aliases {
+			if transaction.Type == alias {
+				copied := *transaction
+				copied.Type = v1Type
+				return &copied
+			}
 		}
 	}
-
-	return filter
+	return transaction
 }
 
-// writeJSONResponse writes a JSON response to the client
-func (h *TransactionHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(data)
-}
-
-// writeErrorResponse writes an error response to the client
-func (h *TransactionHandler) writeErrorResponse(w http.ResponseWriter, statusCode int, message string, err error) {
-	response := models.APIResponse{
-		Success: false,
-		Message: message,
+// toTransactionsV1 converts a list of transactions for v1
+func toTransactionsV1(transactions []*models.Transaction) []*models.Transaction {
+	if transactions == nil {
+		return nil
 	}
-
-	if err != nil {
-		response.Error = err.Error()
+	result := make([]*models.Transaction, len(transactions))
+	for i, transaction := range transactions {
+		result[i] = toTransactionV1(transaction)
 	}
-
-	h.writeJSONResponse(w, statusCode, response)
+	return result
 }
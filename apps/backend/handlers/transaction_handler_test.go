@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"bytes"
 	"encoding/json"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -119,6 +121,70 @@ func TestTransactionHandler_GetTransactionsWithFilters(t *testing.T) {
 	}
 }
 
+func TestTransactionHandler_V1InvestmentTypes(t *testing.T) {
+	handler := NewTransactionHandler(services.NewTransactionService())
+	r := chi.NewRouter()
+	r.Get("/api/transactions", handler.GetTransactions)
+	r.Get("/api/transactions/{id}", handler.GetTransactionByID)
+	r.Group(func(r chi.Router) {
+		r.Use(UseAPIVersion(APIVersion2))
+		r.Get("/api/v2/transactions/{id}", handler.GetTransactionByIDV2)
+	})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+	types := func(rr *httptest.ResponseRecorder) map[string]string {
+		var response struct {
+			Data []models.Transaction `json:"data"`
+		}
+		if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+			t.Fatal(err)
+		}
+		result := make(map[string]string)
+		for _, transaction := range response.Data {
+			result[transaction.ID] = transaction.Type
+		}
+		return result
+	}
+
+	// v1 keeps its three types: buys and fees are debits, sells and dividends credits
+	all := types(get("/api/transactions?limit=100"))
+	for id, transactionType := range all {
+		if transactionType != "debit" && transactionType != "credit" && transactionType != "transfer" {
+			t.Errorf("Expected a v1 type for %s, got %s", id, transactionType)
+		}
+	}
+	if all["txn_006"] != "credit" || all["txn_012"] != "debit" || all["txn_015"] != "credit" || all["txn_016"] != "debit" {
+		t.Errorf("Unexpected v1 types %v", all)
+	}
+
+	// and filters by them the same way
+	debits := types(get("/api/transactions?type=debit&limit=100"))
+	if debits["txn_012"] != "debit" || debits["txn_016"] != "debit" || debits["txn_006"] != "" {
+		t.Errorf("Expected buys and fees among debits, got %v", debits)
+	}
+	if rr := get("/api/transactions?type=buy"); rr.Code != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusBadRequest)
+	}
+
+	var single struct {
+		Data models.Transaction `json:"data"`
+	}
+	if err := json.Unmarshal(get("/api/transactions/txn_006").Body.Bytes(), &single); err != nil || single.Data.Type != "credit" {
+		t.Errorf("Expected the v1 dividend as a credit, got %+v (%v)", single.Data, err)
+	}
+	var v2 struct {
+		Data models.TransactionV2 `json:"data"`
+	}
+	if err := json.Unmarshal(get("/api/v2/transactions/txn_006").Body.Bytes(), &v2); err != nil || v2.Data.Type != models.TransactionDividend {
+		t.Errorf("Expected the v2 dividend as a dividend, got %+v (%v)", v2.Data, err)
+	}
+}
+
 func TestTransactionHandler_GetTransactionByID(t *testing.T) {
 	// Create mock service
 	transactionService := services.NewTransactionService()
@@ -220,3 +286,206 @@ func TestTransactionHandler_GetTransactionsByAccount(t *testing.T) {
 		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
 	}
 }
+
+func TestTransactionHandler_GetTransactionsInvalidQuery(t *testing.T) {
+	transactionService := services.NewTransactionService()
+	handler := NewTransactionHandler(transactionService)
+
+	r := chi.NewRouter()
+	r.Get("/api/transactions", handler.GetTransactions)
+
+	req, err := http.NewRequest("GET", "/api/transactions?limit=abc&start_date=yesterday&status=lost", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+
+	var response models.APIResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every invalid parameter is reported, not just the first
+	codes := make(map[string]string)
+	for _, fieldError := range response.Errors {
+		codes[fieldError.Field] = fieldError.Code
+	}
+	expected := map[string]string{
+		"limit":      services.CodeInvalidFormat,
+		"start_date": services.CodeInvalidFormat,
+		"status":     services.CodeNotAllowed,
+	}
+	for field, code := range expected {
+		if codes[field] != code {
+			t.Errorf("Expected %s error for %s, got %v", code, field, response.Errors)
+		}
+	}
+
+	// end_date before start_date is rejected
+	req, err = http.NewRequest("GET", "/api/transactions?start_date=2024-02-01&end_date=2024-01-01", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+}
+
+func TestTransactionHandler_GetTransactionsByAccountInvalidLimit(t *testing.T) {
+	transactionService := services.NewTransactionService()
+	handler := NewTransactionHandler(transactionService)
+
+	r := chi.NewRouter()
+	r.Get("/api/accounts/{id}/transactions", handler.GetTransactionsByAccount)
+
+	req, err := http.NewRequest("GET", "/api/accounts/acc_001/transactions?limit=0", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+}
+
+func TestTransactionHandler_UpdateTransaction(t *testing.T) {
+	handler := NewTransactionHandler(services.NewTransactionService())
+	r := chi.NewRouter()
+	r.Patch("/api/transactions/{id}", handler.UpdateTransaction)
+	r.Patch("/api/v2/transactions/{id}", handler.UpdateTransactionV2)
+
+	update := func(path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("PATCH", path, bytes.NewBufferString(body))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := update("/api/transactions/txn_001", `{"version":1,"category":"payroll"}`)
+	if rr.Code != http.StatusOK || rr.Header().Get("ETag") == "" {
+		t.Fatalf("Expected 200 with an ETag, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var updated struct {
+		Data models.Transaction `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &updated); err != nil {
+		t.Fatal(err)
+	}
+	if updated.Data.Category != "payroll" || updated.Data.Version != 2 {
+		t.Errorf("Expected payroll at version 2, got %+v", updated.Data)
+	}
+
+	// v2 reports a stale version as a problem
+	rr = update("/api/v2/transactions/txn_001", `{"version":1,"description":"late"}`)
+	if rr.Code != http.StatusConflict || rr.Header().Get("Content-Type") != "application/problem+json" {
+		t.Fatalf("Expected a 409 problem, got %d %s", rr.Code, rr.Header().Get("Content-Type"))
+	}
+	var problem models.ProblemDetails
+	if err := json.Unmarshal(rr.Body.Bytes(), &problem); err != nil || problem.Code != "version_conflict" {
+		t.Errorf("Expected version_conflict, got %+v (%v)", problem, err)
+	}
+
+	if rr := update("/api/transactions/txn_001", `{"category":"payroll"}`); rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 without a version, got %d", rr.Code)
+	}
+	if rr := update("/api/transactions/missing", `{"version":1}`); rr.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a missing transaction, got %d", rr.Code)
+	}
+}
+
+func TestTransactionHandler_Attachments(t *testing.T) {
+	service := services.NewTransactionService()
+	config := services.DefaultAttachmentConfig()
+	config.MaxBytes = 1024
+	service.SetAttachmentStore(services.NewLocalAttachmentStore(t.TempDir()), config)
+	handler := NewTransactionHandler(service)
+	r := chi.NewRouter()
+	r.Post("/api/transactions/{id}/attachments", handler.UploadAttachment)
+	r.Get("/api/transactions/{id}/attachments/{attachmentId}", handler.GetAttachment)
+	r.Delete("/api/transactions/{id}/attachments/{attachmentId}", handler.DeleteAttachment)
+
+	upload := func(path, field string, content []byte) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		_ = writer.WriteField("note", "ignored")
+		part, _ := writer.CreateFormFile(field, "dinner receipt.pdf")
+		_, _ = part.Write(content)
+		_ = writer.Close()
+
+		req := httptest.NewRequest("POST", path, &body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+	pdf := []byte("%PDF-1.4\n%receipt\n")
+
+	rr := upload("/api/transactions/txn_001/attachments", "file", pdf)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var created struct {
+		Data models.Attachment `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &created); err != nil {
+		t.Fatal(err)
+	}
+	if created.Data.ContentType != "application/pdf" || created.Data.FileName != "dinner receipt.pdf" {
+		t.Errorf("Unexpected attachment %+v", created.Data)
+	}
+
+	req := httptest.NewRequest("GET", "/api/transactions/txn_001/attachments/"+created.Data.ID, nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK || rr.Body.String() != string(pdf) || rr.Header().Get("Content-Type") != "application/pdf" {
+		t.Fatalf("Expected the PDF back, got %d %s", rr.Code, rr.Header().Get("Content-Type"))
+	}
+	if disposition := rr.Header().Get("Content-Disposition"); disposition != `attachment; filename="dinner receipt.pdf"` {
+		t.Errorf("Unexpected Content-Disposition %q", disposition)
+	}
+
+	for name, rr := range map[string]*httptest.ResponseRecorder{
+		"no file field": upload("/api/transactions/txn_001/attachments", "other", pdf),
+		"too large":     upload("/api/transactions/txn_001/attachments", "file", append(pdf, bytes.Repeat([]byte{' '}, 2048)...)),
+	} {
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d: %s", name, rr.Code, rr.Body.String())
+		}
+	}
+	req = httptest.NewRequest("POST", "/api/transactions/txn_001/attachments", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a JSON body, got %d", rr.Code)
+	}
+	if rr := upload("/api/transactions/missing/attachments", "file", pdf); rr.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 for a missing transaction, got %d", rr.Code)
+	}
+
+	req = httptest.NewRequest("DELETE", "/api/transactions/txn_001/attachments/"+created.Data.ID, nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	req = httptest.NewRequest("GET", "/api/transactions/txn_001/attachments/"+created.Data.ID, nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("Expected 404 after delete, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// GetTransactionsV2 handles GET /api/v2/transactions with cursor pagination
+func (h *TransactionHandler) GetTransactionsV2(w http.ResponseWriter, r *http.Request) {
+	filter, err := h.buildTransactionFilter(r, transactionTypes...)
+	if err == nil && r.URL.Query().Has("offset") {
+		validation := &services.ValidationError{}
+		validation.Add("offset", services.CodeNotAllowed, "offset is not supported in v2; use cursor")
+		err = validation
+	}
+	if err != nil {
+		writeErrorResponse(w, r, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	h.writeTransactionPage(w, r, filter)
+}
+
+// GetTransactionByIDV2 handles GET /api/v2/transactions/:id
+func (h *TransactionHandler) GetTransactionByIDV2(w http.ResponseWriter, r *http.Request) {
+	transaction, err := h.transactionService.GetTransactionByID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeServiceError(w, r, "Transaction not found", err)
+		return
+	}
+
+	if notModified(w, r, entityTag(r, transaction), time.Time{}) {
+		return
+	}
+
+	writeV2Response(w, r, http.StatusOK, models.ResponseV2{Data: toTransactionV2(transaction)})
+}
+
+// UpdateTransactionV2 handles PATCH /api/v2/transactions/:id
+func (h *TransactionHandler) UpdateTransactionV2(w http.ResponseWriter, r *http.Request) {
+	transaction, ok := h.updateTransaction(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("ETag", entityTag(r, transaction))
+	writeV2Response(w, r, http.StatusOK, models.ResponseV2{Data: toTransactionV2(transaction)})
+}
+
+// GetTransactionsByAccountV2 handles GET /api/v2/accounts/:id/transactions with cursor pagination
+func (h *TransactionHandler) GetTransactionsByAccountV2(w http.ResponseWriter, r *http.Request) {
+	query := newQueryValidator(r)
+	filter := &models.TransactionFilter{
+		AccountID: chi.URLParam(r, "id"),
+		Limit:     query.Int("limit", 1, maxPageLimit, 0),
+	}
+	if err := query.Err(); err != nil {
+		writeErrorResponse(w, r, http.StatusBadRequest, "Invalid query parameters", err)
+		return
+	}
+
+	h.writeTransactionPage(w, r, filter)
+}
+
+// writeTransactionPage writes the page of transactions after the request's cursor
+func (h *TransactionHandler) writeTransactionPage(w http.ResponseWriter, r *http.Request, filter *models.TransactionFilter) {
+	transactions, nextCursor, err := h.transactionService.GetTransactionsPage(filter, r.URL.Query().Get("cursor"))
+	if err != nil {
+		writeServiceError(w, r, "Failed to fetch transactions", err)
+		return
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	response := models.ResponseV2{
+		Data: toTransactionsV2(transactions),
+		Page: &models.CursorPage{
+			Limit:      limit,
+			HasMore:    nextCursor != "",
+			NextCursor: nextCursor,
+		},
+	}
+	if notModified(w, r, entityTag(r, response), time.Time{}) {
+		return
+	}
+
+	writeV2Response(w, r, http.StatusOK, response)
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// writeV2Response writes a v2 envelope, echoing the vendor media type when the client asked for it
+func writeV2Response(w http.ResponseWriter, r *http.Request, statusCode int, response models.ResponseV2) {
+	contentType := "application/json"
+	if version, requested := requestedAPIVersion(r); requested && version == APIVersion2 {
+		contentType = vendorMediaType(APIVersion2)
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(response)
+}
+
+// newMoney formats amount with two decimal places
+func newMoney(amount float64, currency string) models.Money {
+	rounded := math.Round(amount*100) / 100
+	if rounded == 0 {
+		rounded = 0 // avoid "-0.00"
+	}
+	return models.Money{Amount: strconv.FormatFloat(rounded, 'f', 2, 64), Currency: currency}
+}
+
+// toAccountV2 converts an account to its v2 shape
+func toAccountV2(account *models.Account) models.AccountV2 {
+	v2 := models.AccountV2{
+		ID:          account.ID,
+		Name:        account.Name,
+		Bank:        account.Bank,
+		AccountType: account.AccountType,
+		Balance:     newMoney(account.Balance, account.Currency),
+		LastUpdated: account.LastUpdated,
+		IsActive:    account.IsActive,
+		Version:     account.Version,
+	}
+	if account.CreditLimit > 0 {
+		creditLimit := newMoney(account.CreditLimit, account.Currency)
+		v2.CreditLimit = &creditLimit
+	}
+	if liability := account.Liability; liability != nil {
+		v2.Liability = &models.LiabilityV2{
+			APR:          liability.APR,
+			StatementDay: liability.StatementDay,
+			DueDate:      liability.DueDate,
+			TermMonths:   liability.TermMonths,
+			OriginatedOn: liability.OriginatedOn,
+		}
+		if liability.MinimumPayment > 0 {
+			minimumPayment := newMoney(liability.MinimumPayment, account.Currency)
+			v2.Liability.MinimumPayment = &minimumPayment
+		}
+		if liability.Principal > 0 {
+			principal := newMoney(liability.Principal, account.Currency)
+			v2.Liability.Principal = &principal
+		}
+	}
+	return v2
+}
+
+// toTransactionV2 converts a transaction to its v2 shape
+func toTransactionV2(transaction *models.Transaction) models.TransactionV2 {
+	v2 := models.TransactionV2{
+		ID:          transaction.ID,
+		AccountID:   transaction.AccountID,
+		Amount:      newMoney(transaction.Amount, transaction.Currency),
+		Type:        transaction.Type,
+		Category:    transaction.Category,
+		Description: transaction.Description,
+		Merchant:    transaction.Merchant,
+		Date:        transaction.Date,
+		Status:      transaction.Status,
+		Reference:   transaction.Reference,
+		Symbol:      transaction.Symbol,
+		Quantity:    transaction.Quantity,
+		Tags:        transaction.Tags,
+		Notes:       transaction.Notes,
+		Attachments: transaction.Attachments,
+		Version:     transaction.Version,
+	}
+	if transaction.Price != 0 {
+		price := newMoney(transaction.Price, transaction.Currency)
+		v2.Price = &price
+	}
+	return v2
+}
+
+// toTransactionsV2 converts a list of transactions to their v2 shape
+func toTransactionsV2(transactions []*models.Transaction) []models.TransactionV2 {
+	v2 := make([]models.TransactionV2, 0, len(transactions))
+	for _, transaction := range transactions {
+		v2 = append(v2, toTransactionV2(transaction))
+	}
+	return v2
+}
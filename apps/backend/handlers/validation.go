@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"financial-aggregator-api/backend/services"
+)
+
+// maxPageLimit caps the limit query parameter on list endpoints
+const maxPageLimit = 1000
+
+// queryValidator parses query parameters and collects every invalid one
+type queryValidator struct {
+	query      url.Values
+	validation services.ValidationError
+}
+
+// newQueryValidator creates a queryValidator for the request's query string
+func newQueryValidator(r *http.Request) *queryValidator {
+	return &queryValidator{query: r.URL.Query()}
+}
+
+// Int parses an integer parameter within [min, max], returning fallback when it is absent
+func (v *queryValidator) Int(name string, min, max, fallback int) int {
+	raw := v.query.Get(name)
+	if raw == "" {
+		return fallback
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		v.validation.Addf(name, services.CodeInvalidFormat, "%s must be an integer", name)
+		return fallback
+	}
+	if value < min || value > max {
+		v.validation.Addf(name, services.CodeOutOfRange, "%s must be between %d and %d", name, min, max)
+		return fallback
+	}
+
+	return value
+}
+
+// Float parses a number of at least min, returning fallback when it is absent
+func (v *queryValidator) Float(name string, min, fallback float64) float64 {
+	raw := v.query.Get(name)
+	if raw == "" {
+		return fallback
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		v.validation.Addf(name, services.CodeInvalidFormat, "%s must be a number", name)
+		return fallback
+	}
+	if value < min {
+		v.validation.Addf(name, services.CodeOutOfRange, "%s must be at least %v", name, min)
+		return fallback
+	}
+
+	return value
+}
+
+// Date parses a YYYY-MM-DD parameter, returning nil when it is absent or invalid
+func (v *queryValidator) Date(name string) *time.Time {
+	raw := v.query.Get(name)
+	if raw == "" {
+		return nil
+	}
+
+	date, err := time.Parse("2006-01-02", raw)
+	if err != nil {
+		v.validation.Addf(name, services.CodeInvalidFormat, "%s must be a date in YYYY-MM-DD format", name)
+		return nil
+	}
+
+	return &date
+}
+
+// Enum returns a parameter that must be one of allowed, or "" when it is absent or invalid
+func (v *queryValidator) Enum(name string, allowed ...string) string {
+	raw := v.query.Get(name)
+	if raw == "" {
+		return ""
+	}
+
+	for _, value := range allowed {
+		if raw == value {
+			return raw
+		}
+	}
+
+	v.validation.Addf(name, services.CodeNotAllowed, "%s must be one of %v", name, allowed)
+	return ""
+}
+
+// Bool parses a true/false parameter, returning fallback when it is absent
+func (v *queryValidator) Bool(name string, fallback bool) bool {
+	raw := v.query.Get(name)
+	if raw == "" {
+		return fallback
+	}
+
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		v.validation.Addf(name, services.CodeInvalidFormat, "%s must be true or false", name)
+		return fallback
+	}
+
+	return value
+}
+
+// String returns a free-form parameter
+func (v *queryValidator) String(name string) string {
+	return v.query.Get(name)
+}
+
+// Fail records an invalid parameter that needs a cross-field check
+func (v *queryValidator) Fail(name, code, message string) {
+	v.validation.Add(name, code, message)
+}
+
+// Err returns the collected errors, or nil if every parameter was valid
+func (v *queryValidator) Err() error {
+	return v.validation.Err()
+}
+
+// decodeJSONBody decodes the request body into dst, reporting malformed JSON as a field error
+func decodeJSONBody(r *http.Request, dst interface{}) error {
+	err := json.NewDecoder(r.Body).Decode(dst)
+	if err == nil {
+		return nil
+	}
+
+	validation := &services.ValidationError{}
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.EOF):
+		validation.Add("body", services.CodeRequired, "request body is required")
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		validation.Add(typeErr.Field, services.CodeInvalidFormat, fmt.Sprintf("%s has the wrong type: got %s", typeErr.Field, typeErr.Value))
+	default:
+		validation.Add("body", services.CodeInvalidJSON, "request body must be valid JSON: "+err.Error())
+	}
+
+	return validation
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// API versions served under /api/v1 and /api/v2
+const (
+	APIVersion1 = 1
+	APIVersion2 = 2
+
+	// DefaultAPIVersion is served on unversioned /api paths without a vendor Accept type
+	DefaultAPIVersion = APIVersion1
+)
+
+// vendorMediaTypePrefix and vendorMediaTypeSuffix bracket the version in
+// application/vnd.finagg.v2+json
+const (
+	vendorMediaTypePrefix = "application/vnd.finagg.v"
+	vendorMediaTypeSuffix = "+json"
+)
+
+// v1 is deprecated in favour of v2 and will be removed at sunset
+var (
+	v1DeprecatedAt = time.Date(2026, time.November, 1, 0, 0, 0, 0, time.UTC)
+	v1SunsetAt     = time.Date(2027, time.May, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// apiVersionKey is the context key for the API version of a request
+type apiVersionKey struct{}
+
+// APIVersionFromContext returns the API version selected for a request
+func APIVersionFromContext(ctx context.Context) int {
+	if version, ok := ctx.Value(apiVersionKey{}).(int); ok {
+		return version
+	}
+	return DefaultAPIVersion
+}
+
+// UseAPIVersion pins every request under a versioned prefix to version
+func UseAPIVersion(version int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			serveVersion(w, r, next, version)
+		})
+	}
+}
+
+// NegotiateAPIVersion picks the version from an Accept header such as
+// application/vnd.finagg.v2+json, falling back to DefaultAPIVersion
+func NegotiateAPIVersion(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept")
+
+		version, requested := requestedAPIVersion(r)
+		if !requested {
+			version = DefaultAPIVersion
+		}
+		if version != APIVersion1 && version != APIVersion2 {
+			writeErrorResponse(w, r, http.StatusNotAcceptable, "Unsupported API version",
+				fmt.Errorf("API version %d is not supported; use %s1%s or %s2%s", version,
+					vendorMediaTypePrefix, vendorMediaTypeSuffix, vendorMediaTypePrefix, vendorMediaTypeSuffix))
+			return
+		}
+
+		serveVersion(w, r, next, version)
+	})
+}
+
+// Versioned dispatches to the handler for the request's API version
+func Versioned(v1, v2 http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if APIVersionFromContext(r.Context()) == APIVersion2 {
+			v2(w, r)
+			return
+		}
+		v1(w, r)
+	}
+}
+
+// serveVersion records the version on the request and announces v1 deprecation
+func serveVersion(w http.ResponseWriter, r *http.Request, next http.Handler, version int) {
+	w.Header().Set("API-Version", strconv.Itoa(version))
+	if version == APIVersion1 {
+		w.Header().Set("Deprecation", "@"+strconv.FormatInt(v1DeprecatedAt.Unix(), 10))
+		w.Header().Set("Sunset", v1SunsetAt.Format(http.TimeFormat))
+		w.Header().Add("Link", `</api/v2>; rel="successor-version"`)
+	}
+
+	next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiVersionKey{}, version)))
+}
+
+// requestedAPIVersion returns the version named by a vendor media type in Accept
+func requestedAPIVersion(r *http.Request) (int, bool) {
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || !strings.HasPrefix(mediaType, vendorMediaTypePrefix) || !strings.HasSuffix(mediaType, vendorMediaTypeSuffix) {
+			continue
+		}
+
+		version, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(mediaType, vendorMediaTypePrefix), vendorMediaTypeSuffix))
+		if err != nil {
+			continue
+		}
+		return version, true
+	}
+
+	return 0, false
+}
+
+// isV2Request reports whether a request is served by v2, including before routing
+// has selected the version
+func isV2Request(r *http.Request) bool {
+	if version, ok := r.Context().Value(apiVersionKey{}).(int); ok {
+		return version == APIVersion2
+	}
+	if strings.HasPrefix(r.URL.Path, "/api/v2/") {
+		return true
+	}
+	version, requested := requestedAPIVersion(r)
+	return requested && version == APIVersion2
+}
+
+// vendorMediaType returns the media type for version, e.g. application/vnd.finagg.v2+json
+func vendorMediaType(version int) string {
+	return vendorMediaTypePrefix + strconv.Itoa(version) + vendorMediaTypeSuffix
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// newVersionedRouter mirrors the /api, /api/v1 and /api/v2 layout of the server
+func newVersionedRouter() *chi.Mux {
+	accountHandler := NewAccountHandler(services.NewAccountService())
+	transactionHandler := NewTransactionHandler(services.NewTransactionService())
+
+	routes := func(r chi.Router) {
+		r.Get("/accounts/{id}", Versioned(accountHandler.GetAccountByID, accountHandler.GetAccountByIDV2))
+		r.Get("/transactions", Versioned(transactionHandler.GetTransactions, transactionHandler.GetTransactionsV2))
+	}
+
+	r := chi.NewRouter()
+	r.Route("/api", func(r chi.Router) {
+		r.Route("/v1", func(r chi.Router) {
+			r.Use(UseAPIVersion(APIVersion1))
+			routes(r)
+		})
+		r.Route("/v2", func(r chi.Router) {
+			r.Use(UseAPIVersion(APIVersion2))
+			routes(r)
+		})
+		r.Group(func(r chi.Router) {
+			r.Use(NegotiateAPIVersion)
+			routes(r)
+		})
+	})
+	return r
+}
+
+func TestVersioning_V1IsDeprecated(t *testing.T) {
+	r := newVersionedRouter()
+
+	for _, path := range []string{"/api/v1/accounts/acc_001", "/api/accounts/acc_001"} {
+		req := httptest.NewRequest("GET", path, nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: handler returned wrong status code: got %v want %v", path, rr.Code, http.StatusOK)
+		}
+		if rr.Header().Get("Deprecation") == "" || rr.Header().Get("Sunset") == "" {
+			t.Errorf("%s: expected Deprecation and Sunset headers, got %v", path, rr.Header())
+		}
+
+		// v1 keeps the original envelope
+		var response models.APIResponse
+		if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+			t.Fatal(err)
+		}
+		account, ok := response.Data.(map[string]interface{})
+		if !response.Success || !ok {
+			t.Fatalf("%s: expected the v1 envelope, got %s", path, rr.Body.String())
+		}
+		if _, ok := account["balance"].(float64); !ok {
+			t.Errorf("%s: expected a numeric balance, got %v", path, account["balance"])
+		}
+	}
+}
+
+func TestVersioning_V2ByPathAndAccept(t *testing.T) {
+	r := newVersionedRouter()
+
+	tests := []struct {
+		path        string
+		accept      string
+		contentType string
+	}{
+		{"/api/v2/accounts/acc_001", "", "application/json"},
+		{"/api/accounts/acc_001", "application/vnd.finagg.v2+json", "application/vnd.finagg.v2+json"},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", test.path, nil)
+		if test.accept != "" {
+			req.Header.Set("Accept", test.accept)
+		}
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: handler returned wrong status code: got %v want %v", test.path, rr.Code, http.StatusOK)
+		}
+		if rr.Header().Get("Deprecation") != "" {
+			t.Errorf("%s: v2 must not be marked deprecated", test.path)
+		}
+		if contentType := rr.Header().Get("Content-Type"); contentType != test.contentType {
+			t.Errorf("%s: expected content type %s, got %s", test.path, test.contentType, contentType)
+		}
+
+		var response struct {
+			Data models.AccountV2 `json:"data"`
+		}
+		if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+			t.Fatal(err)
+		}
+		if response.Data.Balance.Amount != "2500.75" || response.Data.Balance.Currency != "USD" {
+			t.Errorf("%s: expected decimal balance 2500.75 USD, got %+v", test.path, response.Data.Balance)
+		}
+	}
+}
+
+func TestVersioning_V2ErrorsAreProblemDetails(t *testing.T) {
+	r := newVersionedRouter()
+
+	req := httptest.NewRequest("GET", "/api/v2/accounts/missing", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusNotFound)
+	}
+	if contentType := rr.Header().Get("Content-Type"); contentType != "application/problem+json" {
+		t.Errorf("Expected problem details, got %s", contentType)
+	}
+}
+
+func TestVersioning_UnsupportedVersion(t *testing.T) {
+	r := newVersionedRouter()
+
+	req := httptest.NewRequest("GET", "/api/accounts/acc_001", nil)
+	req.Header.Set("Accept", "application/vnd.finagg.v9+json")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusNotAcceptable {
+		t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusNotAcceptable)
+	}
+}
+
+func TestVersioning_V2CursorPagination(t *testing.T) {
+	r := newVersionedRouter()
+
+	seen := make(map[string]bool)
+	path := "/api/v2/transactions?limit=4"
+	for pages := 0; path != ""; pages++ {
+		if pages > 10 {
+			t.Fatal("Cursor pagination did not terminate")
+		}
+
+		req := httptest.NewRequest("GET", path, nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+		}
+
+		var response struct {
+			Data []models.TransactionV2 `json:"data"`
+			Page models.CursorPage      `json:"page"`
+		}
+		if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, transaction := range response.Data {
+			if seen[transaction.ID] {
+				t.Errorf("Transaction %s returned twice", transaction.ID)
+			}
+			seen[transaction.ID] = true
+		}
+
+		path = ""
+		if response.Page.HasMore {
+			path = "/api/v2/transactions?limit=4&cursor=" + response.Page.NextCursor
+		}
+	}
+
+	if len(seen) == 0 {
+		t.Error("Expected transactions across pages")
+	}
+}
+
+func TestNewMoney(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		expected string
+	}{
+		{2500.75, "2500.75"},
+		{-45.5, "-45.50"},
+		{0.1 + 0.2, "0.30"},
+		{-0.001, "0.00"},
+	}
+
+	for _, test := range tests {
+		if got := newMoney(test.amount, "USD").Amount; got != test.expected {
+			t.Errorf("newMoney(%v): expected %s, got %s", test.amount, test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"net/http"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// WebhookHandler handles webhook subscription HTTP requests
+type WebhookHandler struct {
+	webhookService *services.WebhookService
+}
+
+// NewWebhookHandler creates a new WebhookHandler instance
+func NewWebhookHandler(webhookService *services.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// GetWebhooks handles GET /api/webhooks
+func (h *WebhookHandler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := h.webhookService.GetAllSubscriptions()
+	if err != nil {
+		writeServiceError(w, r, "Failed to fetch webhooks", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Webhooks retrieved successfully",
+		Data:    subscriptions,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// CreateWebhook handles POST /api/webhooks
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var request models.WebhookSubscriptionRequest
+	if err := decodeJSONBody(r, &request); err != nil {
+		writeServiceError(w, r, "Invalid request body", err)
+		return
+	}
+
+	subscription, err := h.webhookService.CreateSubscription(&request)
+	if err != nil {
+		writeServiceError(w, r, "Invalid webhook subscription", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Webhook created successfully",
+		Data:    subscription,
+	}
+
+	writeJSONResponse(w, http.StatusCreated, response)
+}
+
+// GetWebhookByID handles GET /api/webhooks/:id
+func (h *WebhookHandler) GetWebhookByID(w http.ResponseWriter, r *http.Request) {
+	subscription, err := h.webhookService.GetSubscriptionByID(chi.URLParam(r, "id"))
+	if err != nil {
+		writeServiceError(w, r, "Webhook not found", err)
+		return
+	}
+
+	if notModified(w, r, entityTag(r, subscription), subscription.UpdatedAt) {
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Webhook retrieved successfully",
+		Data:    subscription,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// UpdateWebhook handles PUT /api/webhooks/:id
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	var request models.WebhookSubscriptionRequest
+	if err := decodeJSONBody(r, &request); err != nil {
+		writeServiceError(w, r, "Invalid request body", err)
+		return
+	}
+
+	if h.subscriptionChanged(w, r) {
+		return
+	}
+
+	subscription, err := h.webhookService.UpdateSubscription(chi.URLParam(r, "id"), &request)
+	if err != nil {
+		writeServiceError(w, r, "Failed to update webhook", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Webhook updated successfully",
+		Data:    subscription,
+	}
+
+	w.Header().Set("ETag", entityTag(r, subscription))
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// DeleteWebhook handles DELETE /api/webhooks/:id
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	if h.subscriptionChanged(w, r) {
+		return
+	}
+
+	if err := h.webhookService.DeleteSubscription(chi.URLParam(r, "id")); err != nil {
+		writeServiceError(w, r, "Webhook not found", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Webhook deleted successfully",
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// GetWebhookDeliveries handles GET /api/webhooks/:id/deliveries
+func (h *WebhookHandler) GetWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	deliveries, err := h.webhookService.GetDeliveries(chi.URLParam(r, "id"))
+	if err != nil {
+		writeServiceError(w, r, "Webhook not found", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Webhook deliveries retrieved successfully",
+		Data:    deliveries,
+	}
+
+	writeJSONResponse(w, http.StatusOK, response)
+}
+
+// SendTestEvent handles POST /api/webhooks/:id/test
+func (h *WebhookHandler) SendTestEvent(w http.ResponseWriter, r *http.Request) {
+	delivery, err := h.webhookService.SendTestEvent(chi.URLParam(r, "id"))
+	if err != nil {
+		writeServiceError(w, r, "Webhook not found", err)
+		return
+	}
+
+	response := models.APIResponse{
+		Success: true,
+		Message: "Test event queued for delivery",
+		Data:    delivery,
+	}
+
+	writeJSONResponse(w, http.StatusAccepted, response)
+}
+
+// subscriptionChanged writes a 412 when the request's If-Match does not match the
+// subscription's current ETag. A missing subscription is left for the caller to report.
+func (h *WebhookHandler) subscriptionChanged(w http.ResponseWriter, r *http.Request) bool {
+	if r.Header.Get("If-Match") == "" {
+		return false
+	}
+
+	subscription, err := h.webhookService.GetSubscriptionByID(chi.URLParam(r, "id"))
+	if err != nil {
+		return false
+	}
+	return preconditionFailed(w, r, entityTag(r, subscription))
+}
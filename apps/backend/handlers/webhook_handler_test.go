@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func newWebhookRouter() *chi.Mux {
+	service := services.NewWebhookService(nil)
+	service.SetAllowPrivateHosts(true)
+	handler := NewWebhookHandler(service)
+
+	r := chi.NewRouter()
+	r.Get("/api/webhooks", handler.GetWebhooks)
+	r.Post("/api/webhooks", handler.CreateWebhook)
+	r.Get("/api/webhooks/{id}", handler.GetWebhookByID)
+	r.Put("/api/webhooks/{id}", handler.UpdateWebhook)
+	r.Delete("/api/webhooks/{id}", handler.DeleteWebhook)
+	r.Get("/api/webhooks/{id}/deliveries", handler.GetWebhookDeliveries)
+	r.Post("/api/webhooks/{id}/test", handler.SendTestEvent)
+	return r
+}
+
+func TestWebhookHandler_CRUD(t *testing.T) {
+	r := newWebhookRouter()
+
+	// Create
+	body, _ := json.Marshal(models.WebhookSubscriptionRequest{
+		URL:        "http://127.0.0.1:1/hook",
+		EventTypes: []string{models.EventTransactionCreated},
+	})
+	req, err := http.NewRequest("POST", "/api/webhooks", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusCreated {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusCreated)
+	}
+
+	var created struct {
+		Data models.WebhookSubscription `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &created); err != nil {
+		t.Fatal(err)
+	}
+	if created.Data.ID == "" || created.Data.Secret == "" {
+		t.Fatalf("Expected created webhook with ID and secret, got %+v", created.Data)
+	}
+	id := created.Data.ID
+
+	// Update
+	body, _ = json.Marshal(models.WebhookSubscriptionRequest{
+		URL:        "http://127.0.0.1:1/other",
+		EventTypes: []string{models.EventAccountUpdated},
+	})
+	req, _ = http.NewRequest("PUT", "/api/webhooks/"+id, bytes.NewBuffer(body))
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	// Send a test event and read the delivery log
+	req, _ = http.NewRequest("POST", "/api/webhooks/"+id+"/test", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusAccepted {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusAccepted)
+	}
+
+	req, _ = http.NewRequest("GET", "/api/webhooks/"+id+"/deliveries", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	// Delete, then the webhook is gone
+	req, _ = http.NewRequest("DELETE", "/api/webhooks/"+id, nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	req, _ = http.NewRequest("GET", "/api/webhooks/"+id, nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusNotFound {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusNotFound)
+	}
+}
+
+func TestWebhookHandler_CreateInvalid(t *testing.T) {
+	r := newWebhookRouter()
+
+	body, _ := json.Marshal(models.WebhookSubscriptionRequest{URL: "ftp://example.com"})
+	req, err := http.NewRequest("POST", "/api/webhooks", bytes.NewBuffer(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if status := rr.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusBadRequest)
+	}
+
+	var response models.APIResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+
+	// Both the bad URL and the missing event types are reported
+	fields := make(map[string]string)
+	for _, fieldError := range response.Errors {
+		fields[fieldError.Field] = fieldError.Code
+	}
+	if fields["url"] != services.CodeInvalidFormat || fields["event_types"] != services.CodeRequired {
+		t.Errorf("Expected url and event_types field errors, got %v", response.Errors)
+	}
+
+	// Malformed JSON is reported against the body
+	req, err = http.NewRequest("POST", "/api/webhooks", bytes.NewBufferString(`{"url":`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if rr.Code != http.StatusBadRequest || len(response.Errors) != 1 || response.Errors[0].Code != services.CodeInvalidJSON {
+		t.Errorf("Expected invalid_json field error, got %v %v", rr.Code, response.Errors)
+	}
+}
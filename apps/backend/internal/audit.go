@@ -0,0 +1,24 @@
+package internal
+
+import (
+	"net"
+	"net/http"
+
+	"financial-aggregator-api/backend/services"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// auditActor attributes changes made through the API to the caller's address
+// until the API has authentication
+func auditActor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client := r.RemoteAddr
+		if host, _, err := net.SplitHostPort(client); err == nil {
+			client = host
+		}
+
+		ctx := services.WithAuditActor(r.Context(), "api:"+client, middleware.GetReqID(r.Context()))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
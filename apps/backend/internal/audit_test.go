@@ -0,0 +1,111 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"financial-aggregator-api/backend/models"
+	"financial-aggregator-api/backend/services"
+)
+
+func TestAudit_RefreshIsRecordedWithRequestID(t *testing.T) {
+	router := NewServer().GetRouter()
+
+	req, err := http.NewRequest("POST", "/api/accounts/acc_002/refresh", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Request-Id", "req-audit-1")
+	req.RemoteAddr = "203.0.113.7:51234"
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	// The transaction behind the balance change, if any, is recorded under the same request
+	req, _ = http.NewRequest("GET", "/api/audit?request_id=req-audit-1&entity_type=account", nil)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	var response struct {
+		Data []models.AuditEntry `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Data) != 1 {
+		t.Fatalf("Expected one audit entry for the request, got %v", len(response.Data))
+	}
+	if entry := response.Data[0]; entry.EntityID != "acc_002" || entry.Actor != "api:203.0.113.7" {
+		t.Errorf("Expected refresh of acc_002 by api:203.0.113.7, got %v by %v", entry.EntityID, entry.Actor)
+	}
+
+	req, _ = http.NewRequest("GET", "/api/audit/verify", nil)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	var verification struct {
+		Data models.AuditVerification `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &verification); err != nil {
+		t.Fatal(err)
+	}
+	if !verification.Data.Valid {
+		t.Errorf("Expected audit chain to be valid, got %+v", verification.Data)
+	}
+}
+
+func TestAudit_TamperedLogIsReportedAfterRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	store, err := services.NewFileAuditStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auditLog, err := services.NewAuditLog(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, balance := range []float64{100, 200} {
+		if _, err := auditLog.Record(context.Background(), models.AuditAccountRefreshed, "account", "acc_001", nil, models.Account{ID: "acc_001", Balance: balance}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(strings.Replace(string(data), `"after":200`, `"after":999`, 1)), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// The server keeps the broken chain instead of starting over with an empty log
+	t.Setenv("AUDIT_LOG_PATH", path)
+	router := NewServer().GetRouter()
+
+	req, _ := http.NewRequest("GET", "/api/audit/verify", nil)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	var verification struct {
+		Data models.AuditVerification `json:"data"`
+	}
+	if err := json.Unmarshal(rr.Body.Bytes(), &verification); err != nil {
+		t.Fatal(err)
+	}
+	if verification.Data.Valid || verification.Data.FirstInvalid != 2 {
+		t.Errorf("Expected the tampering at sequence 2 to be reported, got %+v", verification.Data)
+	}
+}
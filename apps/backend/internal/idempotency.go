@@ -0,0 +1,284 @@
+package internal
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sync"
+	"time"
+
+	"financial-aggregator-api/backend/handlers"
+)
+
+// maxIdempotencyKeyLength rejects keys that are clearly not client-generated tokens
+const maxIdempotencyKeyLength = 255
+
+// defaultIdempotencyMaxBodyBytes is the largest body buffered to fingerprint a request
+const defaultIdempotencyMaxBodyBytes = 1 << 20
+
+// defaultIdempotencyMaxEntries is how many keys are kept before the least recently used is
+// dropped
+const defaultIdempotencyMaxEntries = 10000
+
+// idempotencyEntry is a stored response, or a request still in flight
+type idempotencyEntry struct {
+	key         string
+	fingerprint string
+	completed   bool
+	statusCode  int
+	header      http.Header
+	body        []byte
+	expiresAt   time.Time
+}
+
+// IdempotencyStore keeps POST responses by Idempotency-Key for replay. Past maxEntries, the
+// least recently used key is dropped first.
+type IdempotencyStore struct {
+	entries           map[string]*list.Element // of *idempotencyEntry
+	recent            *list.List               // most recently used first
+	maxEntries        int
+	ttl               time.Duration
+	maxBodyBytes      int64
+	maxMultipartBytes int64
+	lastSweep         time.Time
+	now               func() time.Time
+	mutex             sync.Mutex
+}
+
+// NewIdempotencyStore creates a store that keeps responses for ttl and accepts request
+// bodies of up to maxBodyBytes
+func NewIdempotencyStore(ttl time.Duration, maxBodyBytes int64) *IdempotencyStore {
+	return &IdempotencyStore{
+		entries:           make(map[string]*list.Element),
+		recent:            list.New(),
+		maxEntries:        defaultIdempotencyMaxEntries,
+		ttl:               ttl,
+		maxBodyBytes:      maxBodyBytes,
+		maxMultipartBytes: maxBodyBytes,
+		now:               time.Now,
+	}
+}
+
+// SetMultipartLimit caps multipart/form-data bodies, which only attachment uploads accept,
+// at maxBytes instead, so an upload with an Idempotency-Key is held to the attachment limit
+// before it is buffered
+func (s *IdempotencyStore) SetMultipartLimit(maxBytes int64) {
+	s.maxMultipartBytes = maxBytes
+}
+
+// SetMaxEntries bounds the keys kept at once. A key dropped to make room is treated as new
+// when it is sent again.
+func (s *IdempotencyStore) SetMaxEntries(maxEntries int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.maxEntries = maxEntries
+	s.evict()
+}
+
+// Middleware replays the stored response for repeated POST requests with the same
+// Idempotency-Key and request, and rejects reuse of a key with a different request
+func (s *IdempotencyStore) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("Idempotency-Key")
+		if r.Method != http.MethodPost || key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			writeIdempotencyError(w, r, http.StatusBadRequest, "idempotency_key_invalid", "Idempotency-Key is too long", "invalid idempotency key")
+			return
+		}
+
+		// The body is buffered to fingerprint it, so it is capped before anything is read
+		limit := s.bodyLimit(r)
+		if r.ContentLength > limit {
+			writeBodyTooLarge(w, r, limit)
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeBodyTooLarge(w, r, limit)
+			return
+		}
+		if err != nil {
+			writeIdempotencyError(w, r, http.StatusBadRequest, "invalid_request", "Failed to read request body", err.Error())
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		fingerprint := requestFingerprint(r, body)
+		entry, found := s.begin(key, fingerprint)
+		if found {
+			switch {
+			case entry.fingerprint != fingerprint:
+				writeIdempotencyError(w, r, http.StatusUnprocessableEntity, "idempotency_key_reused", "Idempotency-Key was already used with a different request", "idempotency key reused")
+			case !entry.completed:
+				writeIdempotencyError(w, r, http.StatusConflict, "idempotency_key_in_use", "A request with this Idempotency-Key is still being processed", "idempotency key in use")
+			default:
+				replayResponse(w, entry)
+			}
+			return
+		}
+
+		recorder := &recordingResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		completed := false
+		defer func() {
+			// A panicking handler releases the key instead of storing a partial response
+			s.finish(key, recorder, completed)
+		}()
+
+		next.ServeHTTP(recorder, r)
+		completed = true
+	})
+}
+
+// bodyLimit returns the largest body accepted for r
+func (s *IdempotencyStore) bodyLimit(r *http.Request) int64 {
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil && mediaType == "multipart/form-data" {
+		return s.maxMultipartBytes
+	}
+	return s.maxBodyBytes
+}
+
+// begin returns the existing entry for key, or reserves key for a new request
+func (s *IdempotencyStore) begin(key, fingerprint string) (idempotencyEntry, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	if element, exists := s.entries[key]; exists {
+		if entry := element.Value.(*idempotencyEntry); now.Before(entry.expiresAt) {
+			s.recent.MoveToFront(element)
+			return *entry, true
+		}
+		s.remove(element)
+	}
+
+	s.entries[key] = s.recent.PushFront(&idempotencyEntry{
+		key:         key,
+		fingerprint: fingerprint,
+		expiresAt:   now.Add(s.ttl),
+	})
+	s.evict()
+	return idempotencyEntry{}, false
+}
+
+// finish stores the recorded response. Server errors are not stored so the client can retry.
+func (s *IdempotencyStore) finish(key string, recorder *recordingResponseWriter, completed bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	element, exists := s.entries[key]
+	if !exists {
+		return
+	}
+
+	if !completed || recorder.statusCode >= http.StatusInternalServerError {
+		s.remove(element)
+		return
+	}
+
+	entry := element.Value.(*idempotencyEntry)
+	entry.completed = true
+	entry.statusCode = recorder.statusCode
+	entry.header = recorder.Header().Clone()
+	entry.body = recorder.body.Bytes()
+	entry.expiresAt = s.now().Add(s.ttl)
+}
+
+// sweep drops expired entries at most once a minute. The caller must hold the lock.
+func (s *IdempotencyStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < time.Minute {
+		return
+	}
+	s.lastSweep = now
+
+	for element := s.recent.Front(); element != nil; {
+		next := element.Next()
+		if !now.Before(element.Value.(*idempotencyEntry).expiresAt) {
+			s.remove(element)
+		}
+		element = next
+	}
+}
+
+// evict drops the least recently used entries beyond maxEntries. The caller must hold the
+// lock.
+func (s *IdempotencyStore) evict() {
+	for s.maxEntries > 0 && s.recent.Len() > s.maxEntries {
+		s.remove(s.recent.Back())
+	}
+}
+
+// remove drops an entry. The caller must hold the lock.
+func (s *IdempotencyStore) remove(element *list.Element) {
+	s.recent.Remove(element)
+	delete(s.entries, element.Value.(*idempotencyEntry).key)
+}
+
+// recordingResponseWriter passes a response through while keeping a copy
+type recordingResponseWriter struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+// WriteHeader records the status code
+func (rw *recordingResponseWriter) WriteHeader(statusCode int) {
+	if !rw.wroteHeader {
+		rw.statusCode = statusCode
+		rw.wroteHeader = true
+	}
+	rw.ResponseWriter.WriteHeader(statusCode)
+}
+
+// Write records the body
+func (rw *recordingResponseWriter) Write(data []byte) (int, error) {
+	rw.wroteHeader = true
+	rw.body.Write(data)
+	return rw.ResponseWriter.Write(data)
+}
+
+// requestFingerprint identifies a request by method, path, query, body and the headers that
+// change its outcome: If-Match picks the version a refresh applies to, and Accept picks the
+// API version and error format of the response
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.Path + "?" + r.URL.RawQuery + "\n"))
+	hash.Write([]byte("If-Match: " + r.Header.Get("If-Match") + "\n"))
+	hash.Write([]byte("Accept: " + r.Header.Get("Accept") + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// replayResponse writes a stored response verbatim
+func replayResponse(w http.ResponseWriter, entry idempotencyEntry) {
+	for name, values := range entry.header {
+		w.Header()[name] = append([]string(nil), values...)
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(entry.statusCode)
+	_, _ = w.Write(entry.body)
+}
+
+// writeBodyTooLarge rejects a body over the limit
+func writeBodyTooLarge(w http.ResponseWriter, r *http.Request, limit int64) {
+	writeIdempotencyError(w, r, http.StatusRequestEntityTooLarge, "request_too_large", fmt.Sprintf("Request body must be at most %d bytes", limit), "request body too large")
+}
+
+// writeIdempotencyError writes an error response in the format of the requested API version
+func writeIdempotencyError(w http.ResponseWriter, r *http.Request, statusCode int, code, message, errText string) {
+	handlers.WriteError(w, r, statusCode, code, message, errors.New(errText))
+}
@@ -0,0 +1,261 @@
+package internal
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func postWithKey(t *testing.T, handler http.Handler, path, key, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req, err := http.NewRequest("POST", path, bytes.NewBufferString(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestIdempotency_ReplaysRefresh(t *testing.T) {
+	router := NewServer().GetRouter()
+
+	first := postWithKey(t, router, "/api/accounts/acc_001/refresh", "key-1", "")
+	if status := first.Code; status != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	second := postWithKey(t, router, "/api/accounts/acc_001/refresh", "key-1", "")
+	if status := second.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+	if second.Header().Get("Idempotent-Replayed") != "true" {
+		t.Error("Expected replayed response to be marked with Idempotent-Replayed")
+	}
+	if first.Body.String() != second.Body.String() {
+		t.Errorf("Expected replayed body to match the original\nfirst:  %s\nsecond: %s", first.Body, second.Body)
+	}
+
+	// Without a key every request is processed
+	third := postWithKey(t, router, "/api/accounts/acc_001/refresh", "", "")
+	if third.Header().Get("Idempotent-Replayed") != "" {
+		t.Error("Expected request without Idempotency-Key not to be replayed")
+	}
+}
+
+func TestIdempotency_RejectsDifferentBody(t *testing.T) {
+	router := NewServer().GetRouter()
+
+	first := postWithKey(t, router, "/api/accounts/acc_001/refresh", "key-2", `{"account_id":"acc_001"}`)
+	if status := first.Code; status != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
+	}
+
+	second := postWithKey(t, router, "/api/accounts/acc_001/refresh", "key-2", `{"account_id":"acc_002"}`)
+	if status := second.Code; status != http.StatusUnprocessableEntity {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusUnprocessableEntity)
+	}
+
+	// Reusing the key on another route is also a different request
+	third := postWithKey(t, router, "/api/accounts/acc_002/refresh", "key-2", `{"account_id":"acc_001"}`)
+	if status := third.Code; status != http.StatusUnprocessableEntity {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestIdempotency_ExpiresAndSkipsServerErrors(t *testing.T) {
+	now := time.Now()
+	store := NewIdempotencyStore(time.Hour, defaultIdempotencyMaxBodyBytes)
+	store.now = func() time.Time { return now }
+
+	calls := 0
+	statusCode := http.StatusInternalServerError
+	handler := store.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(statusCode)
+	}))
+
+	// Server errors are not stored, so a retry runs the handler again
+	postWithKey(t, handler, "/api/things", "key-3", "{}")
+	statusCode = http.StatusCreated
+	postWithKey(t, handler, "/api/things", "key-3", "{}")
+	postWithKey(t, handler, "/api/things", "key-3", "{}")
+	if calls != 2 {
+		t.Errorf("Expected handler to run twice, got %v", calls)
+	}
+
+	// After the TTL the key can be used again
+	now = now.Add(2 * time.Hour)
+	postWithKey(t, handler, "/api/things", "key-3", `{"changed":true}`)
+	if calls != 3 {
+		t.Errorf("Expected expired key to be processed again, got %v calls", calls)
+	}
+}
+
+func TestIdempotency_RejectsLargeBody(t *testing.T) {
+	store := NewIdempotencyStore(time.Hour, 16)
+
+	calls := 0
+	handler := store.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	rr := postWithKey(t, handler, "/api/things", "key-4", `{"name":"far too long for the limit"}`)
+	if status := rr.Code; status != http.StatusRequestEntityTooLarge {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusRequestEntityTooLarge)
+	}
+
+	// A body of unknown length is cut off while it is read
+	req, err := http.NewRequest("POST", "/api/things", io.NopCloser(strings.NewReader(`{"name":"far too long for the limit"}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Idempotency-Key", "key-5")
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if status := rr.Code; status != http.StatusRequestEntityTooLarge {
+		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusRequestEntityTooLarge)
+	}
+	if calls != 0 {
+		t.Errorf("Expected handler not to run, got %v calls", calls)
+	}
+
+	// Bodies within the limit and requests without a key are not affected
+	if rr := postWithKey(t, handler, "/api/things", "key-6", `{}`); rr.Code != http.StatusCreated {
+		t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusCreated)
+	}
+	if rr := postWithKey(t, handler, "/api/things", "", `{"name":"far too long for the limit"}`); rr.Code != http.StatusCreated {
+		t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusCreated)
+	}
+}
+
+func TestIdempotency_RejectsDifferentHeaders(t *testing.T) {
+	router := NewServer().GetRouter()
+
+	post := func(key string, headers map[string]string) *httptest.ResponseRecorder {
+		req, err := http.NewRequest("POST", "/api/accounts/acc_001/refresh", http.NoBody)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Idempotency-Key", key)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// If-Match picks the version a refresh applies to
+	first := post("key-7", map[string]string{"If-Match": `"1"`})
+	if first.Code == http.StatusUnprocessableEntity {
+		t.Fatalf("Expected first request to be processed, got %v", first.Code)
+	}
+	if rr := post("key-7", map[string]string{"If-Match": `"2"`}); rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusUnprocessableEntity)
+	}
+
+	// Accept picks the API version of unversioned paths
+	if rr := post("key-8", nil); rr.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusOK)
+	}
+	rr := post("key-8", map[string]string{"Accept": "application/vnd.finagg.v2+json"})
+	if rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusUnprocessableEntity)
+	}
+	if rr.Header().Get("Idempotent-Replayed") != "" {
+		t.Error("Expected a v2 request not to replay a v1 response")
+	}
+}
+
+func TestIdempotency_HoldsUploadsToAttachmentLimit(t *testing.T) {
+	t.Setenv("ATTACHMENT_DIR", t.TempDir())
+	t.Setenv("ATTACHMENT_MAX_BYTES", "1024")
+	router := NewServer().GetRouter()
+
+	upload := func(key string, size int) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, err := writer.CreateFormFile("file", "receipt.pdf")
+		if err != nil {
+			t.Fatal(err)
+		}
+		part.Write([]byte("%PDF-1.7\n"))
+		part.Write(bytes.Repeat([]byte{' '}, size))
+		writer.Close()
+
+		req, err := http.NewRequest("POST", "/api/transactions/txn_001/attachments", &body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req.Header.Set("Idempotency-Key", key)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		return rr
+	}
+
+	// Far above the attachment limit but below the general body limit, so only the upload
+	// limit can stop it before it is buffered
+	if rr := upload("key-9", 512<<10); rr.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusRequestEntityTooLarge)
+	}
+
+	if rr := upload("key-10", 16); rr.Code != http.StatusCreated {
+		t.Errorf("handler returned wrong status code: got %v want %v: %s", rr.Code, http.StatusCreated, rr.Body)
+	}
+}
+
+func TestIdempotency_EvictsLeastRecentlyUsed(t *testing.T) {
+	store := NewIdempotencyStore(time.Hour, defaultIdempotencyMaxBodyBytes)
+	store.SetMaxEntries(2)
+
+	calls := 0
+	handler := store.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	// Replaying key-a makes key-b the least recently used, so key-c drops it
+	postWithKey(t, handler, "/api/things", "key-a", "{}")
+	postWithKey(t, handler, "/api/things", "key-b", "{}")
+	postWithKey(t, handler, "/api/things", "key-a", "{}")
+	postWithKey(t, handler, "/api/things", "key-c", "{}")
+	if calls != 3 {
+		t.Fatalf("Expected three keys to be processed, got %v calls", calls)
+	}
+
+	if rr := postWithKey(t, handler, "/api/things", "key-a", "{}"); rr.Header().Get("Idempotent-Replayed") != "true" {
+		t.Error("Expected the recently used key to be replayed")
+	}
+	if rr := postWithKey(t, handler, "/api/things", "key-b", "{}"); rr.Header().Get("Idempotent-Replayed") != "" || calls != 4 {
+		t.Errorf("Expected the evicted key to be processed again, got %v calls", calls)
+	}
+	if len(store.entries) != 2 || store.recent.Len() != 2 {
+		t.Errorf("Expected two entries kept, got %v", len(store.entries))
+	}
+}
+
+func TestIdempotency_RejectsDifferentQuery(t *testing.T) {
+	store := NewIdempotencyStore(time.Hour, defaultIdempotencyMaxBodyBytes)
+	handler := store.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	postWithKey(t, handler, "/api/things?dry_run=true", "key-9", "{}")
+	if rr := postWithKey(t, handler, "/api/things?dry_run=false", "key-9", "{}"); rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("handler returned wrong status code: got %v want %v", rr.Code, http.StatusUnprocessableEntity)
+	}
+}
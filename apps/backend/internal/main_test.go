@@ -0,0 +1,23 @@
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	// Keep the audit log written by NewServer out of the source tree
+	dir, err := os.MkdirTemp("", "audit")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("AUDIT_LOG_PATH", filepath.Join(dir, "audit.jsonl"))
+
+	// Webhook tests subscribe local endpoints
+	os.Setenv("WEBHOOK_ALLOW_PRIVATE_HOSTS", "true")
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
@@ -0,0 +1,18 @@
+package internal
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// openAPISpec is the OpenAPI 3.1 description of every route registered in NewServer
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// serveOpenAPI handles GET /openapi.json
+func serveOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(openAPISpec)
+}
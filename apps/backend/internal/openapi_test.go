@@ -0,0 +1,511 @@
+package internal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"math"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// openAPIDocument is the subset of OpenAPI 3.1 the tests inspect
+type openAPIDocument struct {
+	RawPaths   map[string]json.RawMessage             `json:"paths"`
+	Paths      map[string]map[string]openAPIOperation `json:"-"`
+	Components struct {
+		Schemas   map[string]map[string]interface{} `json:"schemas"`
+		Responses map[string]openAPIResponse        `json:"responses"`
+	} `json:"components"`
+}
+
+type openAPIOperation struct {
+	Responses map[string]openAPIResponse `json:"responses"`
+}
+
+type openAPIResponse struct {
+	Ref     string `json:"$ref"`
+	Content map[string]struct {
+		Schema map[string]interface{} `json:"schema"`
+	} `json:"content"`
+}
+
+func loadOpenAPIDocument(t *testing.T) *openAPIDocument {
+	t.Helper()
+
+	var doc openAPIDocument
+	if err := json.Unmarshal(openAPISpec, &doc); err != nil {
+		t.Fatalf("openapi.json is not valid JSON: %v", err)
+	}
+
+	// Versioned aliases are path items that $ref the unversioned path
+	doc.Paths = make(map[string]map[string]openAPIOperation, len(doc.RawPaths))
+	for path, raw := range doc.RawPaths {
+		var ref struct {
+			Ref string `json:"$ref"`
+		}
+		if err := json.Unmarshal(raw, &ref); err == nil && ref.Ref != "" {
+			target := strings.NewReplacer("~1", "/", "~0", "~").Replace(strings.TrimPrefix(ref.Ref, "#/paths/"))
+			resolved, ok := doc.RawPaths[target]
+			if !ok {
+				t.Fatalf("Path %s has unresolved $ref %s", path, ref.Ref)
+			}
+			raw = resolved
+		}
+
+		var operations map[string]openAPIOperation
+		if err := json.Unmarshal(raw, &operations); err != nil {
+			t.Fatalf("Path %s is not a valid path item: %v", path, err)
+		}
+		doc.Paths[path] = operations
+	}
+	return &doc
+}
+
+// normalizeRoute turns chi patterns such as "/api/accounts/" into OpenAPI paths
+func normalizeRoute(route string) string {
+	if len(route) > 1 {
+		route = strings.TrimSuffix(route, "/")
+	}
+	return route
+}
+
+func TestOpenAPI_DocumentsEveryRoute(t *testing.T) {
+	doc := loadOpenAPIDocument(t)
+	router := NewServer().GetRouter()
+
+	registered := make(map[string]bool)
+	err := chi.Walk(router, func(method, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		key := method + " " + normalizeRoute(route)
+		registered[key] = true
+
+		if _, ok := doc.Paths[normalizeRoute(route)][strings.ToLower(method)]; !ok {
+			t.Errorf("Route %s is not documented in openapi.json", key)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Documented operations must exist too, so the spec cannot drift the other way
+	for path, operations := range doc.Paths {
+		for method := range operations {
+			if method == "parameters" {
+				continue
+			}
+			if key := strings.ToUpper(method) + " " + path; !registered[key] {
+				t.Errorf("Documented operation %s is not registered in NewServer", key)
+			}
+		}
+	}
+}
+
+func TestOpenAPI_DocumentsEveryModel(t *testing.T) {
+	doc := loadOpenAPIDocument(t)
+
+	fset := token.NewFileSet()
+	packages, err := parser.ParseDir(fset, "../models", func(info fs.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, pkg := range packages {
+		for _, file := range pkg.Files {
+			ast.Inspect(file, func(node ast.Node) bool {
+				spec, ok := node.(*ast.TypeSpec)
+				if !ok || !spec.Name.IsExported() {
+					return true
+				}
+				structType, ok := spec.Type.(*ast.StructType)
+				if !ok {
+					return true
+				}
+
+				schema, ok := doc.Components.Schemas[spec.Name.Name]
+				if !ok {
+					t.Errorf("Model %s has no schema in openapi.json", spec.Name.Name)
+					return true
+				}
+
+				properties, _ := schema["properties"].(map[string]interface{})
+				for _, field := range structType.Fields.List {
+					name := jsonFieldName(field)
+					if name == "" {
+						continue
+					}
+					if _, ok := properties[name]; !ok {
+						t.Errorf("Field %s.%s is not documented in openapi.json", spec.Name.Name, name)
+					}
+					delete(properties, name)
+				}
+				for name := range properties {
+					t.Errorf("Schema %s documents %s, which the model does not have", spec.Name.Name, name)
+				}
+				return true
+			})
+		}
+	}
+}
+
+func TestOpenAPI_ResponsesMatchSchema(t *testing.T) {
+	doc := loadOpenAPIDocument(t)
+	router := NewServer().GetRouter()
+
+	webhook := `{"url":"http://127.0.0.1:1/hook","event_types":["transaction.created"]}`
+	alertRule := `{"type":"balance_below","account_type":"checking","threshold":1000000}`
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02")
+
+	requests := []struct {
+		method string
+		path   string
+		body   string
+		accept string
+	}{
+		{"GET", "/health", "", ""},
+		{"GET", "/openapi.json", "", ""},
+		{"POST", "/graphql", `{"query":"{ accounts { id balance transactions(limit: 2) { id account { name } } } }"}`, ""},
+		{"GET", "/graphql?query=%7B%20nope%20%7D", "", ""},
+		{"GET", "/api/accounts", "", ""},
+		{"GET", "/api/accounts/acc_001", "", ""},
+		{"GET", "/api/accounts/missing", "", ""},
+		{"POST", "/api/accounts/acc_003/refresh", "", ""},
+		{"POST", "/api/accounts/missing/refresh", "", ""},
+		{"GET", "/api/accounts/acc_001/transactions?limit=2", "", ""},
+		{"GET", "/api/transactions?account_id=acc_001&limit=3", "", ""},
+		{"GET", "/api/transactions?limit=abc&start_date=yesterday", "", ""},
+		{"GET", "/api/transactions/txn_001", "", ""},
+		{"GET", "/api/transactions/missing", "", ""},
+		{"PATCH", "/api/transactions/txn_002", `{"version":1,"category":"interest"}`, ""},
+		{"PATCH", "/api/transactions/txn_002", `{"version":1,"category":"salary"}`, ""},
+		{"PATCH", "/api/transactions/txn_002", `{"category":""}`, ""},
+		{"PATCH", "/api/transactions/txn_004", `{"version":1,"tags":["Work","reimbursable"],"notes":"Team lunch"}`, ""},
+		{"PATCH", "/api/transactions/txn_004", `{"version":2,"tags":[""]}`, ""},
+		{"GET", "/api/transactions?tag=work", "", ""},
+		{"POST", "/api/transactions/txn_004/attachments", `{"file":"receipt.pdf"}`, ""},
+		{"GET", "/api/transactions/txn_004/attachments/att_999", "", ""},
+		{"GET", "/api/categories", "", ""},
+		{"POST", "/api/categories", `{"name":"Coffee shops","parent_id":"restaurants","icon":"☕"}`, ""},
+		{"POST", "/api/categories", `{"name":"Coffee shops","parent_id":"restaurants"}`, ""},
+		{"POST", "/api/categories", `{"name":"Pets"}`, ""},
+		{"GET", "/api/categories/coffee_shops", "", ""},
+		{"GET", "/api/categories/missing", "", ""},
+		{"PATCH", "/api/categories/coffee_shops", `{"id":"cafes","name":"Cafés"}`, ""},
+		{"DELETE", "/api/categories/food", "", ""},
+		{"POST", "/api/categories/cafes/merge", `{"into":"cafes"}`, ""},
+		{"POST", "/api/categories/cafes/merge", `{"into":"restaurants"}`, ""},
+		{"DELETE", "/api/categories/refunds", "", ""},
+		{"GET", "/api/analytics/categories?include_transfers=true", "", ""},
+		{"GET", "/api/analytics/categories?from=2024-03-10&to=2024-03-01", "", ""},
+		{"DELETE", "/api/transactions/missing/attachments/att_001", "", ""},
+		{"POST", "/api/accounts/acc_002/refresh", `{"version":7}`, ""},
+		{"POST", "/api/webhooks", webhook, ""},
+		{"POST", "/api/webhooks", `{"url":"ftp://example.com"}`, ""},
+		{"GET", "/api/webhooks", "", ""},
+		{"GET", "/api/webhooks/wh_001", "", ""},
+		{"PUT", "/api/webhooks/wh_001", webhook, ""},
+		{"POST", "/api/webhooks/wh_001/test", "", ""},
+		{"GET", "/api/webhooks/wh_001/deliveries", "", ""},
+		{"DELETE", "/api/webhooks/wh_001", "", ""},
+		{"GET", "/api/webhooks/wh_001", "", ""},
+		{"POST", "/api/alerts/rules", alertRule, ""},
+		{"POST", "/api/alerts/rules", `{"type":"unknown"}`, ""},
+		{"GET", "/api/alerts/rules", "", ""},
+		{"GET", "/api/alerts/rules/alr_001", "", ""},
+		{"GET", "/api/alerts", "", ""},
+		{"DELETE", "/api/alerts/rules/alr_001", "", ""},
+		{"GET", "/api/analytics/cashflow", "", ""},
+		{"GET", "/api/analytics/cashflow?group_by=week&include_transfers=true", "", ""},
+		{"GET", "/api/analytics/cashflow?group_by=day", "", ""},
+		{"GET", "/api/accounts/acc_004/holdings", "", ""},
+		{"GET", "/api/accounts/acc_001/holdings", "", ""},
+		{"GET", "/api/accounts/acc_004/performance", "", ""},
+		{"GET", "/api/accounts/acc_004/performance?method=average&from=2024-01-01", "", ""},
+		{"GET", "/api/accounts/acc_001/performance", "", ""},
+		{"GET", "/api/liabilities", "", ""},
+		{"GET", "/api/accounts/acc_007/liability", "", ""},
+		{"PATCH", "/api/accounts/acc_007/liability", `{"version":1,"apr":5.99,"due_date":"2026-11-01T00:00:00Z"}`, ""},
+		{"PATCH", "/api/accounts/acc_003/liability", `{"version":1,"principal":100}`, ""},
+		{"GET", "/api/accounts/acc_007/amortization?extra_payment=50", "", ""},
+		{"GET", "/api/accounts/acc_003/amortization", "", ""},
+		{"GET", "/api/accounts/acc_001/reconciliation", "", ""},
+		{"GET", "/api/accounts/acc_004/reconciliation", "", ""},
+		{"POST", "/api/accounts/acc_002/statements", `{"end_date":"` + yesterday + `","balance":14000}`, ""},
+		{"POST", "/api/accounts/acc_002/statements", `{"end_date":"` + yesterday + `"}`, ""},
+		{"GET", "/api/accounts/acc_002/statements", "", ""},
+		{"GET", "/api/accounts/missing/statements", "", ""},
+		{"POST", "/api/forecast/items", `{"account_id":"acc_001","description":"Rent","amount":-1800,"frequency":"monthly","start_date":"2026-01-01T00:00:00Z"}`, ""},
+		{"POST", "/api/forecast/items", `{"account_id":"acc_001","frequency":"daily"}`, ""},
+		{"GET", "/api/forecast/items", "", ""},
+		{"GET", "/api/forecast?days=60&threshold=1000", "", ""},
+		{"GET", "/api/forecast?days=0", "", ""},
+		{"GET", "/api/forecast?account_id=missing", "", ""},
+		{"DELETE", "/api/forecast/items/sch_001", "", ""},
+		{"DELETE", "/api/forecast/items/sch_001", "", ""},
+		{"POST", "/api/goals", `{"name":"Emergency fund","target_amount":30000,"deadline":"2099-06-30T00:00:00Z","account_ids":["acc_006"]}`, ""},
+		{"POST", "/api/goals", `{"name":"Trip","target_amount":0,"account_ids":["acc_003"]}`, ""},
+		{"GET", "/api/goals", "", ""},
+		{"PUT", "/api/goals/goal_001", `{"name":"Emergency fund","target_amount":20000,"deadline":"2099-06-30T00:00:00Z","account_ids":["acc_006","acc_002"]}`, ""},
+		{"GET", "/api/goals/goal_001/history", "", ""},
+		{"DELETE", "/api/goals/goal_001", "", ""},
+		{"GET", "/api/goals/goal_001", "", ""},
+		{"GET", "/api/v2/accounts/acc_007", "", ""},
+		{"GET", "/api/v2/transactions?type=buy", "", ""},
+		{"GET", "/api/merchants?limit=3", "", ""},
+		{"GET", "/api/merchants?from=2024-03-10&to=2024-03-01", "", ""},
+		{"GET", "/api/audit?limit=5", "", ""},
+		{"GET", "/api/audit?offset=-1", "", ""},
+		{"GET", "/api/audit/verify", "", ""},
+		{"GET", "/api/v1/accounts/acc_001", "", ""},
+		{"GET", "/api/v1/transactions/missing", "", "application/problem+json"},
+		{"GET", "/api/v2/accounts", "", ""},
+		{"GET", "/api/v2/accounts/acc_003", "", ""},
+		{"GET", "/api/v2/accounts/missing", "", ""},
+		{"POST", "/api/v2/accounts/acc_001/refresh", "", ""},
+		{"GET", "/api/v2/accounts/acc_001/transactions?limit=2", "", ""},
+		{"GET", "/api/v2/transactions?limit=2", "", ""},
+		{"GET", "/api/v2/transactions?offset=5", "", ""},
+		{"GET", "/api/v2/transactions/txn_001", "", ""},
+		{"PATCH", "/api/v2/transactions/txn_003", `{"version":1,"description":"Groceries"}`, ""},
+		{"GET", "/api/v2/alerts/rules/missing", "", ""},
+		{"GET", "/api/accounts", "", "application/vnd.finagg.v2+json"},
+	}
+
+	for _, request := range requests {
+		name := request.method + " " + request.path
+		req, err := http.NewRequest(request.method, request.path, bytes.NewBufferString(request.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if request.accept != "" {
+			req.Header.Set("Accept", request.accept)
+		}
+
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		rctx := chi.NewRouteContext()
+		if !router.Match(rctx, request.method, req.URL.Path) {
+			t.Errorf("%s: no route matched", name)
+			continue
+		}
+		operation, ok := doc.Paths[normalizeRoute(rctx.RoutePattern())][strings.ToLower(request.method)]
+		if !ok {
+			t.Errorf("%s: operation is not documented", name)
+			continue
+		}
+
+		response, ok := operation.Responses[strconv.Itoa(rr.Code)]
+		if !ok {
+			t.Errorf("%s: status %d is not documented", name, rr.Code)
+			continue
+		}
+		if response.Ref != "" {
+			response = doc.Components.Responses[strings.TrimPrefix(response.Ref, "#/components/responses/")]
+		}
+
+		mediaType, _, _ := mime.ParseMediaType(rr.Header().Get("Content-Type"))
+		content, ok := response.Content[mediaType]
+		if !ok {
+			t.Errorf("%s: status %d has no %s content", name, rr.Code, mediaType)
+			continue
+		}
+
+		var body interface{}
+		if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+			t.Errorf("%s: response is not JSON: %v", name, err)
+			continue
+		}
+
+		for _, problem := range validateSchema(doc, content.Schema, body, "$") {
+			t.Errorf("%s: %s", name, problem)
+		}
+	}
+}
+
+// jsonFieldName returns the JSON name from a struct field's tag
+func jsonFieldName(field *ast.Field) string {
+	if field.Tag == nil {
+		return ""
+	}
+
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return ""
+	}
+
+	name := strings.Split(reflect.StructTag(tag).Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// validateSchema checks value against the subset of JSON Schema used in openapi.json
+func validateSchema(doc *openAPIDocument, schema map[string]interface{}, value interface{}, path string) []string {
+	if ref, ok := schema["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/components/schemas/")
+		resolved, ok := doc.Components.Schemas[name]
+		if !ok {
+			return []string{fmt.Sprintf("%s: unresolved $ref %s", path, ref)}
+		}
+		return validateSchema(doc, resolved, value, path)
+	}
+
+	var problems []string
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		for _, sub := range allOf {
+			problems = append(problems, validateSchema(doc, sub.(map[string]interface{}), value, path)...)
+		}
+	}
+
+	if types := schemaTypes(schema); len(types) > 0 && !matchesAnyType(types, value) {
+		return append(problems, fmt.Sprintf("%s: expected %v, got %T", path, types, value))
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, allowed := range enum {
+			if reflect.DeepEqual(allowed, value) {
+				found = true
+			}
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("%s: %v is not one of %v", path, value, enum))
+		}
+	}
+
+	if format, _ := schema["format"].(string); format == "date-time" {
+		if text, ok := value.(string); ok {
+			if _, err := time.Parse(time.RFC3339Nano, text); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %q is not a date-time", path, text))
+			}
+		}
+	}
+
+	if minimum, ok := schema["minimum"].(float64); ok {
+		if number, ok := value.(float64); ok && number < minimum {
+			problems = append(problems, fmt.Sprintf("%s: %v is below minimum %v", path, number, minimum))
+		}
+	}
+
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		problems = append(problems, validateObject(doc, schema, typed, path)...)
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range typed {
+				problems = append(problems, validateSchema(doc, items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+
+	return problems
+}
+
+// validateObject checks required, properties and additionalProperties
+func validateObject(doc *openAPIDocument, schema map[string]interface{}, object map[string]interface{}, path string) []string {
+	var problems []string
+
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, name := range required {
+			if _, exists := object[name.(string)]; !exists {
+				problems = append(problems, fmt.Sprintf("%s: missing required property %s", path, name))
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		childPath := path + "." + name
+		if property, ok := properties[name].(map[string]interface{}); ok {
+			problems = append(problems, validateSchema(doc, property, object[name], childPath)...)
+			continue
+		}
+
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				problems = append(problems, fmt.Sprintf("%s: undocumented property", childPath))
+			}
+		case map[string]interface{}:
+			problems = append(problems, validateSchema(doc, additional, object[name], childPath)...)
+		}
+	}
+
+	return problems
+}
+
+// schemaTypes returns the schema's type keyword as a list
+func schemaTypes(schema map[string]interface{}) []string {
+	switch typed := schema["type"].(type) {
+	case string:
+		return []string{typed}
+	case []interface{}:
+		types := make([]string, 0, len(typed))
+		for _, t := range typed {
+			types = append(types, t.(string))
+		}
+		return types
+	}
+	return nil
+}
+
+// matchesAnyType reports whether a decoded JSON value has one of the given types
+func matchesAnyType(types []string, value interface{}) bool {
+	for _, expected := range types {
+		switch expected {
+		case "null":
+			if value == nil {
+				return true
+			}
+		case "object":
+			if _, ok := value.(map[string]interface{}); ok {
+				return true
+			}
+		case "array":
+			if _, ok := value.([]interface{}); ok {
+				return true
+			}
+		case "string":
+			if _, ok := value.(string); ok {
+				return true
+			}
+		case "boolean":
+			if _, ok := value.(bool); ok {
+				return true
+			}
+		case "number":
+			if _, ok := value.(float64); ok {
+				return true
+			}
+		case "integer":
+			if number, ok := value.(float64); ok && number == math.Trunc(number) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -3,26 +3,36 @@ package internal
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
+	"financial-aggregator-api/backend/grpcserver"
 	"financial-aggregator-api/backend/handlers"
 	"financial-aggregator-api/backend/services"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
+	"google.golang.org/grpc"
 )
 
-// Server represents the HTTP server
+// Server represents the HTTP and gRPC servers
 type Server struct {
-	router *chi.Mux
-	server *http.Server
+	router     *chi.Mux
+	server     *http.Server
+	grpcServer *grpc.Server
+	scheduler  *services.SyncScheduler
+	eventBus   *services.EventBus
+	webhooks   *services.WebhookService
+	alerts     *services.AlertService
+	goals      *services.GoalService
+	reconciler *services.ReconciliationService
 }
 
 // NewServer creates a new Server instance
@@ -31,9 +41,77 @@ func NewServer() *Server {
 	accountService := services.NewAccountService()
 	transactionService := services.NewTransactionService()
 
+	// Merchant names come from the built-in rules unless MERCHANT_RULES_PATH names a rules file
+	if merchants := loadMerchantNormalizer(); merchants != nil {
+		transactionService.SetMerchantNormalizer(merchants)
+	}
+
+	// Receipts and other attachments are kept on disk or in an S3 bucket
+	attachmentConfig := loadAttachmentConfig()
+	transactionService.SetAttachmentStore(loadAttachmentStore(), attachmentConfig)
+
+	// Transactions may only be filed under categories of the taxonomy
+	categoryService := services.NewCategoryService(transactionService)
+	transactionService.SetCategoryChecker(categoryService)
+
+	// Investment balances are the holdings valued at the price feed, plus cash
+	investmentService := services.NewInvestmentService(accountService, transactionService, loadPriceFeed())
+	accountService.SetHoldingsValuer(investmentService)
+	accountService.SetTransactionRecorder(transactionService)
+
+	// Every account and transaction change is recorded in the audit log
+	auditLog := newAuditLog()
+	accountService.SetAuditLog(auditLog)
+	transactionService.SetAuditLog(auditLog)
+
+	// Change events feed the SSE stream; the last 256 are kept for resume
+	eventBus := services.NewEventBus(256)
+	accountService.SetEventBus(eventBus)
+	transactionService.SetEventBus(eventBus)
+
+	// Webhooks deliver the same events to external endpoints
+	webhookService := services.NewWebhookService(eventBus)
+	webhookService.SetAllowPrivateHosts(loadWebhookAllowPrivateHosts())
+	if accounts, err := accountService.GetAllAccounts(); err == nil {
+		webhookService.SeedBalances(accounts)
+	}
+
+	// Alerts are evaluated on every account and transaction change
+	alertService := services.NewAlertService(eventBus, accountService)
+
+	// Goals record their progress whenever a linked account changes
+	goalService := services.NewGoalService(eventBus, accountService)
+
+	// Reconciliation records every reported balance, starting from the balances at startup
+	reconciliationService := services.NewReconciliationService(eventBus, accountService, transactionService)
+	if accounts, err := accountService.GetAllAccounts(); err == nil {
+		if err := reconciliationService.SeedBalances(accounts); err != nil {
+			log.Printf("Could not seed reconciliation balances: %v", err)
+		}
+	}
+
+	// Reports leave out transfers by the kind of their category in the taxonomy
+	analyticsService := services.NewAnalyticsService(transactionService)
+	analyticsService.SetCategoryKinds(categoryService)
+
+	// Background sync keeps balances fresh without client refreshes
+	scheduler := services.NewSyncScheduler(accountService, loadSyncConfig())
+
 	// Initialize handlers
 	accountHandler := handlers.NewAccountHandler(accountService)
 	transactionHandler := handlers.NewTransactionHandler(transactionService)
+	eventHandler := handlers.NewEventHandler(eventBus)
+	webhookHandler := handlers.NewWebhookHandler(webhookService)
+	alertHandler := handlers.NewAlertHandler(alertService)
+	goalHandler := handlers.NewGoalHandler(goalService)
+	categoryHandler := handlers.NewCategoryHandler(categoryService)
+	reconciliationHandler := handlers.NewReconciliationHandler(reconciliationService)
+	auditHandler := handlers.NewAuditHandler(auditLog)
+	investmentHandler := handlers.NewInvestmentHandler(investmentService)
+	liabilityHandler := handlers.NewLiabilityHandler(services.NewLiabilityService(accountService))
+	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService)
+	forecastHandler := handlers.NewForecastHandler(services.NewForecastService(accountService, transactionService))
+	graphQLHandler := handlers.NewGraphQLHandler(accountService, transactionService)
 
 	// Create router
 	router := chi.NewRouter()
@@ -43,23 +121,33 @@ func NewServer() *Server {
 	router.Use(middleware.Recoverer)
 	router.Use(middleware.RequestID)
 	router.Use(middleware.RealIP)
-	router.Use(middleware.Timeout(60 * time.Second))
+	router.Use(auditActor)
+
+	// Retried POSTs with the same Idempotency-Key replay the first response. Uploads are held
+	// to the attachment limit rather than the general body limit.
+	idempotency := NewIdempotencyStore(24*time.Hour, loadIdempotencyMaxBodyBytes())
+	idempotency.SetMultipartLimit(handlers.UploadBodyLimit(attachmentConfig))
+	idempotency.SetMaxEntries(loadIdempotencyMaxEntries())
+	router.Use(idempotency.Middleware)
+
+	// Applied per route group so long-lived streams are not cut off
+	timeout := middleware.Timeout(60 * time.Second)
 
 	// CORS configuration
 	// CORS: allow all origins for simplicity on Render/preview
 	// TODO: In production, restrict to your frontend origin(s)
 	corsConfig := cors.New(cors.Options{
 		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "Last-Event-ID", "Idempotency-Key", "If-None-Match", "If-Modified-Since", "If-Match"},
+		ExposedHeaders:   []string{"Link", "Idempotent-Replayed", "API-Version", "Deprecation", "Sunset", "ETag", "Last-Modified"},
 		AllowCredentials: false,
 		MaxAge:           300,
 	})
 	router.Use(corsConfig.Handler)
 
 	// Health check endpoint
-	router.Get("/health", func(w http.ResponseWriter, r *http.Request) {
+	router.With(timeout).Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		log.Printf("[health] %s %s", r.Method, r.URL.String())
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
@@ -69,29 +157,153 @@ func NewServer() *Server {
 		})
 	})
 
-	// API routes
+	// API description
+	router.Get("/openapi.json", serveOpenAPI)
+
+	// GraphQL over accounts and transactions
+	router.Group(func(r chi.Router) {
+		r.Use(timeout)
+		r.Get("/graphql", graphQLHandler.ServeGraphQL)
+		r.Post("/graphql", graphQLHandler.ServeGraphQL)
+	})
+
+	// API routes, registered once per version. Handlers whose response shape
+	// changed in v2 dispatch on the version selected by the enclosing group.
+	apiRoutes := func(r chi.Router) {
+		// Event stream (no request timeout)
+		r.Get("/events", eventHandler.StreamEvents)
+
+		r.Group(func(r chi.Router) {
+			r.Use(timeout)
+
+			// Account routes
+			r.Route("/accounts", func(r chi.Router) {
+				r.Get("/", handlers.Versioned(accountHandler.GetAccounts, accountHandler.GetAccountsV2))
+				r.Get("/{id}", handlers.Versioned(accountHandler.GetAccountByID, accountHandler.GetAccountByIDV2))
+				r.Post("/{id}/refresh", handlers.Versioned(accountHandler.RefreshAccount, accountHandler.RefreshAccountV2))
+				r.Get("/{id}/transactions", handlers.Versioned(transactionHandler.GetTransactionsByAccount, transactionHandler.GetTransactionsByAccountV2))
+				r.Get("/{id}/holdings", investmentHandler.GetHoldings)
+				r.Get("/{id}/performance", investmentHandler.GetPerformance)
+				r.Get("/{id}/liability", liabilityHandler.GetLiability)
+				r.Patch("/{id}/liability", liabilityHandler.UpdateLiability)
+				r.Get("/{id}/amortization", liabilityHandler.GetAmortization)
+				r.Get("/{id}/reconciliation", reconciliationHandler.GetReconciliation)
+				r.Get("/{id}/statements", reconciliationHandler.GetStatements)
+				r.Post("/{id}/statements", reconciliationHandler.ReconcileStatement)
+			})
+
+			// Transaction routes
+			r.Route("/transactions", func(r chi.Router) {
+				r.Get("/", handlers.Versioned(transactionHandler.GetTransactions, transactionHandler.GetTransactionsV2))
+				r.Get("/{id}", handlers.Versioned(transactionHandler.GetTransactionByID, transactionHandler.GetTransactionByIDV2))
+				r.Patch("/{id}", handlers.Versioned(transactionHandler.UpdateTransaction, transactionHandler.UpdateTransactionV2))
+				r.Post("/{id}/attachments", transactionHandler.UploadAttachment)
+				r.Get("/{id}/attachments/{attachmentId}", transactionHandler.GetAttachment)
+				r.Delete("/{id}/attachments/{attachmentId}", transactionHandler.DeleteAttachment)
+			})
+
+			// Webhook routes
+			r.Route("/webhooks", func(r chi.Router) {
+				r.Get("/", webhookHandler.GetWebhooks)
+				r.Post("/", webhookHandler.CreateWebhook)
+				r.Get("/{id}", webhookHandler.GetWebhookByID)
+				r.Put("/{id}", webhookHandler.UpdateWebhook)
+				r.Delete("/{id}", webhookHandler.DeleteWebhook)
+				r.Get("/{id}/deliveries", webhookHandler.GetWebhookDeliveries)
+				r.Post("/{id}/test", webhookHandler.SendTestEvent)
+			})
+
+			// Alert routes
+			r.Route("/alerts", func(r chi.Router) {
+				r.Get("/", alertHandler.GetAlerts)
+				r.Get("/rules", alertHandler.GetAlertRules)
+				r.Post("/rules", alertHandler.CreateAlertRule)
+				r.Get("/rules/{id}", alertHandler.GetAlertRuleByID)
+				r.Delete("/rules/{id}", alertHandler.DeleteAlertRule)
+			})
+
+			// Analytics routes
+			r.Route("/analytics", func(r chi.Router) {
+				r.Get("/cashflow", analyticsHandler.GetCashflow)
+				r.Get("/categories", categoryHandler.GetCategoryReport)
+			})
+
+			// Merchant routes
+			r.Get("/merchants", analyticsHandler.GetMerchants)
+
+			// Liability routes
+			r.Get("/liabilities", liabilityHandler.GetLiabilities)
+
+			// Category routes
+			r.Route("/categories", func(r chi.Router) {
+				r.Get("/", categoryHandler.GetCategories)
+				r.Post("/", categoryHandler.CreateCategory)
+				r.Get("/{id}", categoryHandler.GetCategoryByID)
+				r.Patch("/{id}", categoryHandler.UpdateCategory)
+				r.Delete("/{id}", categoryHandler.DeleteCategory)
+				r.Post("/{id}/merge", categoryHandler.MergeCategory)
+			})
+
+			// Goal routes
+			r.Route("/goals", func(r chi.Router) {
+				r.Get("/", goalHandler.GetGoals)
+				r.Post("/", goalHandler.CreateGoal)
+				r.Get("/{id}", goalHandler.GetGoalByID)
+				r.Put("/{id}", goalHandler.UpdateGoal)
+				r.Delete("/{id}", goalHandler.DeleteGoal)
+				r.Get("/{id}/history", goalHandler.GetGoalHistory)
+			})
+
+			// Forecast routes
+			r.Route("/forecast", func(r chi.Router) {
+				r.Get("/", forecastHandler.GetForecast)
+				r.Get("/items", forecastHandler.GetScheduledItems)
+				r.Post("/items", forecastHandler.CreateScheduledItem)
+				r.Delete("/items/{id}", forecastHandler.DeleteScheduledItem)
+			})
+
+			// Audit routes
+			r.Route("/audit", func(r chi.Router) {
+				r.Get("/", auditHandler.GetAuditEntries)
+				r.Get("/verify", auditHandler.VerifyAuditLog)
+			})
+		})
+	}
+
 	router.Route("/api", func(r chi.Router) {
-		// Account routes
-		r.Route("/accounts", func(r chi.Router) {
-			r.Get("/", accountHandler.GetAccounts)
-			r.Get("/{id}", accountHandler.GetAccountByID)
-			r.Post("/{id}/refresh", accountHandler.RefreshAccount)
-			r.Get("/{id}/transactions", transactionHandler.GetTransactionsByAccount)
+		// /api/v1 keeps the original envelope and is marked deprecated; /api/v2 serves the new shapes
+		r.Route("/v1", func(r chi.Router) {
+			r.Use(handlers.UseAPIVersion(handlers.APIVersion1))
+			apiRoutes(r)
+		})
+		r.Route("/v2", func(r chi.Router) {
+			r.Use(handlers.UseAPIVersion(handlers.APIVersion2))
+			apiRoutes(r)
 		})
 
-		// Transaction routes
-		r.Route("/transactions", func(r chi.Router) {
-			r.Get("/", transactionHandler.GetTransactions)
-			r.Get("/{id}", transactionHandler.GetTransactionByID)
+		// Unversioned paths serve v1 unless Accept asks for application/vnd.finagg.v2+json
+		r.Group(func(r chi.Router) {
+			r.Use(handlers.NegotiateAPIVersion)
+			apiRoutes(r)
 		})
 	})
 
+	// Internal services use the typed gRPC API, served on its own port
+	grpcServer := grpcserver.NewGRPCServer(grpcserver.NewServer(accountService, transactionService, eventBus))
+
 	return &Server{
-		router: router,
+		router:     router,
+		grpcServer: grpcServer,
+		scheduler:  scheduler,
+		eventBus:   eventBus,
+		webhooks:   webhookService,
+		alerts:     alertService,
+		goals:      goalService,
+		reconciler: reconciliationService,
 	}
 }
 
-// Start starts the HTTP server
+// Start starts the HTTP server, and the gRPC server on GRPC_PORT (default 9090)
 func (s *Server) Start(port string) error {
 	if port == "" {
 		port = "8080"
@@ -102,6 +314,25 @@ func (s *Server) Start(port string) error {
 		Handler: s.router,
 	}
 
+	// Claim the gRPC port before serving anything, so a conflict fails fast
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "9090"
+	}
+	listener, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		return err
+	}
+	go func() {
+		log.Printf("gRPC server starting on port %s", grpcPort)
+		if err := s.grpcServer.Serve(listener); err != nil {
+			log.Fatalf("gRPC server failed: %v", err)
+		}
+	}()
+
+	// Shutdown waits for active requests, so end event streams as soon as it begins
+	s.server.RegisterOnShutdown(s.eventBus.Close)
+
 	// Start server in a goroutine
 	go func() {
 		log.Printf("Server starting on port %s", port)
@@ -110,6 +341,14 @@ func (s *Server) Start(port string) error {
 		}
 	}()
 
+	// Start background account sync, webhook delivery, alert evaluation, goal tracking and
+	// balance recording
+	s.scheduler.Start()
+	s.webhooks.Start()
+	s.alerts.Start()
+	s.goals.Start()
+	s.reconciler.Start()
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -121,6 +360,31 @@ func (s *Server) Start(port string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
+	// Stop the scheduler first so no refresh starts while requests drain
+	if err := s.scheduler.Stop(ctx); err != nil {
+		log.Printf("Sync scheduler did not stop cleanly: %v", err)
+	}
+
+	if err := s.alerts.Stop(ctx); err != nil {
+		log.Printf("Alert evaluation did not stop cleanly: %v", err)
+	}
+
+	if err := s.goals.Stop(ctx); err != nil {
+		log.Printf("Goal tracking did not stop cleanly: %v", err)
+	}
+
+	if err := s.reconciler.Stop(ctx); err != nil {
+		log.Printf("Balance recording did not stop cleanly: %v", err)
+	}
+
+	if err := s.webhooks.Stop(ctx); err != nil {
+		log.Printf("Webhook deliveries did not finish: %v", err)
+	}
+
+	// End watch streams first, since a graceful stop waits for every open stream
+	s.eventBus.Close()
+	stopGRPCServer(ctx, s.grpcServer)
+
 	if err := s.server.Shutdown(ctx); err != nil {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
@@ -129,7 +393,220 @@ func (s *Server) Start(port string) error {
 	return nil
 }
 
+// stopGRPCServer lets in-flight RPCs finish, cancelling them if ctx expires first
+func stopGRPCServer(ctx context.Context, grpcServer *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		log.Printf("gRPC server did not stop in time, cancelling remaining RPCs")
+		grpcServer.Stop()
+	}
+}
+
 // GetRouter returns the router for testing purposes
 func (s *Server) GetRouter() *chi.Mux {
 	return s.router
 }
+
+// newAuditLog opens the audit log file named by AUDIT_LOG_PATH (default audit.jsonl). Setting
+// it empty keeps entries in memory only. A file that cannot be used stops startup, and a
+// broken chain is kept as loaded so /api/audit/verify reports it.
+func newAuditLog() *services.AuditLog {
+	path, configured := os.LookupEnv("AUDIT_LOG_PATH")
+	if !configured {
+		path = "audit.jsonl"
+	}
+	if path == "" {
+		auditLog, _ := services.NewAuditLog(nil)
+		return auditLog
+	}
+
+	store, err := services.NewFileAuditStore(path)
+	if err != nil {
+		log.Fatalf("Audit log %s unavailable: %v", path, err)
+	}
+	auditLog, err := services.NewAuditLog(store)
+	if err != nil {
+		log.Fatalf("Audit log %s could not be loaded: %v", path, err)
+	}
+
+	if verification := auditLog.Verify(); !verification.Valid {
+		log.Printf("Audit log %s failed verification at sequence %d: %s", path, verification.FirstInvalid, verification.FailureReason)
+	}
+	return auditLog
+}
+
+// loadMerchantNormalizer reads the merchant rules file named by MERCHANT_RULES_PATH, returning
+// nil to keep the built-in rules when it is unset or cannot be used
+func loadMerchantNormalizer() *services.MerchantNormalizer {
+	path := os.Getenv("MERCHANT_RULES_PATH")
+	if path == "" {
+		return nil
+	}
+
+	merchants, err := services.LoadMerchantNormalizer(path)
+	if err != nil {
+		log.Printf("Merchant rules %s unavailable, using the built-in rules: %v", path, err)
+		return nil
+	}
+	return merchants
+}
+
+// loadPriceFeed reads security prices from the file named by PRICE_FEED_PATH, falling back
+// to the built-in prices when it is unset or cannot be used
+func loadPriceFeed() *services.PriceFeed {
+	path := os.Getenv("PRICE_FEED_PATH")
+	if path == "" {
+		return services.DefaultPriceFeed()
+	}
+
+	feed, err := services.LoadPriceFeed(path)
+	if err != nil {
+		log.Printf("Price feed %s unavailable, using the built-in prices: %v", path, err)
+		return services.DefaultPriceFeed()
+	}
+	return feed
+}
+
+// loadAttachmentStore picks the attachment store named by ATTACHMENT_STORE: "local" (the
+// default) keeps files under ATTACHMENT_DIR, "s3" uses the bucket described by the S3_*
+// variables. It returns nil, disabling uploads, when the chosen store is not configured.
+func loadAttachmentStore() services.AttachmentStore {
+	switch kind := os.Getenv("ATTACHMENT_STORE"); kind {
+	case "", "local":
+		dir := os.Getenv("ATTACHMENT_DIR")
+		if dir == "" {
+			dir = "attachments"
+		}
+		return services.NewLocalAttachmentStore(dir)
+	case "s3":
+		config := services.S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          os.Getenv("S3_REGION"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+		}
+		if config.Region == "" {
+			config.Region = "us-east-1"
+		}
+		if config.Endpoint == "" {
+			config.Endpoint = "https://s3." + config.Region + ".amazonaws.com"
+		}
+		if config.Bucket == "" || config.AccessKeyID == "" || config.SecretAccessKey == "" {
+			log.Printf("Attachment store s3 needs S3_BUCKET, S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY; attachments are disabled")
+			return nil
+		}
+		return services.NewS3AttachmentStore(config)
+	default:
+		log.Printf("Ignoring unknown ATTACHMENT_STORE=%q: expected local or s3; attachments are disabled", kind)
+		return nil
+	}
+}
+
+// loadAttachmentConfig builds the attachment limits from environment variables
+func loadAttachmentConfig() services.AttachmentConfig {
+	config := services.DefaultAttachmentConfig()
+
+	if value := os.Getenv("ATTACHMENT_MAX_BYTES"); value != "" {
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil && parsed > 0 {
+			config.MaxBytes = parsed
+		} else {
+			log.Printf("Ignoring invalid ATTACHMENT_MAX_BYTES=%q: expected a positive number of bytes", value)
+		}
+	}
+
+	return config
+}
+
+// loadIdempotencyMaxBodyBytes reads the largest body accepted with an Idempotency-Key from
+// IDEMPOTENCY_MAX_BODY_BYTES
+func loadIdempotencyMaxBodyBytes() int64 {
+	value := os.Getenv("IDEMPOTENCY_MAX_BODY_BYTES")
+	if value == "" {
+		return defaultIdempotencyMaxBodyBytes
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || parsed <= 0 {
+		log.Printf("Ignoring invalid IDEMPOTENCY_MAX_BODY_BYTES=%q: expected a positive number of bytes", value)
+		return defaultIdempotencyMaxBodyBytes
+	}
+
+	return parsed
+}
+
+// loadIdempotencyMaxEntries reads how many Idempotency-Keys are kept at once from
+// IDEMPOTENCY_MAX_ENTRIES
+func loadIdempotencyMaxEntries() int {
+	value := os.Getenv("IDEMPOTENCY_MAX_ENTRIES")
+	if value == "" {
+		return defaultIdempotencyMaxEntries
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed <= 0 {
+		log.Printf("Ignoring invalid IDEMPOTENCY_MAX_ENTRIES=%q: expected a positive number of keys", value)
+		return defaultIdempotencyMaxEntries
+	}
+
+	return parsed
+}
+
+// loadWebhookAllowPrivateHosts reads WEBHOOK_ALLOW_PRIVATE_HOSTS, which lets webhooks reach
+// loopback, private and link-local addresses
+func loadWebhookAllowPrivateHosts() bool {
+	value := os.Getenv("WEBHOOK_ALLOW_PRIVATE_HOSTS")
+	if value == "" {
+		return false
+	}
+
+	allow, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Ignoring invalid WEBHOOK_ALLOW_PRIVATE_HOSTS=%q: expected true or false", value)
+		return false
+	}
+
+	return allow
+}
+
+// loadSyncConfig builds the sync scheduler configuration from environment variables
+func loadSyncConfig() services.SyncConfig {
+	config := services.DefaultSyncConfig()
+
+	if enabled := os.Getenv("SYNC_ENABLED"); enabled != "" {
+		if parsed, err := strconv.ParseBool(enabled); err == nil {
+			config.Enabled = parsed
+		}
+	}
+
+	config.Interval = durationFromEnv("SYNC_INTERVAL", config.Interval)
+	config.Jitter = durationFromEnv("SYNC_JITTER", config.Jitter)
+	config.MinAge = durationFromEnv("SYNC_MIN_AGE", config.MinAge)
+	config.BaseBackoff = durationFromEnv("SYNC_BASE_BACKOFF", config.BaseBackoff)
+	config.MaxBackoff = durationFromEnv("SYNC_MAX_BACKOFF", config.MaxBackoff)
+
+	return config
+}
+
+// durationFromEnv parses a duration such as "5m" from an environment variable
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed < 0 {
+		log.Printf("Ignoring invalid %s=%q: expected a duration like 5m", key, value)
+		return fallback
+	}
+
+	return parsed
+}
@@ -6,19 +6,23 @@ import (
 
 // Account represents a bank account
 type Account struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Bank        string    `json:"bank"`
-	AccountType string    `json:"account_type"` // checking, savings, credit, investment
-	Balance     float64   `json:"balance"`
-	Currency    string    `json:"currency"`
-	LastUpdated time.Time `json:"last_updated"`
-	IsActive    bool      `json:"is_active"`
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Bank        string     `json:"bank"`
+	AccountType string     `json:"account_type"` // checking, savings, credit, loan, investment
+	Balance     float64    `json:"balance"`
+	CreditLimit float64    `json:"credit_limit,omitempty"` // credit accounts only
+	Liability   *Liability `json:"liability,omitempty"`    // credit and loan accounts only
+	Currency    string     `json:"currency"`
+	LastUpdated time.Time  `json:"last_updated"`
+	IsActive    bool       `json:"is_active"`
+	Version     int64      `json:"version"` // incremented on every change
 }
 
 // AccountRefreshRequest represents a request to refresh account data
 type AccountRefreshRequest struct {
 	AccountID string `json:"account_id"`
+	Version   int64  `json:"version,omitempty"` // expected account version; 0 refreshes any version
 }
 
 // AccountRefreshResponse represents the response after refreshing account data
@@ -28,4 +32,6 @@ type AccountRefreshResponse struct {
 	Message     string    `json:"message"`
 	LastUpdated time.Time `json:"last_updated"`
 	NewBalance  float64   `json:"new_balance,omitempty"`
+	Version     int64     `json:"version,omitempty"` // account version after the refresh
+	Account     *Account  `json:"-"`                 // the account as refreshed, for callers that return it
 }
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+)
+
+// EventAlertTriggered is published when an alert rule fires
+const EventAlertTriggered = "alert.triggered"
+
+// Alert rule types
+const (
+	AlertBalanceBelow           = "balance_below"
+	AlertBalanceAbove           = "balance_above"
+	AlertDebitOver              = "debit_over"
+	AlertTransactionCategory    = "transaction_category"
+	AlertCreditUtilizationAbove = "credit_utilization_above"
+)
+
+// AlertRule represents a user-defined condition on accounts or transactions
+type AlertRule struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Type        string    `json:"type"`                   // balance_below, balance_above, debit_over, transaction_category, credit_utilization_above
+	AccountID   string    `json:"account_id,omitempty"`   // limit the rule to one account
+	AccountType string    `json:"account_type,omitempty"` // or to one account type
+	Category    string    `json:"category,omitempty"`     // transaction_category rules
+	Threshold   float64   `json:"threshold,omitempty"`    // amount, or percentage for utilization
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// AlertRuleRequest represents the body for creating an alert rule
+type AlertRuleRequest struct {
+	Name        string  `json:"name"`
+	Type        string  `json:"type"`
+	AccountID   string  `json:"account_id,omitempty"`
+	AccountType string  `json:"account_type,omitempty"`
+	Category    string  `json:"category,omitempty"`
+	Threshold   float64 `json:"threshold,omitempty"`
+}
+
+// Alert represents a triggered alert rule
+type Alert struct {
+	ID            string    `json:"id"`
+	RuleID        string    `json:"rule_id"`
+	RuleName      string    `json:"rule_name"`
+	Type          string    `json:"type"`
+	AccountID     string    `json:"account_id"`
+	TransactionID string    `json:"transaction_id,omitempty"`
+	Message       string    `json:"message"`
+	Value         float64   `json:"value"`
+	Threshold     float64   `json:"threshold,omitempty"`
+	TriggeredAt   time.Time `json:"triggered_at"`
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"time"
+)
+
+// Cashflow groupings
+const (
+	CashflowByCategory = "category"
+	CashflowByMonth    = "month"
+	CashflowByWeek     = "week"
+	CashflowByAccount  = "account"
+)
+
+// CashflowQuery selects the transactions of a cashflow report and how they are grouped
+type CashflowQuery struct {
+	From             time.Time `json:"from"`              // first day, inclusive
+	To               time.Time `json:"to"`                // last day, inclusive
+	GroupBy          string    `json:"group_by"`          // category, month, week or account
+	IncludeTransfers bool      `json:"include_transfers"` // count internal transfers as income and spending
+}
+
+// CashflowTotals is the income, spending and net of a set of transactions. Spending is
+// positive; net is income minus spending.
+type CashflowTotals struct {
+	Income   float64 `json:"income"`
+	Spending float64 `json:"spending"`
+	Net      float64 `json:"net"`
+	Count    int     `json:"count"`
+}
+
+// CashflowChange compares totals with those of the previous period. Percentages are
+// omitted when the previous value is zero.
+type CashflowChange struct {
+	Income          float64  `json:"income"`
+	Spending        float64  `json:"spending"`
+	Net             float64  `json:"net"`
+	IncomePercent   *float64 `json:"income_percent,omitempty"`
+	SpendingPercent *float64 `json:"spending_percent,omitempty"`
+}
+
+// CashflowBucket holds the totals of one category, account, month or week, and how they
+// changed since the previous period
+type CashflowBucket struct {
+	Key      string         `json:"key"`             // category, account ID, "2024-03" or "2024-W09"
+	Start    string         `json:"start,omitempty"` // first day of a month or week bucket
+	End      string         `json:"end,omitempty"`   // last day of a month or week bucket
+	Totals   CashflowTotals `json:"totals"`
+	Previous CashflowTotals `json:"previous"`
+	Change   CashflowChange `json:"change"`
+}
+
+// CashflowReport is the cashflow of a period, grouped into buckets. The report totals compare
+// with PreviousFrom to PreviousTo; month and week buckets each compare with the bucket before.
+type CashflowReport struct {
+	From             string           `json:"from"`
+	To               string           `json:"to"`
+	PreviousFrom     string           `json:"previous_from"`
+	PreviousTo       string           `json:"previous_to"`
+	GroupBy          string           `json:"group_by"`
+	IncludeTransfers bool             `json:"include_transfers"`
+	Totals           CashflowTotals   `json:"totals"`
+	Previous         CashflowTotals   `json:"previous"`
+	Change           CashflowChange   `json:"change"`
+	Buckets          []CashflowBucket `json:"buckets"`
+}
+
+// MerchantFilter selects the transactions of a merchant spend report
+type MerchantFilter struct {
+	AccountID string     `json:"account_id,omitempty"`
+	From      *time.Time `json:"from,omitempty"` // first day, inclusive
+	To        *time.Time `json:"to,omitempty"`   // last day, inclusive
+	Limit     int        `json:"limit,omitempty"`
+}
+
+// MerchantSpend is the spending and income with one merchant. Spending is positive.
+type MerchantSpend struct {
+	Merchant  string    `json:"merchant"`
+	Spending  float64   `json:"spending"`
+	Income    float64   `json:"income"`
+	Count     int       `json:"count"`
+	Category  string    `json:"category"` // the most frequent category
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+)
+
+// Audit actions
+const (
+	AuditAccountRefreshed   = "account.refreshed"
+	AuditAccountUpdated     = "account.updated"
+	AuditTransactionCreated = "transaction.created"
+	AuditTransactionUpdated = "transaction.updated"
+)
+
+// FieldChange holds the before and after value of a changed field
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// AuditEntry is one record in the append-only, hash-chained audit log
+type AuditEntry struct {
+	Sequence   uint64                 `json:"sequence"`
+	Timestamp  time.Time              `json:"timestamp"`
+	Actor      string                 `json:"actor"`
+	RequestID  string                 `json:"request_id,omitempty"`
+	Action     string                 `json:"action"`
+	EntityType string                 `json:"entity_type"` // account, transaction
+	EntityID   string                 `json:"entity_id"`
+	Changes    map[string]FieldChange `json:"changes"`
+	PrevHash   string                 `json:"prev_hash"`
+	Hash       string                 `json:"hash"`
+}
+
+// AuditFilter represents filters for querying the audit log
+type AuditFilter struct {
+	EntityType string `json:"entity_type,omitempty"`
+	EntityID   string `json:"entity_id,omitempty"`
+	Action     string `json:"action,omitempty"`
+	Actor      string `json:"actor,omitempty"`
+	RequestID  string `json:"request_id,omitempty"`
+	Limit      int    `json:"limit,omitempty"`
+	Offset     int    `json:"offset,omitempty"`
+}
+
+// AuditVerification reports whether the audit hash chain is intact
+type AuditVerification struct {
+	Valid         bool   `json:"valid"`
+	Entries       int    `json:"entries"`
+	FirstInvalid  uint64 `json:"first_invalid,omitempty"`
+	LastHash      string `json:"last_hash,omitempty"`
+	FailureReason string `json:"failure_reason,omitempty"`
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"time"
+)
+
+// Category kinds
+const (
+	CategoryIncome   = "income"
+	CategoryExpense  = "expense"
+	CategoryTransfer = "transfer" // money moving between the user's own accounts, including investments
+)
+
+// Category is a node of the category tree. Transactions refer to categories by ID.
+type Category struct {
+	ID       string `json:"id"` // slug such as "groceries"
+	Name     string `json:"name"`
+	ParentID string `json:"parent_id,omitempty"` // empty for a top-level category
+	Kind     string `json:"kind"`                // income, expense or transfer, shared by the whole tree
+	Icon     string `json:"icon,omitempty"`
+	Custom   bool   `json:"custom"` // created by the user rather than the default taxonomy
+}
+
+// CategoryRequest represents the body for creating a category
+type CategoryRequest struct {
+	ID       string `json:"id,omitempty"` // defaults to a slug of the name
+	Name     string `json:"name"`
+	ParentID string `json:"parent_id,omitempty"`
+	Kind     string `json:"kind,omitempty"` // required at the top level; children take their parent's
+	Icon     string `json:"icon,omitempty"`
+}
+
+// CategoryUpdate represents the body for changing a category; nil fields are left unchanged
+type CategoryUpdate struct {
+	ID       *string `json:"id,omitempty"` // renames the category, moving its transactions and children
+	Name     *string `json:"name,omitempty"`
+	ParentID *string `json:"parent_id,omitempty"` // "" moves it to the top level
+	Kind     *string `json:"kind,omitempty"`      // top-level categories only
+	Icon     *string `json:"icon,omitempty"`
+}
+
+// CategoryMerge represents the body for merging a category into another
+type CategoryMerge struct {
+	Into string `json:"into"`
+}
+
+// CategoryMergeResult is the category that remains after a merge
+type CategoryMergeResult struct {
+	Category            Category `json:"category"`
+	TransactionsUpdated int      `json:"transactions_updated"`
+	ChildrenMoved       int      `json:"children_moved"`
+}
+
+// CategoryReportQuery selects the transactions of a category report
+type CategoryReportQuery struct {
+	From             time.Time `json:"from"` // first day, inclusive
+	To               time.Time `json:"to"`   // last day, inclusive
+	AccountID        string    `json:"account_id,omitempty"`
+	IncludeTransfers bool      `json:"include_transfers"` // count transfer categories too
+}
+
+// CategoryReport is income and spending per category over a period, with every child rolled
+// up into its parent
+type CategoryReport struct {
+	From             string          `json:"from"`
+	To               string          `json:"to"`
+	AccountID        string          `json:"account_id,omitempty"`
+	IncludeTransfers bool            `json:"include_transfers"`
+	Totals           CashflowTotals  `json:"totals"`
+	Categories       []CategoryTotal `json:"categories"` // top-level categories with activity
+}
+
+// CategoryTotal is the activity of a category in a report
+type CategoryTotal struct {
+	ID       string          `json:"id"`
+	Name     string          `json:"name"`
+	Kind     string          `json:"kind"`
+	Icon     string          `json:"icon,omitempty"`
+	Own      CashflowTotals  `json:"own"`    // transactions in this category itself
+	Totals   CashflowTotals  `json:"totals"` // including every descendant
+	Children []CategoryTotal `json:"children,omitempty"`
+}
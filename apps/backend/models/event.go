@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+)
+
+// Event types published when account or transaction state changes
+const (
+	EventAccountUpdated     = "account.updated"
+	EventTransactionCreated = "transaction.created"
+	EventTransactionUpdated = "transaction.updated"
+	EventRefreshCompleted   = "refresh.completed"
+	EventRefreshFailed      = "refresh.failed"
+)
+
+// Event represents a change notification delivered to subscribers
+type Event struct {
+	ID        uint64      `json:"id"`
+	Type      string      `json:"type"`
+	Data      interface{} `json:"data"`
+	Timestamp time.Time   `json:"timestamp"`
+}
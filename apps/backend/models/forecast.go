@@ -0,0 +1,110 @@
+package models
+
+import (
+	"time"
+)
+
+// How often a recurring or scheduled item repeats
+const (
+	FrequencyOnce      = "once" // scheduled items only
+	FrequencyWeekly    = "weekly"
+	FrequencyBiweekly  = "biweekly"
+	FrequencyMonthly   = "monthly"
+	FrequencyQuarterly = "quarterly"
+	FrequencyYearly    = "yearly"
+)
+
+// Where a forecast entry comes from
+const (
+	ForecastRecurring = "recurring" // inferred from transaction history
+	ForecastScheduled = "scheduled" // entered by the user
+)
+
+// Forecast day flags, raised for checking and savings accounts only
+const (
+	ForecastBelowZero      = "below_zero"
+	ForecastBelowThreshold = "below_threshold"
+)
+
+// ScheduledItem is a future income or bill entered by the user. Income is positive.
+type ScheduledItem struct {
+	ID          string     `json:"id"`
+	AccountID   string     `json:"account_id"`
+	Description string     `json:"description"`
+	Amount      float64    `json:"amount"`
+	Frequency   string     `json:"frequency"`          // once, weekly, biweekly, monthly, quarterly or yearly
+	StartDate   time.Time  `json:"start_date"`         // first occurrence
+	EndDate     *time.Time `json:"end_date,omitempty"` // last possible occurrence
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// ScheduledItemRequest represents the body for creating a scheduled item
+type ScheduledItemRequest struct {
+	AccountID   string     `json:"account_id"`
+	Description string     `json:"description"`
+	Amount      float64    `json:"amount"`
+	Frequency   string     `json:"frequency"`
+	StartDate   time.Time  `json:"start_date"`
+	EndDate     *time.Time `json:"end_date,omitempty"`
+}
+
+// RecurringItem is an income or bill that repeats in an account's history. Amount is the
+// median of its occurrences.
+type RecurringItem struct {
+	AccountID   string  `json:"account_id"`
+	Description string  `json:"description"` // the merchant, or the description without one
+	Category    string  `json:"category"`
+	Amount      float64 `json:"amount"`
+	Frequency   string  `json:"frequency"` // weekly, biweekly, monthly, quarterly or yearly
+	Occurrences int     `json:"occurrences"`
+	LastDate    string  `json:"last_date"`
+	NextDate    string  `json:"next_date"`
+}
+
+// ForecastQuery selects the accounts and horizon of a forecast
+type ForecastQuery struct {
+	Days      int     `json:"days"`                 // days after today
+	Threshold float64 `json:"threshold,omitempty"`  // flag balances below this as well as below zero
+	AccountID string  `json:"account_id,omitempty"` // one account instead of all
+}
+
+// ForecastEntry is a projected income or bill on a day
+type ForecastEntry struct {
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`
+	Source      string  `json:"source"`            // recurring or scheduled
+	ItemID      string  `json:"item_id,omitempty"` // scheduled items only
+}
+
+// ForecastDay is an account's projected balance at the end of a day
+type ForecastDay struct {
+	Date    string          `json:"date"`
+	Balance float64         `json:"balance"`
+	Entries []ForecastEntry `json:"entries,omitempty"`
+	Flags   []string        `json:"flags,omitempty"` // below_zero, below_threshold
+}
+
+// AccountForecast projects one account's balance from its current balance
+type AccountForecast struct {
+	AccountID           string        `json:"account_id"`
+	Name                string        `json:"name"`
+	AccountType         string        `json:"account_type"`
+	Currency            string        `json:"currency"`
+	StartBalance        float64       `json:"start_balance"`
+	EndBalance          float64       `json:"end_balance"`
+	LowestBalance       float64       `json:"lowest_balance"`
+	LowestDate          string        `json:"lowest_date"`
+	FirstBelowZero      string        `json:"first_below_zero,omitempty"`
+	FirstBelowThreshold string        `json:"first_below_threshold,omitempty"`
+	Days                []ForecastDay `json:"days"`
+}
+
+// Forecast projects daily balances from the day after From through To
+type Forecast struct {
+	From      string            `json:"from"` // today
+	To        string            `json:"to"`
+	Days      int               `json:"days"`
+	Threshold float64           `json:"threshold,omitempty"`
+	Accounts  []AccountForecast `json:"accounts"`  // by account ID
+	Recurring []RecurringItem   `json:"recurring"` // the inferred items used
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+)
+
+// Goal statuses
+const (
+	GoalAchieved = "achieved" // the linked balance has reached the target
+	GoalOnTrack  = "on_track" // at or above a straight line from the start amount to the target
+	GoalBehind   = "behind"
+	GoalMissed   = "missed" // the deadline passed before the target was reached
+)
+
+// Goal is a savings target funded by the balances of one or more linked accounts
+type Goal struct {
+	ID           string       `json:"id"`
+	Name         string       `json:"name"`
+	TargetAmount float64      `json:"target_amount"`
+	Deadline     time.Time    `json:"deadline"`
+	AccountIDs   []string     `json:"account_ids"`
+	StartAmount  float64      `json:"start_amount"` // linked balance when the goal was created
+	Progress     GoalProgress `json:"progress"`     // derived from current balances on every read
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+// GoalRequest represents the body for creating or updating a goal
+type GoalRequest struct {
+	Name         string    `json:"name"`
+	TargetAmount float64   `json:"target_amount"`
+	Deadline     time.Time `json:"deadline"`
+	AccountIDs   []string  `json:"account_ids"`
+}
+
+// GoalProgress measures a goal against its linked balances today
+type GoalProgress struct {
+	CurrentAmount   float64 `json:"current_amount"`   // sum of the linked balances
+	Remaining       float64 `json:"remaining"`        // zero once achieved
+	PercentComplete float64 `json:"percent_complete"` // at most 100
+	ExpectedAmount  float64 `json:"expected_amount"`  // where a straight line from the start amount would be today
+	MonthsLeft      float64 `json:"months_left"`
+	// RequiredMonthlyContribution is the remaining amount spread over the months left, or all
+	// of it with less than a month to go. Zero once achieved or missed.
+	RequiredMonthlyContribution float64 `json:"required_monthly_contribution"`
+	Status                      string  `json:"status"` // achieved, on_track, behind or missed
+}
+
+// GoalSnapshot is a goal's linked balance at the end of a day
+type GoalSnapshot struct {
+	Date            string  `json:"date"`
+	Amount          float64 `json:"amount"`
+	PercentComplete float64 `json:"percent_complete"`
+}
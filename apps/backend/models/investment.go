@@ -0,0 +1,107 @@
+package models
+
+import (
+	"time"
+)
+
+// Investment transaction types, alongside debit, credit and transfer
+const (
+	TransactionBuy      = "buy"
+	TransactionSell     = "sell"
+	TransactionDividend = "dividend"
+	TransactionFee      = "fee"
+)
+
+// Cost basis methods for matching sells against lots
+const (
+	CostBasisFIFO    = "fifo"    // the oldest lots are sold first
+	CostBasisAverage = "average" // every lot is sold in proportion, at the average cost
+)
+
+// Price is the price of a security in the price feed as of a time
+type Price struct {
+	Symbol   string    `json:"symbol"`
+	Price    float64   `json:"price"`
+	Currency string    `json:"currency"`
+	AsOf     time.Time `json:"as_of"`
+}
+
+// Lot is the part of a purchase that is still held
+type Lot struct {
+	TransactionID string    `json:"transaction_id"`
+	Acquired      time.Time `json:"acquired"`
+	Quantity      float64   `json:"quantity"`
+	UnitCost      float64   `json:"unit_cost"`  // purchase price plus commission, per unit
+	CostBasis     float64   `json:"cost_basis"` // quantity times unit cost
+}
+
+// Holding is the position in one security. Without a feed price the last trade price is
+// used and Priced is false.
+type Holding struct {
+	Symbol         string     `json:"symbol"`
+	Quantity       float64    `json:"quantity"`
+	CostBasis      float64    `json:"cost_basis"`
+	AverageCost    float64    `json:"average_cost"`
+	Price          float64    `json:"price"`
+	PriceAsOf      *time.Time `json:"price_as_of,omitempty"`
+	Priced         bool       `json:"priced"`
+	MarketValue    float64    `json:"market_value"`
+	UnrealizedGain float64    `json:"unrealized_gain"`
+	Lots           []Lot      `json:"lots"` // oldest first
+}
+
+// Holdings is the portfolio of an investment account. TotalValue, the market value of the
+// holdings plus cash, is the account balance.
+type Holdings struct {
+	AccountID      string        `json:"account_id"`
+	Currency       string        `json:"currency"`
+	Cash           float64       `json:"cash"`
+	MarketValue    float64       `json:"market_value"`
+	CostBasis      float64       `json:"cost_basis"`
+	UnrealizedGain float64       `json:"unrealized_gain"`
+	TotalValue     float64       `json:"total_value"`
+	Holdings       []Holding     `json:"holdings"`     // by symbol
+	Transactions   []Transaction `json:"transactions"` // buys, sells, dividends and fees, newest first
+}
+
+// PerformanceQuery selects the period and cost basis method of a performance report
+type PerformanceQuery struct {
+	From   time.Time `json:"from"`   // first day, inclusive
+	To     time.Time `json:"to"`     // last day, inclusive
+	Method string    `json:"method"` // fifo or average
+}
+
+// SecurityPerformance is the result of one security over a period. Realized gains and
+// dividends are those of the period; quantity, cost basis and market value are at its end.
+type SecurityPerformance struct {
+	Symbol         string  `json:"symbol"`
+	Quantity       float64 `json:"quantity"`
+	CostBasis      float64 `json:"cost_basis"`
+	MarketValue    float64 `json:"market_value"`
+	RealizedGain   float64 `json:"realized_gain"`
+	UnrealizedGain float64 `json:"unrealized_gain"`
+	Dividends      float64 `json:"dividends"`
+}
+
+// Performance is the return of an investment account over a period. Returns are percentages.
+// The time-weighted return removes the effect of deposits and withdrawals; the money-weighted
+// return (XIRR) is the annual rate that discounts them to the end value, and is omitted when
+// there is none. The annualized return is only given for periods of a year or more.
+type Performance struct {
+	AccountID           string                `json:"account_id"`
+	Currency            string                `json:"currency"`
+	From                string                `json:"from"`
+	To                  string                `json:"to"`
+	Method              string                `json:"method"`
+	StartValue          float64               `json:"start_value"`
+	EndValue            float64               `json:"end_value"`
+	NetContributions    float64               `json:"net_contributions"` // deposits minus withdrawals
+	TimeWeightedReturn  float64               `json:"time_weighted_return"`
+	AnnualizedReturn    *float64              `json:"annualized_return,omitempty"`
+	MoneyWeightedReturn *float64              `json:"money_weighted_return,omitempty"`
+	RealizedGain        float64               `json:"realized_gain"`
+	UnrealizedGain      float64               `json:"unrealized_gain"`
+	DividendIncome      float64               `json:"dividend_income"`
+	Fees                float64               `json:"fees"`       // positive
+	Securities          []SecurityPerformance `json:"securities"` // by symbol
+}
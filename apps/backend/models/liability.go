@@ -0,0 +1,98 @@
+package models
+
+import (
+	"time"
+)
+
+// Liability holds the terms of a credit card or loan. Amounts are positive.
+type Liability struct {
+	APR            float64    `json:"apr"`                       // annual percentage rate, e.g. 24.99
+	StatementDay   int        `json:"statement_day,omitempty"`   // day of the month statements close
+	DueDate        *time.Time `json:"due_date,omitempty"`        // next payment due
+	MinimumPayment float64    `json:"minimum_payment,omitempty"` // the monthly payment, for loans
+	Principal      float64    `json:"principal,omitempty"`       // amount borrowed, loans only
+	TermMonths     int        `json:"term_months,omitempty"`     // loans only
+	OriginatedOn   *time.Time `json:"originated_on,omitempty"`   // loans only
+}
+
+// LiabilityUpdate represents the body for updating the terms of a credit or loan account.
+// Version must be the account version the client last read; nil fields are left unchanged.
+type LiabilityUpdate struct {
+	Version        int64      `json:"version"`
+	CreditLimit    *float64   `json:"credit_limit,omitempty"`
+	APR            *float64   `json:"apr,omitempty"`
+	StatementDay   *int       `json:"statement_day,omitempty"`
+	DueDate        *time.Time `json:"due_date,omitempty"`
+	MinimumPayment *float64   `json:"minimum_payment,omitempty"`
+	Principal      *float64   `json:"principal,omitempty"`
+	TermMonths     *int       `json:"term_months,omitempty"`
+	OriginatedOn   *time.Time `json:"originated_on,omitempty"`
+}
+
+// LiabilityDetails is a credit or loan account with its terms and what follows from them.
+// Owed is positive. Utilization is in percent, for accounts with a credit limit; the payoff
+// fields are for loans that are paid down at their monthly payment.
+type LiabilityDetails struct {
+	AccountID         string     `json:"account_id"`
+	Name              string     `json:"name"`
+	AccountType       string     `json:"account_type"`
+	Currency          string     `json:"currency"`
+	Owed              float64    `json:"owed"`
+	CreditLimit       float64    `json:"credit_limit,omitempty"`
+	AvailableCredit   *float64   `json:"available_credit,omitempty"`
+	Utilization       *float64   `json:"utilization,omitempty"`
+	APR               float64    `json:"apr"`
+	MonthlyInterest   float64    `json:"monthly_interest"` // interest on what is owed for one month
+	StatementDay      int        `json:"statement_day,omitempty"`
+	NextStatementDate string     `json:"next_statement_date,omitempty"`
+	DueDate           *time.Time `json:"due_date,omitempty"`
+	MinimumPayment    float64    `json:"minimum_payment,omitempty"`
+	Principal         float64    `json:"principal,omitempty"`
+	TermMonths        int        `json:"term_months,omitempty"`
+	OriginatedOn      *time.Time `json:"originated_on,omitempty"`
+	PaymentsLeft      int        `json:"payments_left,omitempty"`
+	PayoffDate        string     `json:"payoff_date,omitempty"`
+	RemainingInterest *float64   `json:"remaining_interest,omitempty"`
+	Version           int64      `json:"version"` // account version, for updates
+}
+
+// LiabilityReport lists every credit and loan account. Utilization is over all accounts
+// with a credit limit.
+type LiabilityReport struct {
+	TotalOwed        float64            `json:"total_owed"`
+	TotalCreditLimit float64            `json:"total_credit_limit"`
+	Utilization      *float64           `json:"utilization,omitempty"`
+	Liabilities      []LiabilityDetails `json:"liabilities"` // by account ID
+}
+
+// AmortizationQuery adjusts a loan's amortization schedule
+type AmortizationQuery struct {
+	ExtraPayment float64 `json:"extra_payment,omitempty"` // paid towards principal every month
+}
+
+// AmortizationPayment is one monthly payment of a schedule. Balance is what is owed after it.
+type AmortizationPayment struct {
+	Number    int     `json:"number"`
+	Date      string  `json:"date"`
+	Payment   float64 `json:"payment"`
+	Principal float64 `json:"principal"`
+	Interest  float64 `json:"interest"`
+	Balance   float64 `json:"balance"`
+}
+
+// AmortizationSchedule pays a loan down from what is owed today. InterestSaved compares
+// with paying no extra and is omitted without an extra payment.
+type AmortizationSchedule struct {
+	AccountID     string                `json:"account_id"`
+	Currency      string                `json:"currency"`
+	Owed          float64               `json:"owed"`
+	APR           float64               `json:"apr"`
+	Payment       float64               `json:"payment"` // monthly payment, without the extra
+	ExtraPayment  float64               `json:"extra_payment"`
+	PaymentsLeft  int                   `json:"payments_left"`
+	PayoffDate    string                `json:"payoff_date"`
+	TotalInterest float64               `json:"total_interest"`
+	TotalPaid     float64               `json:"total_paid"`
+	InterestSaved *float64              `json:"interest_saved,omitempty"`
+	Payments      []AmortizationPayment `json:"payments"`
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+)
+
+// Reconciliation statuses
+const (
+	ReconciliationBalanced    = "balanced"
+	ReconciliationDiscrepancy = "discrepancy"
+)
+
+// Reconciliation anchor sources
+const (
+	AnchorOpening   = "opening"   // the balance implied when the account was first seen
+	AnchorStatement = "statement" // the closing balance of the last reconciled statement
+)
+
+// Reconciliation compares an account's provider-reported balance with the balance worked
+// out from its anchor and the transactions since
+type Reconciliation struct {
+	AccountID         string               `json:"account_id"`
+	Currency          string               `json:"currency"`
+	Status            string               `json:"status"` // balanced or discrepancy
+	ReportedBalance   float64              `json:"reported_balance"`
+	CalculatedBalance float64              `json:"calculated_balance"`
+	Discrepancy       float64              `json:"discrepancy"`                 // reported minus calculated
+	DiscrepancySince  *time.Time           `json:"discrepancy_since,omitempty"` // first reported balance of the current discrepancy
+	Anchor            ReconciliationAnchor `json:"anchor"`
+	TransactionCount  int                  `json:"transaction_count"` // transactions since the anchor
+	History           []BalanceCheck       `json:"history"`           // every reported balance since the anchor, oldest first
+}
+
+// ReconciliationAnchor is a balance known to be right, from which transactions are counted
+type ReconciliationAnchor struct {
+	Source      string    `json:"source"` // opening or statement
+	StatementID string    `json:"statement_id,omitempty"`
+	Date        time.Time `json:"date"` // transactions from here on count
+	Balance     float64   `json:"balance"`
+}
+
+// BalanceCheck is a provider-reported balance next to the calculated balance at that time
+type BalanceCheck struct {
+	At          time.Time `json:"at"`
+	Reported    float64   `json:"reported"`
+	Calculated  float64   `json:"calculated"`
+	Discrepancy float64   `json:"discrepancy"`
+}
+
+// Statement is a reconciled account statement. Its closing balance becomes the anchor of
+// later reconciliations.
+type Statement struct {
+	ID                string    `json:"id"`
+	AccountID         string    `json:"account_id"`
+	EndDate           string    `json:"end_date"` // last day covered, inclusive
+	Balance           float64   `json:"balance"`  // closing balance on the statement
+	CalculatedBalance float64   `json:"calculated_balance"`
+	Adjustment        float64   `json:"adjustment"` // balance minus calculated; zero when the history agreed
+	ReconciledAt      time.Time `json:"reconciled_at"`
+}
+
+// StatementRequest represents the body for marking a statement as reconciled
+type StatementRequest struct {
+	EndDate string   `json:"end_date"` // YYYY-MM-DD, before today
+	Balance *float64 `json:"balance"`
+}
@@ -2,10 +2,31 @@ package models
 
 // APIResponse represents a standard API response
 type APIResponse struct {
-	Success bool        `json:"success"`
-	Message string      `json:"message,omitempty"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
+	Success bool         `json:"success"`
+	Message string       `json:"message,omitempty"`
+	Data    interface{}  `json:"data,omitempty"`
+	Error   string       `json:"error,omitempty"`
+	Code    string       `json:"code,omitempty"` // stable error code such as account_not_found
+	Errors  []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes one invalid request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"` // required, invalid_format, out_of_range, not_allowed, invalid_json
+	Message string `json:"message"`
+}
+
+// ProblemDetails represents an RFC 7807 error response, sent when the client
+// accepts application/problem+json
+type ProblemDetails struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code"`
+	Errors   []FieldError `json:"errors,omitempty"`
 }
 
 // PaginatedResponse represents a paginated API response
@@ -6,24 +6,54 @@ import (
 
 // Transaction represents a financial transaction
 type Transaction struct {
+	ID          string       `json:"id"`
+	AccountID   string       `json:"account_id"`
+	Amount      float64      `json:"amount"`
+	Currency    string       `json:"currency"`
+	Type        string       `json:"type"`     // debit, credit, transfer, buy, sell, dividend, fee
+	Category    string       `json:"category"` // food, transportation, salary, etc.
+	Description string       `json:"description"`
+	Merchant    string       `json:"merchant,omitempty"` // normalized from the description
+	Date        time.Time    `json:"date"`
+	Status      string       `json:"status"` // pending, completed, failed, cancelled
+	Reference   string       `json:"reference,omitempty"`
+	Symbol      string       `json:"symbol,omitempty"`      // security of a buy, sell or dividend
+	Quantity    float64      `json:"quantity,omitempty"`    // units bought or sold
+	Price       float64      `json:"price,omitempty"`       // per unit, excluding commission
+	Tags        []string     `json:"tags,omitempty"`        // lowercase, sorted
+	Notes       string       `json:"notes,omitempty"`       // free text from the user
+	Attachments []Attachment `json:"attachments,omitempty"` // receipts, oldest first
+	Version     int64        `json:"version"`               // incremented on every change
+}
+
+// Attachment describes a file, such as a receipt, uploaded to a transaction. The content is
+// kept in the attachment store.
+type Attachment struct {
 	ID          string    `json:"id"`
-	AccountID   string    `json:"account_id"`
-	Amount      float64   `json:"amount"`
-	Currency    string    `json:"currency"`
-	Type        string    `json:"type"`     // debit, credit, transfer
-	Category    string    `json:"category"` // food, transportation, salary, etc.
-	Description string    `json:"description"`
-	Date        time.Time `json:"date"`
-	Status      string    `json:"status"` // pending, completed, failed, cancelled
-	Reference   string    `json:"reference,omitempty"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"` // detected from the content
+	Size        int64     `json:"size"`         // bytes
+	UploadedAt  time.Time `json:"uploaded_at"`
+}
+
+// TransactionUpdate represents the body for updating a transaction. Version must be the
+// version the client last read; nil fields are left unchanged.
+type TransactionUpdate struct {
+	Version     int64     `json:"version"`
+	Category    *string   `json:"category,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	Tags        *[]string `json:"tags,omitempty"`  // replaces every tag; [] removes them
+	Notes       *string   `json:"notes,omitempty"` // "" removes the notes
 }
 
 // TransactionFilter represents filters for querying transactions
 type TransactionFilter struct {
 	AccountID string     `json:"account_id,omitempty"`
 	Type      string     `json:"type,omitempty"`
+	Types     []string   `json:"-"` // any of these types, for v1 filters that cover several
 	Category  string     `json:"category,omitempty"`
 	Status    string     `json:"status,omitempty"`
+	Tag       string     `json:"tag,omitempty"` // transactions with this tag
 	StartDate *time.Time `json:"start_date,omitempty"`
 	EndDate   *time.Time `json:"end_date,omitempty"`
 	Limit     int        `json:"limit,omitempty"`
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"time"
+)
+
+// Money is a decimal amount serialised as a string so clients never round through binary floats
+type Money struct {
+	Amount   string `json:"amount"` // e.g. "-1234.50"
+	Currency string `json:"currency"`
+}
+
+// AccountV2 is the v2 representation of an account
+type AccountV2 struct {
+	ID          string       `json:"id"`
+	Name        string       `json:"name"`
+	Bank        string       `json:"bank"`
+	AccountType string       `json:"account_type"`
+	Balance     Money        `json:"balance"`
+	CreditLimit *Money       `json:"credit_limit,omitempty"`
+	Liability   *LiabilityV2 `json:"liability,omitempty"`
+	LastUpdated time.Time    `json:"last_updated"`
+	IsActive    bool         `json:"is_active"`
+	Version     int64        `json:"version"`
+}
+
+// LiabilityV2 is the v2 representation of the terms of a credit card or loan
+type LiabilityV2 struct {
+	APR            float64    `json:"apr"`
+	StatementDay   int        `json:"statement_day,omitempty"`
+	DueDate        *time.Time `json:"due_date,omitempty"`
+	MinimumPayment *Money     `json:"minimum_payment,omitempty"`
+	Principal      *Money     `json:"principal,omitempty"`
+	TermMonths     int        `json:"term_months,omitempty"`
+	OriginatedOn   *time.Time `json:"originated_on,omitempty"`
+}
+
+// TransactionV2 is the v2 representation of a transaction
+type TransactionV2 struct {
+	ID          string       `json:"id"`
+	AccountID   string       `json:"account_id"`
+	Amount      Money        `json:"amount"`
+	Type        string       `json:"type"`
+	Category    string       `json:"category"`
+	Description string       `json:"description"`
+	Merchant    string       `json:"merchant,omitempty"`
+	Date        time.Time    `json:"date"`
+	Status      string       `json:"status"`
+	Reference   string       `json:"reference,omitempty"`
+	Symbol      string       `json:"symbol,omitempty"`
+	Quantity    float64      `json:"quantity,omitempty"`
+	Price       *Money       `json:"price,omitempty"`
+	Tags        []string     `json:"tags,omitempty"`
+	Notes       string       `json:"notes,omitempty"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+	Version     int64        `json:"version"`
+}
+
+// ResponseV2 is the v2 success envelope; v2 errors are always RFC 7807 problem details
+type ResponseV2 struct {
+	Data interface{} `json:"data"`
+	Page *CursorPage `json:"page,omitempty"`
+}
+
+// CursorPage describes a page of a cursor-paginated list
+type CursorPage struct {
+	Limit      int    `json:"limit"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"` // pass as ?cursor= to fetch the next page
+}
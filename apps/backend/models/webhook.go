@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+)
+
+// Webhook-only event types
+const (
+	EventBalanceThresholdCrossed = "balance.threshold_crossed"
+	EventWebhookTest             = "webhook.test"
+)
+
+// Webhook delivery statuses
+const (
+	DeliveryPending   = "pending"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+// WebhookSubscription represents an endpoint notified about events
+type WebhookSubscription struct {
+	ID                string    `json:"id"`
+	URL               string    `json:"url"`
+	EventTypes        []string  `json:"event_types"`
+	Secret            string    `json:"secret,omitempty"` // only returned when the subscription is created
+	BalanceThresholds []float64 `json:"balance_thresholds,omitempty"`
+	IsActive          bool      `json:"is_active"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// WebhookSubscriptionRequest represents the body for creating or updating a subscription
+type WebhookSubscriptionRequest struct {
+	URL               string    `json:"url"`
+	EventTypes        []string  `json:"event_types"`
+	Secret            string    `json:"secret,omitempty"`
+	BalanceThresholds []float64 `json:"balance_thresholds,omitempty"`
+	IsActive          *bool     `json:"is_active,omitempty"`
+}
+
+// WebhookPayload is the JSON body POSTed to subscribers
+type WebhookPayload struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// BalanceThresholdCrossing is the data of a balance.threshold_crossed event
+type BalanceThresholdCrossing struct {
+	AccountID       string  `json:"account_id"`
+	Threshold       float64 `json:"threshold"`
+	PreviousBalance float64 `json:"previous_balance"`
+	NewBalance      float64 `json:"new_balance"`
+	Direction       string  `json:"direction"` // above, below
+}
+
+// WebhookDelivery records an attempt to deliver an event to a subscription
+type WebhookDelivery struct {
+	ID             string     `json:"id"`
+	SubscriptionID string     `json:"subscription_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"` // pending, succeeded, failed
+	Attempts       int        `json:"attempts"`
+	ResponseCode   int        `json:"response_code,omitempty"`
+	Error          string     `json:"error,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	LastAttemptAt  *time.Time `json:"last_attempt_at,omitempty"`
+}
@@ -0,0 +1,310 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: aggregator/v1/aggregator.proto
+
+package aggregatorv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	AggregatorService_ListAccounts_FullMethodName     = "/finagg.aggregator.v1.AggregatorService/ListAccounts"
+	AggregatorService_GetAccount_FullMethodName       = "/finagg.aggregator.v1.AggregatorService/GetAccount"
+	AggregatorService_RefreshAccount_FullMethodName   = "/finagg.aggregator.v1.AggregatorService/RefreshAccount"
+	AggregatorService_ListTransactions_FullMethodName = "/finagg.aggregator.v1.AggregatorService/ListTransactions"
+	AggregatorService_WatchChanges_FullMethodName     = "/finagg.aggregator.v1.AggregatorService/WatchChanges"
+)
+
+// AggregatorServiceClient is the client API for AggregatorService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AggregatorService exposes accounts, transactions and change events to internal services.
+// Errors use standard gRPC codes; the REST error code (for example "account_not_found") is
+// sent as the reason of a google.rpc.ErrorInfo detail, and validation failures also carry a
+// google.rpc.BadRequest detail with one violation per field.
+type AggregatorServiceClient interface {
+	// ListAccounts returns every account, ordered by ID.
+	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
+	// GetAccount returns a single account.
+	GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*Account, error)
+	// RefreshAccount fetches the latest balance from the provider.
+	RefreshAccount(ctx context.Context, in *RefreshAccountRequest, opts ...grpc.CallOption) (*RefreshAccountResponse, error)
+	// ListTransactions returns transactions newest first, one page at a time.
+	ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error)
+	// WatchChanges streams change events until the client cancels or the server shuts down.
+	WatchChanges(ctx context.Context, in *WatchChangesRequest, opts ...grpc.CallOption) (AggregatorService_WatchChangesClient, error)
+}
+
+type aggregatorServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAggregatorServiceClient(cc grpc.ClientConnInterface) AggregatorServiceClient {
+	return &aggregatorServiceClient{cc}
+}
+
+func (c *aggregatorServiceClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccountsResponse)
+	err := c.cc.Invoke(ctx, AggregatorService_ListAccounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aggregatorServiceClient) GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Account)
+	err := c.cc.Invoke(ctx, AggregatorService_GetAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aggregatorServiceClient) RefreshAccount(ctx context.Context, in *RefreshAccountRequest, opts ...grpc.CallOption) (*RefreshAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefreshAccountResponse)
+	err := c.cc.Invoke(ctx, AggregatorService_RefreshAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aggregatorServiceClient) ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTransactionsResponse)
+	err := c.cc.Invoke(ctx, AggregatorService_ListTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aggregatorServiceClient) WatchChanges(ctx context.Context, in *WatchChangesRequest, opts ...grpc.CallOption) (AggregatorService_WatchChangesClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AggregatorService_ServiceDesc.Streams[0], AggregatorService_WatchChanges_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aggregatorServiceWatchChangesClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AggregatorService_WatchChangesClient interface {
+	Recv() (*ChangeEvent, error)
+	grpc.ClientStream
+}
+
+type aggregatorServiceWatchChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *aggregatorServiceWatchChangesClient) Recv() (*ChangeEvent, error) {
+	m := new(ChangeEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// AggregatorServiceServer is the server API for AggregatorService service.
+// All implementations must embed UnimplementedAggregatorServiceServer
+// for forward compatibility
+//
+// AggregatorService exposes accounts, transactions and change events to internal services.
+// Errors use standard gRPC codes; the REST error code (for example "account_not_found") is
+// sent as the reason of a google.rpc.ErrorInfo detail, and validation failures also carry a
+// google.rpc.BadRequest detail with one violation per field.
+type AggregatorServiceServer interface {
+	// ListAccounts returns every account, ordered by ID.
+	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
+	// GetAccount returns a single account.
+	GetAccount(context.Context, *GetAccountRequest) (*Account, error)
+	// RefreshAccount fetches the latest balance from the provider.
+	RefreshAccount(context.Context, *RefreshAccountRequest) (*RefreshAccountResponse, error)
+	// ListTransactions returns transactions newest first, one page at a time.
+	ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error)
+	// WatchChanges streams change events until the client cancels or the server shuts down.
+	WatchChanges(*WatchChangesRequest, AggregatorService_WatchChangesServer) error
+	mustEmbedUnimplementedAggregatorServiceServer()
+}
+
+// UnimplementedAggregatorServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedAggregatorServiceServer struct {
+}
+
+func (UnimplementedAggregatorServiceServer) ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccounts not implemented")
+}
+func (UnimplementedAggregatorServiceServer) GetAccount(context.Context, *GetAccountRequest) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccount not implemented")
+}
+func (UnimplementedAggregatorServiceServer) RefreshAccount(context.Context, *RefreshAccountRequest) (*RefreshAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshAccount not implemented")
+}
+func (UnimplementedAggregatorServiceServer) ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransactions not implemented")
+}
+func (UnimplementedAggregatorServiceServer) WatchChanges(*WatchChangesRequest, AggregatorService_WatchChangesServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchChanges not implemented")
+}
+func (UnimplementedAggregatorServiceServer) mustEmbedUnimplementedAggregatorServiceServer() {}
+
+// UnsafeAggregatorServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AggregatorServiceServer will
+// result in compilation errors.
+type UnsafeAggregatorServiceServer interface {
+	mustEmbedUnimplementedAggregatorServiceServer()
+}
+
+func RegisterAggregatorServiceServer(s grpc.ServiceRegistrar, srv AggregatorServiceServer) {
+	s.RegisterService(&AggregatorService_ServiceDesc, srv)
+}
+
+func _AggregatorService_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AggregatorServiceServer).ListAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AggregatorService_ListAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AggregatorServiceServer).ListAccounts(ctx, req.(*ListAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AggregatorService_GetAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AggregatorServiceServer).GetAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AggregatorService_GetAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AggregatorServiceServer).GetAccount(ctx, req.(*GetAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AggregatorService_RefreshAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AggregatorServiceServer).RefreshAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AggregatorService_RefreshAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AggregatorServiceServer).RefreshAccount(ctx, req.(*RefreshAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AggregatorService_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AggregatorServiceServer).ListTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AggregatorService_ListTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AggregatorServiceServer).ListTransactions(ctx, req.(*ListTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AggregatorService_WatchChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchChangesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AggregatorServiceServer).WatchChanges(m, &aggregatorServiceWatchChangesServer{ServerStream: stream})
+}
+
+type AggregatorService_WatchChangesServer interface {
+	Send(*ChangeEvent) error
+	grpc.ServerStream
+}
+
+type aggregatorServiceWatchChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *aggregatorServiceWatchChangesServer) Send(m *ChangeEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// AggregatorService_ServiceDesc is the grpc.ServiceDesc for AggregatorService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AggregatorService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "finagg.aggregator.v1.AggregatorService",
+	HandlerType: (*AggregatorServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListAccounts",
+			Handler:    _AggregatorService_ListAccounts_Handler,
+		},
+		{
+			MethodName: "GetAccount",
+			Handler:    _AggregatorService_GetAccount_Handler,
+		},
+		{
+			MethodName: "RefreshAccount",
+			Handler:    _AggregatorService_RefreshAccount_Handler,
+		},
+		{
+			MethodName: "ListTransactions",
+			Handler:    _AggregatorService_ListTransactions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchChanges",
+			Handler:       _AggregatorService_WatchChanges_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "aggregator/v1/aggregator.proto",
+}
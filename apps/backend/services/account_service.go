@@ -1,7 +1,11 @@
 package services
 
 import (
-	"errors"
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
 	"sync"
 	"time"
 
@@ -12,6 +16,20 @@ import (
 type AccountService struct {
 	accounts map[string]*models.Account
 	mutex    sync.RWMutex
+	events   *EventBus
+	audit    *AuditLog
+	holdings HoldingsValuer
+	recorder TransactionRecorder
+}
+
+// HoldingsValuer values the holdings of investment accounts
+type HoldingsValuer interface {
+	TotalValue(accountID string) (float64, error)
+}
+
+// TransactionRecorder records the transactions the provider reports behind a balance change
+type TransactionRecorder interface {
+	CreateTransaction(ctx context.Context, transaction *models.Transaction) (*models.Transaction, error)
 }
 
 // NewAccountService creates a new AccountService instance
@@ -23,54 +41,144 @@ func NewAccountService() *AccountService {
 	return service
 }
 
-// GetAllAccounts returns all accounts
+// SetEventBus configures where account change events are published
+func (s *AccountService) SetEventBus(events *EventBus) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.events = events
+}
+
+// SetAuditLog configures where account changes are recorded
+func (s *AccountService) SetAuditLog(audit *AuditLog) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.audit = audit
+}
+
+// SetTransactionRecorder records a transaction for the balance change of every refresh of a
+// non-investment account, so the transaction history keeps adding up to the balance
+func (s *AccountService) SetTransactionRecorder(recorder TransactionRecorder) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.recorder = recorder
+}
+
+// SetHoldingsValuer derives the balance of investment accounts from their holdings, now and on
+// every refresh. Setting it values the accounts without changing their versions.
+func (s *AccountService) SetHoldingsValuer(holdings HoldingsValuer) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.holdings = holdings
+	for id, current := range s.accounts {
+		if current.AccountType != "investment" {
+			continue
+		}
+		if value, err := holdings.TotalValue(id); err == nil {
+			account := copyAccount(current)
+			account.Balance = value
+			s.accounts[id] = account
+		}
+	}
+}
+
+// GetAllAccounts returns copies of all accounts
 func (s *AccountService) GetAllAccounts() ([]*models.Account, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
 	accounts := make([]*models.Account, 0, len(s.accounts))
 	for _, account := range s.accounts {
-		accounts = append(accounts, account)
+		accounts = append(accounts, copyAccount(account))
 	}
+	// Stable order, so list ETags only change when the content does
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].ID < accounts[j].ID })
 
 	return accounts, nil
 }
 
-// GetAccountByID returns an account by ID
+// GetAccountByID returns a copy of an account by ID
 func (s *AccountService) GetAccountByID(id string) (*models.Account, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
 	account, exists := s.accounts[id]
 	if !exists {
-		return nil, errors.New("account not found")
+		return nil, ErrAccountNotFound
 	}
 
-	return account, nil
+	return copyAccount(account), nil
+}
+
+// GetAccountsByIDs returns copies of the accounts with the given IDs in one lookup; missing IDs are omitted
+func (s *AccountService) GetAccountsByIDs(ids []string) (map[string]*models.Account, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	accounts := make(map[string]*models.Account, len(ids))
+	for _, id := range ids {
+		if account, exists := s.accounts[id]; exists {
+			accounts[id] = copyAccount(account)
+		}
+	}
+
+	return accounts, nil
 }
 
 // RefreshAccount simulates fetching updated data from external sources
-func (s *AccountService) RefreshAccount(accountID string) (*models.AccountRefreshResponse, error) {
+func (s *AccountService) RefreshAccount(ctx context.Context, accountID string) (*models.AccountRefreshResponse, error) {
+	return s.RefreshAccountAtVersion(ctx, accountID, 0)
+}
+
+// RefreshAccountAtVersion refreshes the account only if it is still at version, so a client
+// acting on what it last read does not silently overwrite a newer change. A version of 0
+// refreshes whatever version is current.
+func (s *AccountService) RefreshAccountAtVersion(ctx context.Context, accountID string, version int64) (*models.AccountRefreshResponse, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
 	account, exists := s.accounts[accountID]
 	if !exists {
-		return &models.AccountRefreshResponse{
+		response := &models.AccountRefreshResponse{
 			AccountID:   accountID,
 			Success:     false,
 			Message:     "account not found",
 			LastUpdated: time.Now(),
-		}, errors.New("account not found")
+		}
+		publishEvent(s.events, models.EventRefreshFailed, *response)
+		return response, ErrAccountNotFound
+	}
+	if version != 0 && account.Version != version {
+		return nil, ErrVersionConflict
+	}
+
+	// Simulate external API call delay; a caller that gives up sees the provider as unavailable
+	select {
+	case <-time.After(100 * time.Millisecond):
+	case <-ctx.Done():
+		return nil, ErrAccountProviderUnavailable.Wrap(ctx.Err())
 	}
 
-	// Simulate external API call delay
-	time.Sleep(100 * time.Millisecond)
+	// Change a copy and swap it in, so copies handed out earlier never change underneath readers
+	before := *account
+	account = copyAccount(account)
 
-	// Simulate balance update (random change between -100 and +100)
-	balanceChange := (float64(time.Now().UnixNano()%200) - 100) / 100
-	account.Balance += balanceChange
+	// Investment balances follow their holdings; others simulate a change between -100 and +100
+	if account.AccountType == "investment" && s.holdings != nil {
+		value, err := s.holdings.TotalValue(accountID)
+		if err != nil {
+			return nil, err
+		}
+		account.Balance = value
+	} else {
+		balanceChange := (float64(time.Now().UnixNano()%200) - 100) / 100
+		account.Balance += balanceChange
+	}
 	account.LastUpdated = time.Now()
+	account.Version++
 
 	// Ensure balance doesn't go negative for checking/savings accounts
 	if account.AccountType == "checking" || account.AccountType == "savings" {
@@ -79,18 +187,163 @@ func (s *AccountService) RefreshAccount(accountID string) (*models.AccountRefres
 		}
 	}
 
-	return &models.AccountRefreshResponse{
+	// The mock provider reports the transaction behind the change before the new balance
+	if change := math.Round((account.Balance-before.Balance)*100) / 100; change != 0 && account.AccountType != "investment" && s.recorder != nil {
+		transactionType := "credit"
+		if change < 0 {
+			transactionType = "debit"
+		}
+		if _, err := s.recorder.CreateTransaction(ctx, &models.Transaction{
+			ID:          fmt.Sprintf("txn_%s_v%d", accountID, account.Version),
+			AccountID:   accountID,
+			Amount:      change,
+			Currency:    account.Currency,
+			Type:        transactionType,
+			Category:    uncategorized,
+			Description: "Provider balance adjustment",
+			Date:        account.LastUpdated,
+			Status:      "completed",
+		}); err != nil {
+			return nil, err
+		}
+	}
+	s.accounts[accountID] = account
+
+	response := &models.AccountRefreshResponse{
 		AccountID:   accountID,
 		Success:     true,
 		Message:     "account data refreshed successfully",
 		LastUpdated: account.LastUpdated,
 		NewBalance:  account.Balance,
-	}, nil
+		Version:     account.Version,
+	}
+
+	recordAudit(ctx, s.audit, models.AuditAccountRefreshed, "account", accountID, before, *account)
+	publishEvent(s.events, models.EventAccountUpdated, *account)
+	publishEvent(s.events, models.EventRefreshCompleted, *response)
+
+	response.Account = copyAccount(account)
+	return response, nil
+}
+
+// UpdateLiability changes the terms of a credit or loan account, if it is still at
+// update.Version. Nil fields are left unchanged; an update that changes nothing keeps the version.
+func (s *AccountService) UpdateLiability(ctx context.Context, accountID string, update *models.LiabilityUpdate) (*models.Account, error) {
+	validation := &ValidationError{}
+	if update.Version <= 0 {
+		validation.Add("version", CodeRequired, "version is required")
+	}
+	if update.CreditLimit != nil && *update.CreditLimit < 0 {
+		validation.Add("credit_limit", CodeOutOfRange, "credit_limit must not be negative")
+	}
+	if update.APR != nil && (*update.APR < 0 || *update.APR > 100) {
+		validation.Add("apr", CodeOutOfRange, "apr must be between 0 and 100")
+	}
+	if update.StatementDay != nil && (*update.StatementDay < 1 || *update.StatementDay > 31) {
+		validation.Add("statement_day", CodeOutOfRange, "statement_day must be between 1 and 31")
+	}
+	if update.MinimumPayment != nil && *update.MinimumPayment < 0 {
+		validation.Add("minimum_payment", CodeOutOfRange, "minimum_payment must not be negative")
+	}
+	if update.Principal != nil && *update.Principal < 0 {
+		validation.Add("principal", CodeOutOfRange, "principal must not be negative")
+	}
+	if update.TermMonths != nil && (*update.TermMonths < 1 || *update.TermMonths > maxTermMonths) {
+		validation.Addf("term_months", CodeOutOfRange, "term_months must be between 1 and %d", maxTermMonths)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	current, exists := s.accounts[accountID]
+	if !exists {
+		return nil, ErrAccountNotFound
+	}
+	if !isLiabilityType(current.AccountType) {
+		return nil, ErrNotLiabilityAccount
+	}
+
+	// Credit limits are for cards; principal, term and origination for loans
+	if current.AccountType == "loan" && update.CreditLimit != nil {
+		validation.Add("credit_limit", CodeNotAllowed, "credit_limit is for credit accounts only")
+	}
+	if current.AccountType == "credit" {
+		if update.Principal != nil {
+			validation.Add("principal", CodeNotAllowed, "principal is for loan accounts only")
+		}
+		if update.TermMonths != nil {
+			validation.Add("term_months", CodeNotAllowed, "term_months is for loan accounts only")
+		}
+		if update.OriginatedOn != nil {
+			validation.Add("originated_on", CodeNotAllowed, "originated_on is for loan accounts only")
+		}
+	}
+	if err := validation.Err(); err != nil {
+		return nil, err
+	}
+	if current.Version != update.Version {
+		return nil, ErrVersionConflict
+	}
+
+	// Change a copy and swap it in, so copies handed out earlier never change underneath readers
+	account := copyAccount(current)
+	if account.Liability == nil {
+		account.Liability = &models.Liability{}
+	}
+	liability := account.Liability
+	if update.CreditLimit != nil {
+		account.CreditLimit = *update.CreditLimit
+	}
+	if update.APR != nil {
+		liability.APR = *update.APR
+	}
+	if update.StatementDay != nil {
+		liability.StatementDay = *update.StatementDay
+	}
+	if update.DueDate != nil {
+		dueDate := *update.DueDate
+		liability.DueDate = &dueDate
+	}
+	if update.MinimumPayment != nil {
+		liability.MinimumPayment = *update.MinimumPayment
+	}
+	if update.Principal != nil {
+		liability.Principal = *update.Principal
+	}
+	if update.TermMonths != nil {
+		liability.TermMonths = *update.TermMonths
+	}
+	if update.OriginatedOn != nil {
+		originatedOn := *update.OriginatedOn
+		liability.OriginatedOn = &originatedOn
+	}
+	if reflect.DeepEqual(account, current) {
+		return account, nil
+	}
+	account.Version++
+	s.accounts[accountID] = account
+
+	recordAudit(ctx, s.audit, models.AuditAccountUpdated, "account", accountID, *current, *account)
+	publishEvent(s.events, models.EventAccountUpdated, *account)
+
+	return copyAccount(account), nil
+}
+
+// maxTermMonths is the longest loan term accepted, forty years
+const maxTermMonths = 480
+
+// isLiabilityType reports whether an account type is a credit card or loan
+func isLiabilityType(accountType string) bool {
+	return accountType == "credit" || accountType == "loan"
 }
 
 // initializeMockData populates the service with mock data
 func (s *AccountService) initializeMockData() {
 	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	cardDueDate := today.AddDate(0, 0, 12)
+	loanDueDate := today.AddDate(0, 0, 20)
+	loanOriginatedOn := loanDueDate.AddDate(0, -19, 0)
 
 	mockAccounts := []*models.Account{
 		{
@@ -119,6 +372,13 @@ func (s *AccountService) initializeMockData() {
 			Bank:        "Capital One",
 			AccountType: "credit",
 			Balance:     -1200.50,
+			CreditLimit: 5000.00,
+			Liability: &models.Liability{
+				APR:            24.99,
+				StatementDay:   5,
+				DueDate:        &cardDueDate,
+				MinimumPayment: 35.00,
+			},
 			Currency:    "USD",
 			LastUpdated: now.Add(-30 * time.Minute),
 			IsActive:    true,
@@ -153,9 +413,39 @@ func (s *AccountService) initializeMockData() {
 			LastUpdated: now.Add(-1 * time.Hour),
 			IsActive:    true,
 		},
+		{
+			// 18 of 60 payments made
+			ID:          "acc_007",
+			Name:        "Auto Loan",
+			Bank:        "Capital One",
+			AccountType: "loan",
+			Balance:     -18329.63,
+			Liability: &models.Liability{
+				APR:            6.49,
+				DueDate:        &loanDueDate,
+				MinimumPayment: 489.04,
+				Principal:      25000.00,
+				TermMonths:     60,
+				OriginatedOn:   &loanOriginatedOn,
+			},
+			Currency:    "USD",
+			LastUpdated: now.Add(-3 * time.Hour),
+			IsActive:    true,
+		},
 	}
 
 	for _, account := range mockAccounts {
+		account.Version = 1
 		s.accounts[account.ID] = account
 	}
 }
+
+// copyAccount returns a copy of account that callers may keep or modify
+func copyAccount(account *models.Account) *models.Account {
+	copied := *account
+	if account.Liability != nil {
+		liability := *account.Liability
+		copied.Liability = &liability
+	}
+	return &copied
+}
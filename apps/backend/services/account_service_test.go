@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestAccountService_RefreshAccountAtVersion(t *testing.T) {
+	service := NewAccountService()
+	ctx := context.Background()
+
+	read, err := service.GetAccountByID("acc_001")
+	if err != nil || read.Version != 1 {
+		t.Fatalf("Expected acc_001 at version 1, got %+v (%v)", read, err)
+	}
+	balance := read.Balance
+
+	refresh, err := service.RefreshAccountAtVersion(ctx, "acc_001", read.Version)
+	if err != nil || refresh.Version != 2 {
+		t.Fatalf("Expected the refresh to move to version 2, got %+v (%v)", refresh, err)
+	}
+	if account := refresh.Account; account == nil || account.Version != 2 || account.Balance != refresh.NewBalance {
+		t.Errorf("Expected the refreshed account at version 2, got %+v", account)
+	}
+
+	// The copy read before the refresh still holds the old state
+	if read.Version != 1 || read.Balance != balance {
+		t.Errorf("Expected the earlier copy to be unchanged, got %+v", read)
+	}
+
+	if _, err := service.RefreshAccountAtVersion(ctx, "acc_001", 1); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("Expected a version conflict for a stale version, got %v", err)
+	}
+
+	// Version 0 refreshes whatever is current
+	if refresh, err := service.RefreshAccount(ctx, "acc_001"); err != nil || refresh.Version != 3 {
+		t.Errorf("Expected an unconditional refresh to version 3, got %+v (%v)", refresh, err)
+	}
+}
+
+func TestAccountService_ConcurrentReadsAndRefreshes(t *testing.T) {
+	service := NewAccountService()
+	ctx := context.Background()
+
+	// Run with -race: readers must never share structs with a refresh in progress
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := service.RefreshAccount(ctx, "acc_002"); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			accounts, _ := service.GetAllAccounts()
+			for _, account := range accounts {
+				_ = account.Balance + float64(account.Version)
+			}
+		}()
+	}
+	wg.Wait()
+
+	account, _ := service.GetAccountByID("acc_002")
+	if account.Version != 5 {
+		t.Errorf("Expected 4 refreshes to reach version 5, got %d", account.Version)
+	}
+}
+
+func TestAccountService_RefreshRecordsTransactions(t *testing.T) {
+	service := NewAccountService()
+	transactions := NewTransactionService()
+	service.SetTransactionRecorder(transactions)
+	ctx := context.Background()
+
+	before, _ := service.GetAccountByID("acc_002")
+	for i := 0; i < 3; i++ {
+		if _, err := service.RefreshAccount(ctx, "acc_002"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	after, _ := service.GetAccountByID("acc_002")
+
+	// The recorded transactions add up to the change in balance
+	all, _ := transactions.GetAllTransactions(nil)
+	recorded := 0.0
+	for _, transaction := range all {
+		if strings.HasPrefix(transaction.ID, "txn_acc_002_") {
+			recorded += transaction.Amount
+			if transaction.Version != 1 || transaction.Category != uncategorized || (transaction.Amount < 0) != (transaction.Type == "debit") {
+				t.Errorf("Unexpected transaction %+v", transaction)
+			}
+		}
+	}
+	if math.Abs(before.Balance+recorded-after.Balance) > 0.001 {
+		t.Errorf("Expected transactions of %.2f to explain the change from %.2f to %.2f", recorded, before.Balance, after.Balance)
+	}
+}
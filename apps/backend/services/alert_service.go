@@ -0,0 +1,459 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// maxStoredAlerts bounds how many triggered alerts are kept
+const maxStoredAlerts = 1000
+
+// maxFiredPairs bounds the rule|transaction pairs remembered so a transaction fires a rule
+// once. The oldest are forgotten first, so only a transaction updated after that many newer
+// alerts can fire again.
+const maxFiredPairs = 10000
+
+// AccountReader is the subset of AccountService used to look up accounts
+type AccountReader interface {
+	GetAllAccounts() ([]*models.Account, error)
+	GetAccountByID(id string) (*models.Account, error)
+}
+
+// AlertService evaluates alert rules whenever accounts or transactions change
+type AlertService struct {
+	rules       map[string]*models.AlertRule
+	alerts      []*models.Alert
+	active      map[string]bool // rule|account pairs whose balance condition currently holds
+	fired       map[string]bool // rule|transaction pairs that already triggered
+	firedOrder  []string        // keys of fired, oldest first
+	nextRuleID  int
+	nextAlertID int
+	mutex       sync.RWMutex
+
+	accounts AccountReader
+	eventBus *EventBus
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewAlertService creates a new AlertService instance
+func NewAlertService(eventBus *EventBus, accounts AccountReader) *AlertService {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &AlertService{
+		rules:    make(map[string]*models.AlertRule),
+		active:   make(map[string]bool),
+		fired:    make(map[string]bool),
+		accounts: accounts,
+		eventBus: eventBus,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// CreateRule registers an alert rule and evaluates it against current balances
+func (s *AlertService) CreateRule(request *models.AlertRuleRequest) (*models.AlertRule, error) {
+	if err := validateAlertRule(request); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	s.nextRuleID++
+	rule := &models.AlertRule{
+		ID:          fmt.Sprintf("alr_%03d", s.nextRuleID),
+		Name:        request.Name,
+		Type:        request.Type,
+		AccountID:   request.AccountID,
+		AccountType: request.AccountType,
+		Category:    request.Category,
+		Threshold:   request.Threshold,
+		IsActive:    true,
+		CreatedAt:   time.Now(),
+	}
+	if rule.Name == "" {
+		rule.Name = describeAlertRule(rule)
+	}
+	s.rules[rule.ID] = rule
+	created := *rule
+	s.mutex.Unlock()
+
+	// Balance conditions that already hold fire immediately
+	if s.accounts != nil {
+		if accounts, err := s.accounts.GetAllAccounts(); err == nil {
+			for _, account := range accounts {
+				s.EvaluateAccount(*account)
+			}
+		}
+	}
+
+	return &created, nil
+}
+
+// GetAllRules returns all alert rules
+func (s *AlertService) GetAllRules() ([]*models.AlertRule, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	rules := make([]*models.AlertRule, 0, len(s.rules))
+	for _, rule := range s.rules {
+		copied := *rule
+		rules = append(rules, &copied)
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].ID < rules[j].ID
+	})
+
+	return rules, nil
+}
+
+// GetRuleByID returns an alert rule by ID
+func (s *AlertService) GetRuleByID(id string) (*models.AlertRule, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	rule, exists := s.rules[id]
+	if !exists {
+		return nil, ErrAlertRuleNotFound
+	}
+
+	copied := *rule
+	return &copied, nil
+}
+
+// DeleteRule removes an alert rule; alerts it already triggered are kept
+func (s *AlertService) DeleteRule(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.rules[id]; !exists {
+		return ErrAlertRuleNotFound
+	}
+
+	delete(s.rules, id)
+	for key := range s.active {
+		if strings.HasPrefix(key, id+"|") {
+			delete(s.active, key)
+		}
+	}
+	kept := s.firedOrder[:0]
+	for _, key := range s.firedOrder {
+		if strings.HasPrefix(key, id+"|") {
+			delete(s.fired, key)
+		} else {
+			kept = append(kept, key)
+		}
+	}
+	s.firedOrder = kept
+
+	return nil
+}
+
+// GetAlerts returns triggered alerts, newest first, optionally filtered by rule or account
+func (s *AlertService) GetAlerts(ruleID, accountID string) ([]*models.Alert, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	alerts := make([]*models.Alert, 0)
+	for i := len(s.alerts) - 1; i >= 0; i-- {
+		alert := s.alerts[i]
+		if ruleID != "" && alert.RuleID != ruleID {
+			continue
+		}
+		if accountID != "" && alert.AccountID != accountID {
+			continue
+		}
+		copied := *alert
+		alerts = append(alerts, &copied)
+	}
+
+	return alerts, nil
+}
+
+// Start subscribes to the event bus and evaluates rules on every change
+func (s *AlertService) Start() {
+	if s.eventBus == nil {
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.eventBus.Consume(s.ctx, s.handleEvent)
+	}()
+}
+
+// Stop stops evaluating events and waits for the consumer or ctx to expire
+func (s *AlertService) Stop(ctx context.Context) error {
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// handleEvent routes account and transaction changes to the evaluators
+func (s *AlertService) handleEvent(event models.Event) {
+	switch data := event.Data.(type) {
+	case models.Account:
+		if event.Type == models.EventAccountUpdated {
+			s.EvaluateAccount(data)
+		}
+	case models.Transaction:
+		// A recategorized transaction can match a rule it missed; fired pairs are not repeated
+		if event.Type == models.EventTransactionCreated || event.Type == models.EventTransactionUpdated {
+			s.EvaluateTransaction(data)
+		}
+	}
+}
+
+// EvaluateAccount checks balance and utilization rules for an account. Each rule fires once
+// when its condition becomes true and re-arms after the condition clears.
+func (s *AlertService) EvaluateAccount(account models.Account) {
+	var triggered []*models.Alert
+
+	s.mutex.Lock()
+	for _, rule := range s.rules {
+		if !rule.IsActive || !ruleMatchesAccount(rule, account) {
+			continue
+		}
+
+		value, holds, applicable := evaluateBalanceRule(rule, account)
+		if !applicable {
+			continue
+		}
+
+		key := rule.ID + "|" + account.ID
+		if !holds {
+			delete(s.active, key)
+			continue
+		}
+		if s.active[key] {
+			continue
+		}
+
+		s.active[key] = true
+		triggered = append(triggered, s.recordAlert(rule, account.ID, "", value,
+			fmt.Sprintf("%s: %s (%s is %.2f)", rule.Name, account.Name, alertValueLabel(rule.Type), value)))
+	}
+	s.mutex.Unlock()
+
+	s.publishAlerts(triggered)
+}
+
+// EvaluateTransaction checks transaction rules; each transaction fires a rule at most once
+func (s *AlertService) EvaluateTransaction(transaction models.Transaction) {
+	if transaction.Status == "failed" || transaction.Status == "cancelled" {
+		return
+	}
+
+	// Account type scoped rules need the owning account
+	var account models.Account
+	if s.accounts != nil {
+		if owner, err := s.accounts.GetAccountByID(transaction.AccountID); err == nil {
+			account = *owner
+		}
+	}
+	account.ID = transaction.AccountID
+
+	var triggered []*models.Alert
+
+	s.mutex.Lock()
+	for _, rule := range s.rules {
+		if !rule.IsActive || !ruleMatchesAccount(rule, account) {
+			continue
+		}
+
+		value, holds := evaluateTransactionRule(rule, transaction)
+		if !holds {
+			continue
+		}
+
+		key := rule.ID + "|" + transaction.ID
+		if s.fired[key] {
+			continue
+		}
+
+		s.markFired(key)
+		triggered = append(triggered, s.recordAlert(rule, transaction.AccountID, transaction.ID, value,
+			fmt.Sprintf("%s: %s %.2f (%s)", rule.Name, transaction.Description, transaction.Amount, transaction.Category)))
+	}
+	s.mutex.Unlock()
+
+	s.publishAlerts(triggered)
+}
+
+// markFired remembers that a rule fired for a transaction, forgetting the oldest pair beyond
+// maxFiredPairs. The caller must hold the write lock.
+func (s *AlertService) markFired(key string) {
+	s.fired[key] = true
+	s.firedOrder = append(s.firedOrder, key)
+	if len(s.firedOrder) > maxFiredPairs {
+		delete(s.fired, s.firedOrder[0])
+		s.firedOrder = s.firedOrder[1:]
+	}
+}
+
+// recordAlert stores a triggered alert. The caller must hold the write lock.
+func (s *AlertService) recordAlert(rule *models.AlertRule, accountID, transactionID string, value float64, message string) *models.Alert {
+	s.nextAlertID++
+	alert := &models.Alert{
+		ID:            fmt.Sprintf("alt_%06d", s.nextAlertID),
+		RuleID:        rule.ID,
+		RuleName:      rule.Name,
+		Type:          rule.Type,
+		AccountID:     accountID,
+		TransactionID: transactionID,
+		Message:       message,
+		Value:         value,
+		Threshold:     rule.Threshold,
+		TriggeredAt:   time.Now(),
+	}
+
+	s.alerts = append(s.alerts, alert)
+	if len(s.alerts) > maxStoredAlerts {
+		s.alerts = s.alerts[len(s.alerts)-maxStoredAlerts:]
+	}
+
+	return alert
+}
+
+// publishAlerts announces triggered alerts on the event bus
+func (s *AlertService) publishAlerts(alerts []*models.Alert) {
+	for _, alert := range alerts {
+		publishEvent(s.eventBus, models.EventAlertTriggered, *alert)
+	}
+}
+
+// ruleMatchesAccount reports whether a rule's account scope includes account
+func ruleMatchesAccount(rule *models.AlertRule, account models.Account) bool {
+	if rule.AccountID != "" && rule.AccountID != account.ID {
+		return false
+	}
+	if rule.AccountType != "" && rule.AccountType != account.AccountType {
+		return false
+	}
+	return true
+}
+
+// evaluateBalanceRule returns the observed value, whether the condition holds and
+// whether the rule applies to account-level state at all
+func evaluateBalanceRule(rule *models.AlertRule, account models.Account) (float64, bool, bool) {
+	switch rule.Type {
+	case models.AlertBalanceBelow:
+		return account.Balance, account.Balance < rule.Threshold, true
+	case models.AlertBalanceAbove:
+		return account.Balance, account.Balance > rule.Threshold, true
+	case models.AlertCreditUtilizationAbove:
+		utilization, ok := CreditUtilization(account)
+		if !ok {
+			return 0, false, false
+		}
+		return utilization, utilization > rule.Threshold, true
+	}
+	return 0, false, false
+}
+
+// evaluateTransactionRule returns the observed value and whether the condition holds
+func evaluateTransactionRule(rule *models.AlertRule, transaction models.Transaction) (float64, bool) {
+	switch rule.Type {
+	case models.AlertDebitOver:
+		// Buying or selling a holding moves money between positions rather than spending it,
+		// as in the cashflow reports
+		if transaction.Type == models.TransactionBuy || transaction.Type == models.TransactionSell {
+			return 0, false
+		}
+		if transaction.Amount >= 0 && transaction.Type != "debit" {
+			return 0, false
+		}
+		amount := math.Abs(transaction.Amount)
+		return amount, amount > rule.Threshold
+	case models.AlertTransactionCategory:
+		return transaction.Amount, strings.EqualFold(transaction.Category, rule.Category)
+	}
+	return 0, false
+}
+
+// CreditUtilization returns the percentage of the credit limit in use
+func CreditUtilization(account models.Account) (float64, bool) {
+	if account.CreditLimit <= 0 {
+		return 0, false
+	}
+
+	owed := math.Max(0, -account.Balance)
+	return owed / account.CreditLimit * 100, true
+}
+
+// validateAlertRule checks that a rule request is complete for its type
+func validateAlertRule(request *models.AlertRuleRequest) error {
+	validation := &ValidationError{}
+	if request == nil {
+		validation.Add("body", CodeRequired, "request body is required")
+		return validation
+	}
+
+	switch request.Type {
+	case models.AlertBalanceBelow, models.AlertBalanceAbove:
+	case models.AlertDebitOver:
+		if request.Threshold <= 0 {
+			validation.Add("threshold", CodeOutOfRange, "threshold must be greater than zero")
+		}
+	case models.AlertTransactionCategory:
+		if request.Category == "" {
+			validation.Add("category", CodeRequired, "category is required")
+		}
+	case models.AlertCreditUtilizationAbove:
+		if request.Threshold <= 0 || request.Threshold > 100 {
+			validation.Add("threshold", CodeOutOfRange, "threshold must be a percentage between 0 and 100")
+		}
+	case "":
+		validation.Add("type", CodeRequired, "type is required")
+	default:
+		validation.Addf("type", CodeNotAllowed, "unsupported alert type %q", request.Type)
+	}
+
+	return validation.Err()
+}
+
+// describeAlertRule builds a default name such as "balance below 500.00"
+func describeAlertRule(rule *models.AlertRule) string {
+	switch rule.Type {
+	case models.AlertBalanceBelow:
+		return fmt.Sprintf("balance below %.2f", rule.Threshold)
+	case models.AlertBalanceAbove:
+		return fmt.Sprintf("balance above %.2f", rule.Threshold)
+	case models.AlertDebitOver:
+		return fmt.Sprintf("single debit over %.2f", rule.Threshold)
+	case models.AlertTransactionCategory:
+		return fmt.Sprintf("new transaction in category %s", rule.Category)
+	case models.AlertCreditUtilizationAbove:
+		return fmt.Sprintf("credit utilization above %.0f%%", rule.Threshold)
+	}
+	return rule.Type
+}
+
+// alertValueLabel names the value an account rule measures
+func alertValueLabel(ruleType string) string {
+	if ruleType == models.AlertCreditUtilizationAbove {
+		return "utilization %"
+	}
+	return "balance"
+}
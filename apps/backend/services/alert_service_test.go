@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+func TestAlertService_BalanceRuleDeduplicates(t *testing.T) {
+	service := NewAlertService(nil, nil)
+	if _, err := service.CreateRule(&models.AlertRuleRequest{
+		Type:        models.AlertBalanceBelow,
+		AccountType: "checking",
+		Threshold:   500,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	account := models.Account{ID: "acc_001", Name: "Primary Checking", AccountType: "checking", Balance: 450}
+	service.EvaluateAccount(account)
+	service.EvaluateAccount(account)
+
+	alerts, _ := service.GetAlerts("", "")
+	if len(alerts) != 1 {
+		t.Fatalf("Expected balance alert to fire once, got %v", len(alerts))
+	}
+
+	// Recovering re-arms the rule so the next drop fires again
+	account.Balance = 600
+	service.EvaluateAccount(account)
+	account.Balance = 400
+	service.EvaluateAccount(account)
+
+	alerts, _ = service.GetAlerts("", "acc_001")
+	if len(alerts) != 2 {
+		t.Errorf("Expected alert to fire again after recovery, got %v", len(alerts))
+	}
+
+	// Other account types are out of scope
+	service.EvaluateAccount(models.Account{ID: "acc_002", AccountType: "savings", Balance: 10})
+	alerts, _ = service.GetAlerts("", "acc_002")
+	if len(alerts) != 0 {
+		t.Errorf("Expected savings account to be ignored, got %v alerts", len(alerts))
+	}
+}
+
+func TestAlertService_TransactionRules(t *testing.T) {
+	service := NewAlertService(nil, NewAccountService())
+	debitRule, err := service.CreateRule(&models.AlertRuleRequest{Type: models.AlertDebitOver, Threshold: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	categoryRule, err := service.CreateRule(&models.AlertRuleRequest{Type: models.AlertTransactionCategory, Category: "gambling"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	large := models.Transaction{ID: "txn_100", AccountID: "acc_001", Amount: -1500, Type: "debit", Status: "completed"}
+	service.EvaluateTransaction(large)
+	service.EvaluateTransaction(large)
+	service.EvaluateTransaction(models.Transaction{ID: "txn_101", AccountID: "acc_001", Amount: 2000, Type: "credit"})
+	service.EvaluateTransaction(models.Transaction{ID: "txn_104", AccountID: "acc_001", Amount: -20004.95, Type: models.TransactionBuy, Status: "completed"})
+	service.EvaluateTransaction(models.Transaction{ID: "txn_102", AccountID: "acc_001", Amount: -20, Category: "Gambling"})
+	service.EvaluateTransaction(models.Transaction{ID: "txn_103", AccountID: "acc_001", Amount: -20, Category: "gambling", Status: "failed"})
+
+	if alerts, _ := service.GetAlerts(debitRule.ID, ""); len(alerts) != 1 || alerts[0].TransactionID != "txn_100" {
+		t.Errorf("Expected one debit alert for txn_100, got %v", alerts)
+	}
+	if alerts, _ := service.GetAlerts(categoryRule.ID, ""); len(alerts) != 1 || alerts[0].TransactionID != "txn_102" {
+		t.Errorf("Expected one category alert for txn_102, got %v", alerts)
+	}
+}
+
+func TestAlertService_BoundsFiredTransactions(t *testing.T) {
+	service := NewAlertService(nil, nil)
+	rule, err := service.CreateRule(&models.AlertRuleRequest{Type: models.AlertDebitOver, Threshold: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i <= maxFiredPairs; i++ {
+		service.EvaluateTransaction(models.Transaction{ID: fmt.Sprintf("txn_%d", i), AccountID: "acc_001", Amount: -20, Type: "debit"})
+	}
+	if len(service.fired) != maxFiredPairs || len(service.firedOrder) != maxFiredPairs {
+		t.Errorf("Expected %d remembered pairs, got %d and %d", maxFiredPairs, len(service.fired), len(service.firedOrder))
+	}
+
+	// The oldest pair is forgotten, the newest still fires only once
+	if service.fired[rule.ID+"|txn_0"] || !service.fired[fmt.Sprintf("%s|txn_%d", rule.ID, maxFiredPairs)] {
+		t.Error("Expected only the oldest pair to be forgotten")
+	}
+
+	if err := service.DeleteRule(rule.ID); err != nil {
+		t.Fatal(err)
+	}
+	if len(service.fired) != 0 || len(service.firedOrder) != 0 {
+		t.Errorf("Expected deleting the rule to forget its pairs, got %d and %d", len(service.fired), len(service.firedOrder))
+	}
+}
+
+func TestAlertService_CreditUtilizationOnCreate(t *testing.T) {
+	// acc_003 owes 1200.50 of a 5000 limit, about 24% utilization
+	service := NewAlertService(nil, NewAccountService())
+
+	low, err := service.CreateRule(&models.AlertRuleRequest{Type: models.AlertCreditUtilizationAbove, Threshold: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	high, err := service.CreateRule(&models.AlertRuleRequest{Type: models.AlertCreditUtilizationAbove, Threshold: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if alerts, _ := service.GetAlerts(low.ID, ""); len(alerts) != 1 || alerts[0].AccountID != "acc_003" {
+		t.Errorf("Expected utilization above 20%% to fire for acc_003, got %v", alerts)
+	}
+	if alerts, _ := service.GetAlerts(high.ID, ""); len(alerts) != 0 {
+		t.Errorf("Expected utilization above 30%% not to fire, got %v", alerts)
+	}
+
+	if _, err := service.CreateRule(&models.AlertRuleRequest{Type: models.AlertCreditUtilizationAbove, Threshold: 150}); err == nil {
+		t.Error("Expected utilization threshold above 100 to be rejected")
+	}
+}
+
+func TestAlertService_EvaluatesBusEvents(t *testing.T) {
+	eventBus := NewEventBus(16)
+	transactionService := NewTransactionService()
+	transactionService.SetEventBus(eventBus)
+
+	service := NewAlertService(eventBus, NewAccountService())
+	if _, err := service.CreateRule(&models.AlertRuleRequest{Type: models.AlertTransactionCategory, Category: "gambling"}); err != nil {
+		t.Fatal(err)
+	}
+
+	service.Start()
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = service.Stop(ctx)
+	}()
+
+	_, events, unsubscribe := eventBus.Subscribe(0)
+	defer unsubscribe()
+
+	time.Sleep(20 * time.Millisecond)
+	if _, err := transactionService.CreateTransaction(context.Background(), &models.Transaction{
+		ID: "txn_200", AccountID: "acc_001", Amount: -50, Category: "gambling", Status: "completed",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case event := <-events:
+			if event.Type == models.EventAlertTriggered {
+				return
+			}
+		case <-timeout:
+			t.Fatal("Timed out waiting for alert.triggered event")
+		}
+	}
+}
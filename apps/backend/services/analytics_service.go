@@ -0,0 +1,409 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// maxCashflowDays bounds the period of a cashflow report
+const maxCashflowDays = 5 * 366
+
+// dateLayout formats the dates of a report, matching the YYYY-MM-DD query parameters
+const dateLayout = "2006-01-02"
+
+// AnalyticsService computes reports over transaction data
+type AnalyticsService struct {
+	transactions *TransactionService
+	categories   CategoryKinds
+}
+
+// NewAnalyticsService creates a new AnalyticsService instance
+func NewAnalyticsService(transactions *TransactionService) *AnalyticsService {
+	return &AnalyticsService{
+		transactions: transactions,
+	}
+}
+
+// SetCategoryKinds makes reports treat every category of the transfer kind as a transfer.
+// Without it, only the transfer category is.
+func (s *AnalyticsService) SetCategoryKinds(kinds CategoryKinds) {
+	s.categories = kinds
+}
+
+// Cashflow returns income, spending and net per bucket, with the change since the previous
+// period. Failed and cancelled transactions and trades never count; internal transfers only
+// count when the query includes them. Month and week reports are widened to whole months or weeks.
+func (s *AnalyticsService) Cashflow(query models.CashflowQuery) (*models.CashflowReport, error) {
+	validation := &ValidationError{}
+	switch query.GroupBy {
+	case models.CashflowByCategory, models.CashflowByMonth, models.CashflowByWeek, models.CashflowByAccount:
+	default:
+		validation.Add("group_by", CodeNotAllowed, "group_by must be one of category, month, week or account")
+	}
+	from, to := startOfDay(query.From), startOfDay(query.To)
+	if to.Before(from) {
+		validation.Add("to", CodeOutOfRange, "to must not be before from")
+	} else if to.Sub(from) > maxCashflowDays*24*time.Hour {
+		validation.Addf("to", CodeOutOfRange, "the period must not exceed %d days", maxCashflowDays)
+	}
+	if err := validation.Err(); err != nil {
+		return nil, err
+	}
+
+	// Work on half-open ranges [start, end)
+	start, end := from, to.AddDate(0, 0, 1)
+	timeBuckets := query.GroupBy == models.CashflowByMonth || query.GroupBy == models.CashflowByWeek
+	if timeBuckets {
+		start = bucketStart(start, query.GroupBy)
+		if last := bucketStart(end.AddDate(0, 0, -1), query.GroupBy); last.Before(end) {
+			end = nextBucket(last, query.GroupBy)
+		}
+	}
+	previousStart, previousEnd := previousPeriod(start, end, query.GroupBy)
+
+	all, err := s.transactions.GetAllTransactions(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.CashflowReport{
+		From:             start.Format(dateLayout),
+		To:               end.AddDate(0, 0, -1).Format(dateLayout),
+		PreviousFrom:     previousStart.Format(dateLayout),
+		PreviousTo:       previousEnd.AddDate(0, 0, -1).Format(dateLayout),
+		GroupBy:          query.GroupBy,
+		IncludeTransfers: query.IncludeTransfers,
+		Buckets:          []models.CashflowBucket{},
+	}
+
+	// Totals per bucket key, for the current and the previous period
+	current := make(map[string]*cashflowSums)
+	previous := make(map[string]*cashflowSums)
+	var currentTotal, previousTotal cashflowSums
+
+	for _, transaction := range all {
+		if !countsTowardsCashflow(transaction, query.IncludeTransfers, s.categories) {
+			continue
+		}
+
+		date := transaction.Date.UTC()
+		inCurrent := !date.Before(start) && date.Before(end)
+		inPrevious := !date.Before(previousStart) && date.Before(previousEnd)
+		switch {
+		case inCurrent:
+			currentTotal.add(transaction.Amount)
+		case inPrevious:
+			previousTotal.add(transaction.Amount)
+		default:
+			continue
+		}
+
+		var key string
+		sums := current
+		switch query.GroupBy {
+		case models.CashflowByCategory:
+			key = transaction.Category
+		case models.CashflowByAccount:
+			key = transaction.AccountID
+		default:
+			// The previous period of a time bucket is the bucket before it, keyed by its start
+			key = bucketStart(date, query.GroupBy).Format(dateLayout)
+		}
+		if !inCurrent && !timeBuckets {
+			sums = previous
+		}
+		if sums[key] == nil {
+			sums[key] = &cashflowSums{}
+		}
+		sums[key].add(transaction.Amount)
+	}
+
+	report.Totals = currentTotal.totals()
+	report.Previous = previousTotal.totals()
+	report.Change = cashflowChange(report.Totals, report.Previous)
+
+	if timeBuckets {
+		for bucket := start; bucket.Before(end); bucket = nextBucket(bucket, query.GroupBy) {
+			before := bucket.AddDate(0, 0, -1)
+			totals := current[bucket.Format(dateLayout)].totals()
+			previousTotals := current[bucketStart(before, query.GroupBy).Format(dateLayout)].totals()
+
+			report.Buckets = append(report.Buckets, models.CashflowBucket{
+				Key:      bucketKey(bucket, query.GroupBy),
+				Start:    bucket.Format(dateLayout),
+				End:      nextBucket(bucket, query.GroupBy).AddDate(0, 0, -1).Format(dateLayout),
+				Totals:   totals,
+				Previous: previousTotals,
+				Change:   cashflowChange(totals, previousTotals),
+			})
+		}
+		return report, nil
+	}
+
+	// Keys from either period, so a category that stopped entirely still shows its drop
+	keys := make(map[string]bool)
+	for key := range current {
+		keys[key] = true
+	}
+	for key := range previous {
+		keys[key] = true
+	}
+	for key := range keys {
+		totals := current[key].totals()
+		previousTotals := previous[key].totals()
+		report.Buckets = append(report.Buckets, models.CashflowBucket{
+			Key:      key,
+			Totals:   totals,
+			Previous: previousTotals,
+			Change:   cashflowChange(totals, previousTotals),
+		})
+	}
+
+	// Biggest spending first, then biggest income
+	sort.Slice(report.Buckets, func(i, j int) bool {
+		a, b := report.Buckets[i].Totals, report.Buckets[j].Totals
+		if a.Spending != b.Spending {
+			return a.Spending > b.Spending
+		}
+		if a.Income != b.Income {
+			return a.Income > b.Income
+		}
+		return report.Buckets[i].Key < report.Buckets[j].Key
+	})
+
+	return report, nil
+}
+
+// MerchantSpend returns the spending and income per merchant, biggest spending first. Like
+// cashflow, it leaves out failed and cancelled transactions and internal transfers.
+func (s *AnalyticsService) MerchantSpend(filter models.MerchantFilter) ([]models.MerchantSpend, error) {
+	// Either end of [start, end) may be open
+	var start, end time.Time
+	if filter.From != nil {
+		start = startOfDay(*filter.From)
+	}
+	if filter.To != nil {
+		end = startOfDay(*filter.To).AddDate(0, 0, 1)
+	}
+	if !start.IsZero() && !end.IsZero() && !start.Before(end) {
+		validation := &ValidationError{}
+		validation.Add("to", CodeOutOfRange, "to must not be before from")
+		return nil, validation
+	}
+
+	all, err := s.transactions.GetAllTransactions(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	type merchantSums struct {
+		cashflowSums
+		categories          map[string]int
+		firstSeen, lastSeen time.Time
+	}
+	merchants := make(map[string]*merchantSums)
+	for _, transaction := range all {
+		if transaction.Merchant == "" || !countsTowardsCashflow(transaction, false, s.categories) {
+			continue
+		}
+		if filter.AccountID != "" && transaction.AccountID != filter.AccountID {
+			continue
+		}
+		date := transaction.Date.UTC()
+		if (!start.IsZero() && date.Before(start)) || (!end.IsZero() && !date.Before(end)) {
+			continue
+		}
+
+		sums := merchants[transaction.Merchant]
+		if sums == nil {
+			sums = &merchantSums{categories: make(map[string]int), firstSeen: transaction.Date, lastSeen: transaction.Date}
+			merchants[transaction.Merchant] = sums
+		}
+		sums.add(transaction.Amount)
+		sums.categories[transaction.Category]++
+		if transaction.Date.Before(sums.firstSeen) {
+			sums.firstSeen = transaction.Date
+		}
+		if transaction.Date.After(sums.lastSeen) {
+			sums.lastSeen = transaction.Date
+		}
+	}
+
+	spend := make([]models.MerchantSpend, 0, len(merchants))
+	for merchant, sums := range merchants {
+		totals := sums.totals()
+		spend = append(spend, models.MerchantSpend{
+			Merchant:  merchant,
+			Spending:  totals.Spending,
+			Income:    totals.Income,
+			Count:     totals.Count,
+			Category:  mostFrequent(sums.categories),
+			FirstSeen: sums.firstSeen,
+			LastSeen:  sums.lastSeen,
+		})
+	}
+
+	sort.Slice(spend, func(i, j int) bool {
+		if spend[i].Spending != spend[j].Spending {
+			return spend[i].Spending > spend[j].Spending
+		}
+		if spend[i].Count != spend[j].Count {
+			return spend[i].Count > spend[j].Count
+		}
+		return spend[i].Merchant < spend[j].Merchant
+	})
+	if filter.Limit > 0 && len(spend) > filter.Limit {
+		spend = spend[:filter.Limit]
+	}
+
+	return spend, nil
+}
+
+// mostFrequent returns the key with the highest count, the first alphabetically on a tie
+func mostFrequent(counts map[string]int) string {
+	best := ""
+	for key, count := range counts {
+		if count > counts[best] || (count == counts[best] && key < best) {
+			best = key
+		}
+	}
+	return best
+}
+
+// countsTowardsCashflow reports whether a transaction is part of a cashflow report. Buys and
+// sells only swap cash for securities, so they are neither income nor spending, while
+// dividends and fees always are, whatever their category. Transfers are transactions of the
+// transfer type or in a category of the transfer kind, resolved through kinds; without kinds,
+// only the transfer category is one.
+func countsTowardsCashflow(transaction *models.Transaction, includeTransfers bool, kinds CategoryKinds) bool {
+	if transaction.Status == "failed" || transaction.Status == "cancelled" {
+		return false
+	}
+	switch transaction.Type {
+	case models.TransactionBuy, models.TransactionSell:
+		return false
+	case models.TransactionDividend, models.TransactionFee:
+		return true
+	}
+	if includeTransfers {
+		return true
+	}
+	if transaction.Type == "transfer" {
+		return false
+	}
+	if kinds == nil {
+		return transaction.Category != "transfer"
+	}
+	return kinds.CategoryKind(transaction.Category) != models.CategoryTransfer
+}
+
+// cashflowSums accumulates the income and spending of a bucket
+type cashflowSums struct {
+	income   float64
+	spending float64
+	count    int
+}
+
+// add counts one transaction amount; credits are income and debits spending
+func (s *cashflowSums) add(amount float64) {
+	if amount >= 0 {
+		s.income += amount
+	} else {
+		s.spending -= amount
+	}
+	s.count++
+}
+
+// totals returns the sums rounded to cents; a nil bucket has zero totals
+func (s *cashflowSums) totals() models.CashflowTotals {
+	if s == nil {
+		return models.CashflowTotals{}
+	}
+	return models.CashflowTotals{
+		Income:   roundCents(s.income),
+		Spending: roundCents(s.spending),
+		Net:      roundCents(s.income - s.spending),
+		Count:    s.count,
+	}
+}
+
+// cashflowChange compares current with previous totals
+func cashflowChange(current, previous models.CashflowTotals) models.CashflowChange {
+	return models.CashflowChange{
+		Income:          roundCents(current.Income - previous.Income),
+		Spending:        roundCents(current.Spending - previous.Spending),
+		Net:             roundCents(current.Net - previous.Net),
+		IncomePercent:   percentChange(current.Income, previous.Income),
+		SpendingPercent: percentChange(current.Spending, previous.Spending),
+	}
+}
+
+// percentChange returns the change from previous to current in percent, to one decimal,
+// or nil when there is nothing to compare with
+func percentChange(current, previous float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	percent := math.Round((current-previous)/previous*1000) / 10
+	return &percent
+}
+
+// roundCents rounds an amount to two decimals
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// startOfDay truncates t to midnight UTC of its date
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// bucketStart returns the first day of the month or ISO week (starting Monday) containing t
+func bucketStart(t time.Time, groupBy string) time.Time {
+	day := startOfDay(t)
+	if groupBy == models.CashflowByMonth {
+		return day.AddDate(0, 0, 1-day.Day())
+	}
+	offset := (int(day.Weekday()) + 6) % 7 // days since Monday
+	return day.AddDate(0, 0, -offset)
+}
+
+// nextBucket returns the start of the month or week after the one starting at start
+func nextBucket(start time.Time, groupBy string) time.Time {
+	if groupBy == models.CashflowByMonth {
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 7)
+}
+
+// bucketKey labels a month as 2024-03 and a week as its ISO week, 2024-W09
+func bucketKey(start time.Time, groupBy string) string {
+	if groupBy == models.CashflowByMonth {
+		return start.Format("2006-01")
+	}
+	year, week := start.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", year, week)
+}
+
+// previousPeriod returns the period [start, end) is compared with. Whole months compare with
+// the same number of months before; a month to date, such as March 1-15, with the same days
+// of the previous month; anything else with the same number of days just before start.
+func previousPeriod(start, end time.Time, groupBy string) (time.Time, time.Time) {
+	days := int(end.Sub(start).Hours() / 24)
+	if groupBy != models.CashflowByWeek && start.Day() == 1 {
+		if end.Day() == 1 {
+			months := (end.Year()-start.Year())*12 + int(end.Month()-start.Month())
+			return start.AddDate(0, -months, 0), start
+		}
+		if days < 28 {
+			previousStart := start.AddDate(0, -1, 0)
+			return previousStart, previousStart.AddDate(0, 0, days)
+		}
+	}
+	return start.AddDate(0, 0, -days), start
+}
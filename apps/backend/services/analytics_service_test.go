@@ -0,0 +1,305 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// newCashflowService returns an AnalyticsService over the mock data plus transactions in
+// February and March 2024, well clear of the mock dates
+func newCashflowService(t *testing.T) *AnalyticsService {
+	t.Helper()
+
+	transactions := NewTransactionService()
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2024, month, d, 12, 0, 0, 0, time.UTC)
+	}
+	for _, transaction := range []*models.Transaction{
+		{ID: "cf_1", AccountID: "acc_001", Description: "POS 1234 WHOLEFDS #102", Amount: -20, Type: "debit", Category: "food", Date: day(time.February, 10), Status: "completed"},
+		{ID: "cf_2", AccountID: "acc_001", Description: "ACME CORP PAYROLL", Amount: 1000, Type: "credit", Category: "salary", Date: day(time.February, 15), Status: "completed"},
+		{ID: "cf_3", AccountID: "acc_001", Description: "WHOLEFDS MKT 10233", Amount: -50, Type: "debit", Category: "food", Date: day(time.March, 5), Status: "completed"},
+		{ID: "cf_4", AccountID: "acc_001", Description: "ACME CORP PAYROLL", Amount: 1000, Type: "credit", Category: "salary", Date: day(time.March, 6), Status: "completed"},
+		{ID: "cf_5", AccountID: "acc_002", Description: "Transfer from Savings", Amount: 200, Type: "credit", Category: "transfer", Date: day(time.March, 7), Status: "completed"},
+		{ID: "cf_6", AccountID: "acc_001", Description: "WHOLEFDS", Amount: -999, Type: "debit", Category: "food", Date: day(time.March, 8), Status: "failed"},
+		{ID: "cf_7", AccountID: "acc_002", Description: "AMC 0451 ONLINE", Amount: -30, Type: "debit", Category: "entertainment", Date: day(time.March, 20), Status: "pending"},
+	} {
+		if _, err := transactions.CreateTransaction(context.Background(), transaction); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return NewAnalyticsService(transactions)
+}
+
+func TestAnalyticsService_CashflowByCategory(t *testing.T) {
+	service := newCashflowService(t)
+
+	report, err := service.Cashflow(models.CashflowQuery{
+		From:    time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+		GroupBy: models.CashflowByCategory,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A whole month compares with the month before; failed transactions and transfers are left out
+	if report.PreviousFrom != "2024-02-01" || report.PreviousTo != "2024-02-29" {
+		t.Errorf("Expected February as the previous period, got %s to %s", report.PreviousFrom, report.PreviousTo)
+	}
+	want := models.CashflowTotals{Income: 1000, Spending: 80, Net: 920, Count: 3}
+	if report.Totals != want {
+		t.Errorf("Expected totals %+v, got %+v", want, report.Totals)
+	}
+	if report.Change.Spending != 60 || report.Change.SpendingPercent == nil || *report.Change.SpendingPercent != 300 {
+		t.Errorf("Expected spending up 60 (300%%), got %+v", report.Change)
+	}
+
+	// Biggest spending first
+	var keys []string
+	for _, bucket := range report.Buckets {
+		keys = append(keys, bucket.Key)
+	}
+	if len(keys) != 3 || keys[0] != "food" || keys[1] != "entertainment" || keys[2] != "salary" {
+		t.Fatalf("Expected food, entertainment, salary; got %v", keys)
+	}
+	if food := report.Buckets[0]; food.Previous.Spending != 20 || food.Change.Spending != 30 {
+		t.Errorf("Expected food spending 20 -> 50, got %+v", food)
+	}
+	if entertainment := report.Buckets[1]; entertainment.Change.SpendingPercent != nil {
+		t.Errorf("Expected no percentage without previous spending, got %v", *entertainment.Change.SpendingPercent)
+	}
+
+	// Transfers count when asked for
+	report, err = service.Cashflow(models.CashflowQuery{
+		From:             time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		To:               time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+		GroupBy:          models.CashflowByAccount,
+		IncludeTransfers: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Totals.Income != 1200 || len(report.Buckets) != 2 {
+		t.Errorf("Expected transfers in income across two accounts, got %+v", report)
+	}
+}
+
+func TestAnalyticsService_TransferCategories(t *testing.T) {
+	service := newCashflowService(t)
+	categories := NewCategoryService(service.transactions)
+	service.SetCategoryKinds(categories)
+	ctx := context.Background()
+
+	// A renamed transfer category and a custom one of the transfer kind are both transfers
+	if _, err := categories.UpdateCategory(ctx, "transfer", &models.CategoryUpdate{ID: strPtr("own_transfers")}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := categories.CreateCategory(&models.CategoryRequest{ID: "sweeps", Name: "Sweeps", Kind: models.CategoryTransfer}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := categories.MergeCategory(ctx, "entertainment", &models.CategoryMerge{Into: "sweeps"}); err != nil {
+		t.Fatal(err)
+	}
+
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+	report, err := service.Cashflow(models.CashflowQuery{From: from, To: to, GroupBy: models.CashflowByCategory})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := models.CashflowTotals{Income: 1000, Spending: 50, Net: 950, Count: 2}
+	if report.Totals != want {
+		t.Errorf("Expected totals %+v, got %+v", want, report.Totals)
+	}
+
+	// The category report and merchant spend leave out the same transactions
+	categoryReport, err := categories.Report(models.CategoryReportQuery{From: from, To: to})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if categoryReport.Totals != want {
+		t.Errorf("Expected category report totals %+v, got %+v", want, categoryReport.Totals)
+	}
+	spend, err := service.MerchantSpend(models.MerchantFilter{From: &from, To: &to})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, merchant := range spend {
+		if merchant.Merchant == "AMC Theatres" {
+			t.Errorf("Expected the transfer to be left out of merchant spend, got %+v", merchant)
+		}
+	}
+
+	// Transfers count when asked for
+	report, err = service.Cashflow(models.CashflowQuery{From: from, To: to, GroupBy: models.CashflowByCategory, IncludeTransfers: true})
+	if err != nil || report.Totals.Income != 1200 || report.Totals.Spending != 80 {
+		t.Errorf("Expected transfers in income and spending, got %+v (%v)", report, err)
+	}
+}
+
+func TestAnalyticsService_DividendsAreIncome(t *testing.T) {
+	service := newCashflowService(t)
+	categories := NewCategoryService(service.transactions)
+	service.SetCategoryKinds(categories)
+
+	// Filed under investment, a transfer category, like the mock dividend
+	dividend := &models.Transaction{ID: "cf_8", AccountID: "acc_004", Description: "VTI DIVIDEND", Amount: 150, Type: models.TransactionDividend, Category: "investment", Symbol: "VTI", Date: time.Date(2024, time.March, 15, 12, 0, 0, 0, time.UTC), Status: "completed"}
+	if _, err := service.transactions.CreateTransaction(context.Background(), dividend); err != nil {
+		t.Fatal(err)
+	}
+
+	from := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+	report, err := service.Cashflow(models.CashflowQuery{From: from, To: to, GroupBy: models.CashflowByCategory})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := models.CashflowTotals{Income: 1150, Spending: 80, Net: 1070, Count: 4}
+	if report.Totals != want {
+		t.Errorf("Expected totals %+v, got %+v", want, report.Totals)
+	}
+
+	categoryReport, err := categories.Report(models.CategoryReportQuery{From: from, To: to})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if categoryReport.Totals != want {
+		t.Errorf("Expected category report totals %+v, got %+v", want, categoryReport.Totals)
+	}
+
+	spend, err := service.MerchantSpend(models.MerchantFilter{AccountID: "acc_004", From: &from, To: &to})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(spend) != 1 || spend[0].Income != 150 {
+		t.Errorf("Expected the dividend as merchant income, got %+v", spend)
+	}
+}
+
+func TestAnalyticsService_CashflowPreviousPeriod(t *testing.T) {
+	service := newCashflowService(t)
+
+	// A month to date compares with the same days of the month before
+	report, err := service.Cashflow(models.CashflowQuery{
+		From:    time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC),
+		GroupBy: models.CashflowByCategory,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.PreviousFrom != "2024-02-01" || report.PreviousTo != "2024-02-10" {
+		t.Errorf("Expected February 1-10 as the previous period, got %s to %s", report.PreviousFrom, report.PreviousTo)
+	}
+	if want := (models.CashflowTotals{Spending: 20, Net: -20, Count: 1}); report.Previous != want {
+		t.Errorf("Expected previous totals %+v, got %+v", want, report.Previous)
+	}
+
+	// Any other period compares with the same number of days just before it
+	report, err = service.Cashflow(models.CashflowQuery{
+		From:    time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2024, time.March, 20, 0, 0, 0, 0, time.UTC),
+		GroupBy: models.CashflowByCategory,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.PreviousFrom != "2024-02-18" || report.PreviousTo != "2024-03-04" {
+		t.Errorf("Expected February 18 to March 4 as the previous period, got %s to %s", report.PreviousFrom, report.PreviousTo)
+	}
+}
+
+func TestAnalyticsService_CashflowByTime(t *testing.T) {
+	service := newCashflowService(t)
+
+	// Partial months are widened, and each month compares with the one before
+	report, err := service.Cashflow(models.CashflowQuery{
+		From:    time.Date(2024, time.February, 15, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC),
+		GroupBy: models.CashflowByMonth,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.From != "2024-02-01" || report.To != "2024-03-31" || len(report.Buckets) != 2 {
+		t.Fatalf("Expected February and March, got %s to %s with %d buckets", report.From, report.To, len(report.Buckets))
+	}
+	february, march := report.Buckets[0], report.Buckets[1]
+	if february.Key != "2024-02" || february.Start != "2024-02-01" || february.End != "2024-02-29" {
+		t.Errorf("Unexpected February bucket %+v", february)
+	}
+	if march.Previous != february.Totals || march.Change.Spending != 60 {
+		t.Errorf("Expected March to compare with February, got %+v", march)
+	}
+
+	// Weeks start on Monday and are labelled with their ISO week
+	report, err = service.Cashflow(models.CashflowQuery{
+		From:    time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC),
+		GroupBy: models.CashflowByWeek,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Buckets) != 1 || report.Buckets[0].Key != "2024-W10" || report.Buckets[0].Start != "2024-03-04" {
+		t.Fatalf("Expected week 2024-W10 starting March 4, got %+v", report.Buckets)
+	}
+	if totals := report.Buckets[0].Totals; totals.Income != 1000 || totals.Spending != 50 {
+		t.Errorf("Unexpected week totals %+v", totals)
+	}
+}
+
+func TestAnalyticsService_CashflowValidation(t *testing.T) {
+	service := newCashflowService(t)
+
+	_, err := service.Cashflow(models.CashflowQuery{
+		From:    time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		GroupBy: "day",
+	})
+	var validation *ValidationError
+	if !errors.As(err, &validation) || len(validation.Fields) != 2 {
+		t.Fatalf("Expected group_by and to to be rejected, got %v", err)
+	}
+}
+
+func TestAnalyticsService_MerchantSpend(t *testing.T) {
+	service := newCashflowService(t)
+
+	from := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+	spend, err := service.MerchantSpend(models.MerchantFilter{From: &from, To: &to})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The failed purchase and the transfer are left out; biggest spending first
+	if len(spend) != 3 {
+		t.Fatalf("Expected three merchants, got %+v", spend)
+	}
+	wholeFoods := spend[0]
+	if wholeFoods.Merchant != "Whole Foods Market" || wholeFoods.Spending != 70 || wholeFoods.Count != 2 || wholeFoods.Category != "food" {
+		t.Errorf("Unexpected Whole Foods spend %+v", wholeFoods)
+	}
+	if !wholeFoods.FirstSeen.Equal(time.Date(2024, time.February, 10, 12, 0, 0, 0, time.UTC)) || !wholeFoods.LastSeen.Equal(time.Date(2024, time.March, 5, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected Whole Foods dates %v to %v", wholeFoods.FirstSeen, wholeFoods.LastSeen)
+	}
+	if spend[1].Merchant != "AMC Theatres" || spend[2].Merchant != "Acme Corp Payroll" || spend[2].Income != 2000 {
+		t.Errorf("Expected AMC Theatres then Acme Corp Payroll, got %+v", spend[1:])
+	}
+
+	// Filters narrow the account and the number of merchants
+	spend, err = service.MerchantSpend(models.MerchantFilter{AccountID: "acc_002", From: &from, To: &to, Limit: 1})
+	if err != nil || len(spend) != 1 || spend[0].Merchant != "AMC Theatres" {
+		t.Errorf("Expected only AMC Theatres, got %+v (%v)", spend, err)
+	}
+
+	if _, err := service.MerchantSpend(models.MerchantFilter{From: &to, To: &from}); err == nil {
+		t.Error("Expected an error when to is before from")
+	}
+}
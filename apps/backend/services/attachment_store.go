@@ -0,0 +1,277 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// errAttachmentMissing is returned by a store for a key it does not hold
+var errAttachmentMissing = errors.New("attachment content not found")
+
+// AttachmentStore keeps the content of transaction attachments. Keys are slash-separated
+// paths such as "txn_001/att_001".
+type AttachmentStore interface {
+	Put(ctx context.Context, key, contentType string, content []byte) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// AttachmentConfig limits what can be attached to a transaction
+type AttachmentConfig struct {
+	MaxBytes          int64    // largest file accepted
+	MaxPerTransaction int      // attachments a transaction can have
+	AllowedTypes      []string // content types accepted, as detected from the content
+}
+
+// DefaultAttachmentConfig returns the limits used when nothing is overridden
+func DefaultAttachmentConfig() AttachmentConfig {
+	return AttachmentConfig{
+		MaxBytes:          10 << 20,
+		MaxPerTransaction: 10,
+		AllowedTypes:      []string{"image/jpeg", "image/png", "image/gif", "image/webp", "application/pdf"},
+	}
+}
+
+// LocalAttachmentStore keeps attachments as files under a directory
+type LocalAttachmentStore struct {
+	dir string
+}
+
+// NewLocalAttachmentStore creates a new LocalAttachmentStore instance
+func NewLocalAttachmentStore(dir string) *LocalAttachmentStore {
+	return &LocalAttachmentStore{
+		dir: dir,
+	}
+}
+
+// Put writes content to a temporary file and renames it into place, so readers never see a
+// partial file
+func (s *LocalAttachmentStore) Put(ctx context.Context, key, contentType string, content []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// Get opens the file of key
+func (s *LocalAttachmentStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, errAttachmentMissing
+	}
+	return file, err
+}
+
+// Delete removes the file of key; a missing file is not an error
+func (s *LocalAttachmentStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// path maps key to a file under the store directory, refusing keys that would escape it
+func (s *LocalAttachmentStore) path(key string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid attachment key %q", key)
+	}
+	return filepath.Join(s.dir, cleaned), nil
+}
+
+// S3Config locates a bucket on Amazon S3 or an S3-compatible server such as MinIO
+type S3Config struct {
+	Endpoint        string // such as https://s3.us-east-1.amazonaws.com or http://localhost:9000
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// S3AttachmentStore keeps attachments as objects in an S3 bucket, addressed path-style so
+// any S3-compatible server can stand in
+type S3AttachmentStore struct {
+	config S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewS3AttachmentStore creates a new S3AttachmentStore instance
+func NewS3AttachmentStore(config S3Config) *S3AttachmentStore {
+	return &S3AttachmentStore{
+		config: config,
+		client: &http.Client{Timeout: 30 * time.Second},
+		now:    time.Now,
+	}
+}
+
+// Put uploads content as the object key
+func (s *S3AttachmentStore) Put(ctx context.Context, key, contentType string, content []byte) error {
+	response, err := s.do(ctx, http.MethodPut, key, contentType, content)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return s3Error(response)
+	}
+	return nil
+}
+
+// Get downloads the object key
+func (s *S3AttachmentStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	response, err := s.do(ctx, http.MethodGet, key, "", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return response.Body, nil
+	case http.StatusNotFound:
+		response.Body.Close()
+		return nil, errAttachmentMissing
+	default:
+		defer response.Body.Close()
+		return nil, s3Error(response)
+	}
+}
+
+// Delete removes the object key; S3 reports success for a missing object too
+func (s *S3AttachmentStore) Delete(ctx context.Context, key string) error {
+	response, err := s.do(ctx, http.MethodDelete, key, "", nil)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNotFound {
+		return s3Error(response)
+	}
+	return nil
+}
+
+// do sends a request for the object key, signed with AWS Signature Version 4
+func (s *S3AttachmentStore) do(ctx context.Context, method, key, contentType string, body []byte) (*http.Response, error) {
+	endpoint, err := url.Parse(strings.TrimSuffix(s.config.Endpoint, "/"))
+	if err != nil {
+		return nil, err
+	}
+	endpoint.Path += "/" + s.config.Bucket + "/" + key
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, body)
+
+	return s.client.Do(req)
+}
+
+// sign adds the AWS Signature Version 4 headers for the s3 service to req
+func (s *S3AttachmentStore) sign(req *http.Request, body []byte) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signed := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	if req.Header.Get("Content-Type") != "" {
+		signed = append([]string{"content-type"}, signed...)
+	}
+	var canonicalHeaders strings.Builder
+	for _, name := range signed {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.URL.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	signedHeaders := strings.Join(signed, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + s.config.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), day)
+	key = hmacSHA256(key, s.config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.config.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// s3Error describes a failed S3 response, including the start of its error document
+func s3Error(response *http.Response) error {
+	detail, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+	return fmt.Errorf("attachment store returned %s: %s", response.Status, strings.TrimSpace(string(detail)))
+}
+
+// sha256Hex returns the hex-encoded SHA-256 of data
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data under key
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
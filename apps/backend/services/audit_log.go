@@ -0,0 +1,327 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// auditContextKey is the context key for the actor and request ID of a change
+type auditContextKey struct{}
+
+// auditInfo identifies who made a change
+type auditInfo struct {
+	actor     string
+	requestID string
+}
+
+// systemActor is recorded when a change has no caller in its context
+const systemActor = "system"
+
+// WithAuditActor returns a context that attributes changes to actor and requestID
+func WithAuditActor(ctx context.Context, actor, requestID string) context.Context {
+	return context.WithValue(ctx, auditContextKey{}, auditInfo{actor: actor, requestID: requestID})
+}
+
+// auditInfoFromContext returns the actor and request ID stored in ctx
+func auditInfoFromContext(ctx context.Context) auditInfo {
+	if ctx != nil {
+		if info, ok := ctx.Value(auditContextKey{}).(auditInfo); ok && info.actor != "" {
+			return info
+		}
+	}
+	return auditInfo{actor: systemActor}
+}
+
+// AuditStore persists audit entries
+type AuditStore interface {
+	Load() ([]models.AuditEntry, error)
+	Append(entry models.AuditEntry) error
+}
+
+// FileAuditStore appends audit entries as JSON lines to a file
+type FileAuditStore struct {
+	path string
+	file *os.File
+}
+
+// NewFileAuditStore opens (or creates) an append-only audit file
+func NewFileAuditStore(path string) (*FileAuditStore, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600) // #nosec G304 -- path comes from configuration
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileAuditStore{path: path, file: file}, nil
+}
+
+// Load reads every entry from the file
+func (f *FileAuditStore) Load() ([]models.AuditEntry, error) {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []models.AuditEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var entry models.AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("corrupt audit entry after sequence %d: %w", len(entries), err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, scanner.Err()
+}
+
+// Append writes an entry and syncs it to disk
+func (f *FileAuditStore) Append(entry models.AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return f.file.Sync()
+}
+
+// Close closes the underlying file
+func (f *FileAuditStore) Close() error {
+	return f.file.Close()
+}
+
+// AuditLog records every state change with a hash chain for tamper evidence
+type AuditLog struct {
+	entries []models.AuditEntry
+	store   AuditStore
+	mutex   sync.RWMutex
+}
+
+// NewAuditLog creates an AuditLog backed by store; a nil store keeps entries in memory only.
+// Entries are loaded even when their chain is broken, so Verify keeps reporting where.
+func NewAuditLog(store AuditStore) (*AuditLog, error) {
+	auditLog := &AuditLog{store: store}
+
+	if store != nil {
+		entries, err := store.Load()
+		if err != nil {
+			return nil, err
+		}
+		auditLog.entries = entries
+	}
+
+	return auditLog, nil
+}
+
+// Record appends an entry describing the change from before to after.
+// Either side may be nil for creations and deletions.
+func (l *AuditLog) Record(ctx context.Context, action, entityType, entityID string, before, after interface{}) (*models.AuditEntry, error) {
+	changes, err := diffEntities(before, after)
+	if err != nil {
+		return nil, err
+	}
+
+	info := auditInfoFromContext(ctx)
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	entry := models.AuditEntry{
+		Sequence:   uint64(len(l.entries)) + 1,
+		Timestamp:  time.Now().UTC(),
+		Actor:      info.actor,
+		RequestID:  info.requestID,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Changes:    changes,
+	}
+	if len(l.entries) > 0 {
+		entry.PrevHash = l.entries[len(l.entries)-1].Hash
+	}
+
+	entry.Hash, err = hashAuditEntry(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	if l.store != nil {
+		if err := l.store.Append(entry); err != nil {
+			return nil, err
+		}
+	}
+	l.entries = append(l.entries, entry)
+
+	return &entry, nil
+}
+
+// GetEntries returns entries matching filter, newest first
+func (l *AuditLog) GetEntries(filter *models.AuditFilter) ([]models.AuditEntry, int) {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	matched := make([]models.AuditEntry, 0)
+	for i := len(l.entries) - 1; i >= 0; i-- {
+		entry := l.entries[i]
+		if filter != nil {
+			if filter.EntityType != "" && entry.EntityType != filter.EntityType {
+				continue
+			}
+			if filter.EntityID != "" && entry.EntityID != filter.EntityID {
+				continue
+			}
+			if filter.Action != "" && entry.Action != filter.Action {
+				continue
+			}
+			if filter.Actor != "" && entry.Actor != filter.Actor {
+				continue
+			}
+			if filter.RequestID != "" && entry.RequestID != filter.RequestID {
+				continue
+			}
+		}
+		matched = append(matched, entry)
+	}
+
+	total := len(matched)
+	offset, limit := 0, 50
+	if filter != nil {
+		offset = filter.Offset
+		if filter.Limit > 0 {
+			limit = filter.Limit
+		}
+	}
+
+	if offset >= total {
+		return []models.AuditEntry{}, total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+
+	return matched[offset:end], total
+}
+
+// Verify recomputes the hash chain and reports the first broken link
+func (l *AuditLog) Verify() models.AuditVerification {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	verification := models.AuditVerification{Valid: true, Entries: len(l.entries)}
+	prevHash := ""
+	for i, entry := range l.entries {
+		reason := ""
+		expected, err := hashAuditEntry(entry)
+		switch {
+		case entry.Sequence != uint64(i)+1:
+			reason = "sequence is out of order"
+		case entry.PrevHash != prevHash:
+			reason = "previous hash does not match"
+		case err != nil || entry.Hash != expected:
+			reason = "entry hash does not match its contents"
+		}
+
+		if reason != "" {
+			verification.Valid = false
+			verification.FirstInvalid = entry.Sequence
+			verification.FailureReason = reason
+			return verification
+		}
+		prevHash = entry.Hash
+	}
+
+	verification.LastHash = prevHash
+	return verification
+}
+
+// hashAuditEntry hashes an entry's JSON form with the Hash field cleared
+func hashAuditEntry(entry models.AuditEntry) (string, error) {
+	entry.Hash = ""
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// diffEntities returns the fields whose JSON values differ between before and after
+func diffEntities(before, after interface{}) (map[string]models.FieldChange, error) {
+	beforeFields, err := entityFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := entityFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]bool)
+	for key := range beforeFields {
+		keys[key] = true
+	}
+	for key := range afterFields {
+		keys[key] = true
+	}
+
+	changes := make(map[string]models.FieldChange)
+	for key := range keys {
+		if !reflect.DeepEqual(beforeFields[key], afterFields[key]) {
+			changes[key] = models.FieldChange{Before: beforeFields[key], After: afterFields[key]}
+		}
+	}
+
+	return changes, nil
+}
+
+// entityFields converts an entity to its JSON field map so stored values round-trip exactly
+func entityFields(entity interface{}) (map[string]interface{}, error) {
+	if entity == nil || (reflect.ValueOf(entity).Kind() == reflect.Ptr && reflect.ValueOf(entity).IsNil()) {
+		return map[string]interface{}{}, nil
+	}
+
+	data, err := json.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, errors.New("audited entities must encode as JSON objects")
+	}
+
+	return fields, nil
+}
+
+// recordAudit records a change when an audit log is configured
+func recordAudit(ctx context.Context, auditLog *AuditLog, action, entityType, entityID string, before, after interface{}) {
+	if auditLog == nil {
+		return
+	}
+
+	if _, err := auditLog.Record(ctx, action, entityType, entityID, before, after); err != nil {
+		log.Printf("[audit] failed to record %s of %s: %v", action, entityID, err)
+	}
+}
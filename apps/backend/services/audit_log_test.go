@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"financial-aggregator-api/backend/models"
+)
+
+func TestAuditLog_RecordsRefreshWithActorAndDiff(t *testing.T) {
+	auditLog, err := NewAuditLog(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accountService := NewAccountService()
+	accountService.SetAuditLog(auditLog)
+
+	ctx := WithAuditActor(context.Background(), "api:127.0.0.1", "req-42")
+	if _, err := accountService.RefreshAccount(ctx, "acc_001"); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, total := auditLog.GetEntries(&models.AuditFilter{EntityID: "acc_001"})
+	if total != 1 {
+		t.Fatalf("Expected 1 audit entry, got %v", total)
+	}
+
+	entry := entries[0]
+	if entry.Actor != "api:127.0.0.1" || entry.RequestID != "req-42" {
+		t.Errorf("Expected actor and request ID from context, got %v / %v", entry.Actor, entry.RequestID)
+	}
+	if entry.Action != models.AuditAccountRefreshed || entry.EntityType != "account" {
+		t.Errorf("Expected account refresh entry, got %v on %v", entry.Action, entry.EntityType)
+	}
+	if _, ok := entry.Changes["last_updated"]; !ok {
+		t.Errorf("Expected last_updated in changes, got %v", entry.Changes)
+	}
+	if _, ok := entry.Changes["name"]; ok {
+		t.Error("Expected unchanged fields to be left out of the diff")
+	}
+
+	// Changes without a caller are attributed to the system
+	transactionService := NewTransactionService()
+	transactionService.SetAuditLog(auditLog)
+	if _, err := transactionService.CreateTransaction(context.Background(), &models.Transaction{ID: "txn_new", AccountID: "acc_001"}); err != nil {
+		t.Fatal(err)
+	}
+	entries, _ = auditLog.GetEntries(&models.AuditFilter{EntityType: "transaction"})
+	if len(entries) != 1 || entries[0].Actor != systemActor {
+		t.Errorf("Expected one system transaction entry, got %v", entries)
+	}
+}
+
+func TestAuditLog_PersistsAndDetectsTampering(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	store, err := NewFileAuditStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auditLog, err := NewAuditLog(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, balance := range []float64{100, 200, 300} {
+		before := models.Account{ID: "acc_001", Balance: balance - 100}
+		after := models.Account{ID: "acc_001", Balance: balance}
+		if _, err := auditLog.Record(context.Background(), models.AuditAccountRefreshed, "account", "acc_001", before, after); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store.Close()
+
+	// Reopening continues the chain
+	store, err = NewFileAuditStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := NewAuditLog(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verification := reopened.Verify(); !verification.Valid || verification.Entries != 3 {
+		t.Errorf("Expected intact chain of 3 entries, got %+v", verification)
+	}
+	entry, err := reopened.Record(context.Background(), models.AuditAccountRefreshed, "account", "acc_001", nil, models.Account{ID: "acc_001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Sequence != 4 {
+		t.Errorf("Expected sequence to continue at 4, got %v", entry.Sequence)
+	}
+	store.Close()
+
+	// Editing a past balance breaks the chain
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := strings.Replace(string(data), `"after":200`, `"after":999`, 1)
+	if err := os.WriteFile(path, []byte(tampered), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err = NewFileAuditStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	tamperedLog, err := NewAuditLog(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verification := tamperedLog.Verify(); verification.Valid || verification.FirstInvalid != 2 {
+		t.Errorf("Expected tampering at sequence 2 to be detected, got %+v", verification)
+	}
+}
@@ -0,0 +1,613 @@
+package services
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// Limits on the category tree
+const (
+	maxCategoryDepth      = 3
+	maxCategoryIDLength   = 40
+	maxCategoryNameLength = 60
+	maxCategoryIconLength = 16
+)
+
+// uncategorized collects transactions whose category is not in the tree in reports
+const uncategorized = "uncategorized"
+
+// categorySlug is the format of category IDs, such as "groceries" or "public_transit"
+var categorySlug = regexp.MustCompile(`^[a-z0-9]+(?:[_-][a-z0-9]+)*$`)
+
+// CategoryChecker reports whether a category exists, keeping the tree unchanged while the
+// caller acts on the answer
+type CategoryChecker interface {
+	WithCategory(id string, apply func(exists bool) error) error
+}
+
+// CategoryKinds resolves the kind of a category, or "" when it is not in the tree
+type CategoryKinds interface {
+	CategoryKind(id string) string
+}
+
+// categorySnapshot is a copy of the tree taken for a report
+type categorySnapshot map[string]*models.Category
+
+// WithCategory calls apply with whether id is in the tree, which no rename, merge or delete
+// changes until apply returns. Transactions move into a category this way, so one cannot
+// land in a category removed since it was checked. apply must not call back into the
+// service.
+func (s *CategoryService) WithCategory(id string, apply func(exists bool) error) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	_, exists := s.categories[id]
+	return apply(exists)
+}
+
+// CategoryKind returns the kind of category id
+func (c categorySnapshot) CategoryKind(id string) string {
+	if category, exists := c[id]; exists {
+		return category.Kind
+	}
+	return ""
+}
+
+// defaultCategories is the taxonomy every service starts with. It covers the categories of
+// the mock data here and of the serverless mock data in api/.
+var defaultCategories = []models.Category{
+	{ID: "income", Name: "Income", Kind: models.CategoryIncome, Icon: "💰"},
+	{ID: "salary", Name: "Salary", ParentID: "income", Kind: models.CategoryIncome, Icon: "💼"},
+	{ID: "business", Name: "Business income", ParentID: "income", Kind: models.CategoryIncome, Icon: "🤝"},
+	{ID: "interest", Name: "Interest", ParentID: "income", Kind: models.CategoryIncome, Icon: "🏦"},
+	{ID: "dividends", Name: "Dividends", ParentID: "income", Kind: models.CategoryIncome, Icon: "📊"},
+	{ID: "refunds", Name: "Refunds", ParentID: "income", Kind: models.CategoryIncome, Icon: "↩️"},
+
+	{ID: "food", Name: "Food & dining", Kind: models.CategoryExpense, Icon: "🍽️"},
+	{ID: "groceries", Name: "Groceries", ParentID: "food", Kind: models.CategoryExpense, Icon: "🛒"},
+	{ID: "restaurants", Name: "Restaurants", ParentID: "food", Kind: models.CategoryExpense, Icon: "🍔"},
+	{ID: "housing", Name: "Housing", Kind: models.CategoryExpense, Icon: "🏠"},
+	{ID: "rent", Name: "Rent & mortgage", ParentID: "housing", Kind: models.CategoryExpense, Icon: "🔑"},
+	{ID: "utilities", Name: "Utilities", ParentID: "housing", Kind: models.CategoryExpense, Icon: "💡"},
+	{ID: "transportation", Name: "Transportation", Kind: models.CategoryExpense, Icon: "🚗"},
+	{ID: "fuel", Name: "Fuel", ParentID: "transportation", Kind: models.CategoryExpense, Icon: "⛽"},
+	{ID: "public_transit", Name: "Public transit", ParentID: "transportation", Kind: models.CategoryExpense, Icon: "🚆"},
+	{ID: "shopping", Name: "Shopping", Kind: models.CategoryExpense, Icon: "🛍️"},
+	{ID: "entertainment", Name: "Entertainment", Kind: models.CategoryExpense, Icon: "🎬"},
+	{ID: "healthcare", Name: "Healthcare", Kind: models.CategoryExpense, Icon: "🩺"},
+	{ID: "fees", Name: "Fees & charges", Kind: models.CategoryExpense, Icon: "🧾"},
+	{ID: uncategorized, Name: "Uncategorized", Kind: models.CategoryExpense, Icon: "❔"},
+
+	{ID: "transfer", Name: "Transfers", Kind: models.CategoryTransfer, Icon: "🔁"},
+	{ID: "investment", Name: "Investments", Kind: models.CategoryTransfer, Icon: "📈"},
+}
+
+// CategoryService manages the category tree and keeps transactions in step with renames
+// and merges
+type CategoryService struct {
+	categories   map[string]*models.Category
+	transactions *TransactionService
+	mutex        sync.RWMutex
+}
+
+// NewCategoryService creates a new CategoryService instance
+func NewCategoryService(transactions *TransactionService) *CategoryService {
+	service := &CategoryService{
+		categories:   make(map[string]*models.Category, len(defaultCategories)),
+		transactions: transactions,
+	}
+	for i := range defaultCategories {
+		category := defaultCategories[i]
+		service.categories[category.ID] = &category
+	}
+	return service
+}
+
+// HasCategory reports whether id is in the tree
+func (s *CategoryService) HasCategory(id string) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	_, exists := s.categories[id]
+	return exists
+}
+
+// CategoryKind returns the kind of category id, or "" when it is not in the tree
+func (s *CategoryService) CategoryKind(id string) string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return categorySnapshot(s.categories).CategoryKind(id)
+}
+
+// GetAllCategories returns the tree depth first: income, expense and transfer categories in
+// that order, each followed by its children, siblings by name
+func (s *CategoryService) GetAllCategories() []models.Category {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	children := s.children()
+	result := make([]models.Category, 0, len(s.categories))
+	var visit func(id string)
+	visit = func(id string) {
+		for _, child := range children[id] {
+			result = append(result, *s.categories[child])
+			visit(child)
+		}
+	}
+	visit("")
+	return result
+}
+
+// GetCategoryByID returns a category
+func (s *CategoryService) GetCategoryByID(id string) (*models.Category, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	category, exists := s.categories[id]
+	if !exists {
+		return nil, ErrCategoryNotFound
+	}
+	copied := *category
+	return &copied, nil
+}
+
+// CreateCategory adds a custom category. A child takes the kind of its parent.
+func (s *CategoryService) CreateCategory(request *models.CategoryRequest) (*models.Category, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	id := strings.TrimSpace(request.ID)
+	if id == "" {
+		id = slugify(request.Name)
+	}
+	name := strings.TrimSpace(request.Name)
+	icon := strings.TrimSpace(request.Icon)
+	parentID := strings.TrimSpace(request.ParentID)
+
+	validation := &ValidationError{}
+	validateCategoryID(validation, id)
+	validateCategoryName(validation, name)
+	validateCategoryIcon(validation, icon)
+
+	kind := request.Kind
+	if parentID != "" {
+		parent, exists := s.categories[parentID]
+		switch {
+		case !exists:
+			validation.Addf("parent_id", CodeInvalidFormat, "category %s not found", parentID)
+		case s.depth(parentID)+1 > maxCategoryDepth:
+			validation.Addf("parent_id", CodeOutOfRange, "categories can be nested at most %d levels deep", maxCategoryDepth)
+		case kind != "" && kind != parent.Kind:
+			validation.Addf("kind", CodeNotAllowed, "kind must be %s, the kind of %s", parent.Kind, parentID)
+		default:
+			kind = parent.Kind
+		}
+	} else {
+		validateCategoryKind(validation, kind)
+	}
+	if err := validation.Err(); err != nil {
+		return nil, err
+	}
+	if _, exists := s.categories[id]; exists {
+		return nil, ErrCategoryExists
+	}
+
+	category := &models.Category{
+		ID:       id,
+		Name:     name,
+		ParentID: parentID,
+		Kind:     kind,
+		Icon:     icon,
+		Custom:   true,
+	}
+	s.categories[id] = category
+
+	copied := *category
+	return &copied, nil
+}
+
+// UpdateCategory changes a category. A new ID renames it, moving its children and every
+// transaction in it; moving it under another parent gives its subtree the parent's kind.
+func (s *CategoryService) UpdateCategory(ctx context.Context, id string, update *models.CategoryUpdate) (*models.Category, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	current, exists := s.categories[id]
+	if !exists {
+		return nil, ErrCategoryNotFound
+	}
+
+	// Change a copy and swap it in, so copies handed out earlier never change underneath readers
+	updated := *current
+	validation := &ValidationError{}
+	newID := id
+	if update.ID != nil {
+		newID = strings.TrimSpace(*update.ID)
+		validateCategoryID(validation, newID)
+	}
+	if update.Name != nil {
+		updated.Name = strings.TrimSpace(*update.Name)
+		validateCategoryName(validation, updated.Name)
+	}
+	if update.Icon != nil {
+		updated.Icon = strings.TrimSpace(*update.Icon)
+		validateCategoryIcon(validation, updated.Icon)
+	}
+	if update.ParentID != nil {
+		updated.ParentID = strings.TrimSpace(*update.ParentID)
+		if parentID := updated.ParentID; parentID != "" && parentID != current.ParentID {
+			parent, exists := s.categories[parentID]
+			switch {
+			case !exists:
+				validation.Addf("parent_id", CodeInvalidFormat, "category %s not found", parentID)
+			case parentID == id || s.isDescendant(parentID, id):
+				validation.Add("parent_id", CodeNotAllowed, "a category cannot move under itself or one of its children")
+			case s.depth(parentID)+s.height(id) > maxCategoryDepth:
+				validation.Addf("parent_id", CodeOutOfRange, "categories can be nested at most %d levels deep", maxCategoryDepth)
+			default:
+				updated.Kind = parent.Kind
+			}
+		}
+	}
+	if update.Kind != nil && *update.Kind != updated.Kind {
+		if updated.ParentID != "" {
+			validation.Add("kind", CodeNotAllowed, "only top-level categories have their own kind; children take their parent's")
+		} else {
+			validateCategoryKind(validation, *update.Kind)
+			updated.Kind = *update.Kind
+		}
+	}
+	if err := validation.Err(); err != nil {
+		return nil, err
+	}
+	if _, exists := s.categories[newID]; exists && newID != id {
+		return nil, ErrCategoryExists
+	}
+
+	if newID != id {
+		delete(s.categories, id)
+		updated.ID = newID
+		for _, child := range s.children()[id] {
+			moved := *s.categories[child]
+			moved.ParentID = newID
+			s.categories[child] = &moved
+		}
+	}
+	s.categories[newID] = &updated
+	s.setKind(newID, updated.Kind)
+
+	if newID != id {
+		s.transactions.ReplaceCategory(ctx, id, newID)
+	}
+
+	copied := *s.categories[newID]
+	return &copied, nil
+}
+
+// MergeCategory moves every transaction and child of a category into another and removes
+// it. Moved children take the kind of the category they join.
+func (s *CategoryService) MergeCategory(ctx context.Context, id string, merge *models.CategoryMerge) (*models.CategoryMergeResult, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.categories[id]; !exists {
+		return nil, ErrCategoryNotFound
+	}
+
+	into := strings.TrimSpace(merge.Into)
+	children := s.children()[id]
+	validation := &ValidationError{}
+	target, exists := s.categories[into]
+	switch {
+	case into == "":
+		validation.Add("into", CodeRequired, "into is required")
+	case into == id:
+		validation.Add("into", CodeNotAllowed, "a category cannot be merged into itself")
+	case !exists:
+		validation.Addf("into", CodeInvalidFormat, "category %s not found", into)
+	case s.isDescendant(into, id):
+		validation.Add("into", CodeNotAllowed, "a category cannot be merged into one of its children")
+	default:
+		for _, child := range children {
+			if s.depth(into)+s.height(child) > maxCategoryDepth {
+				validation.Addf("into", CodeOutOfRange, "the children of %s would be nested more than %d levels deep", id, maxCategoryDepth)
+				break
+			}
+		}
+	}
+	if err := validation.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, child := range children {
+		moved := *s.categories[child]
+		moved.ParentID = into
+		s.categories[child] = &moved
+		s.setKind(child, target.Kind)
+	}
+	delete(s.categories, id)
+
+	return &models.CategoryMergeResult{
+		Category:            *target,
+		TransactionsUpdated: s.transactions.ReplaceCategory(ctx, id, into),
+		ChildrenMoved:       len(children),
+	}, nil
+}
+
+// DeleteCategory removes a category that has no children and no transactions. Categories in
+// use are merged instead.
+func (s *CategoryService) DeleteCategory(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.categories[id]; !exists {
+		return ErrCategoryNotFound
+	}
+	if len(s.children()[id]) > 0 {
+		return ErrCategoryInUse
+	}
+	transactions, err := s.transactions.GetAllTransactions(nil)
+	if err != nil {
+		return err
+	}
+	for _, transaction := range transactions {
+		if transaction.Category == id {
+			return ErrCategoryInUse
+		}
+	}
+
+	delete(s.categories, id)
+	return nil
+}
+
+// Report returns income and spending per category over a period, rolling children up into
+// their parents. Transactions in categories that are not in the tree count as uncategorized.
+// The same transactions count as in a cashflow report, so categories of the transfer kind
+// only count when the query includes transfers.
+func (s *CategoryService) Report(query models.CategoryReportQuery) (*models.CategoryReport, error) {
+	validation := &ValidationError{}
+	from, to := startOfDay(query.From), startOfDay(query.To)
+	if to.Before(from) {
+		validation.Add("to", CodeOutOfRange, "to must not be before from")
+	} else if to.Sub(from) > maxCashflowDays*24*time.Hour {
+		validation.Addf("to", CodeOutOfRange, "the period must not exceed %d days", maxCashflowDays)
+	}
+	if err := validation.Err(); err != nil {
+		return nil, err
+	}
+	start, end := from, to.AddDate(0, 0, 1)
+
+	s.mutex.RLock()
+	categories := make(categorySnapshot, len(s.categories)+1)
+	for id, category := range s.categories {
+		categories[id] = category
+	}
+	s.mutex.RUnlock()
+	if categories[uncategorized] == nil {
+		categories[uncategorized] = &models.Category{ID: uncategorized, Name: "Uncategorized", Kind: models.CategoryExpense}
+	}
+
+	all, err := s.transactions.GetAllTransactions(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	own := make(map[string]*cashflowSums)
+	var total cashflowSums
+	for _, transaction := range all {
+		if query.AccountID != "" && transaction.AccountID != query.AccountID {
+			continue
+		}
+		if !countsTowardsCashflow(transaction, query.IncludeTransfers, categories) {
+			continue
+		}
+		if date := transaction.Date.UTC(); date.Before(start) || !date.Before(end) {
+			continue
+		}
+
+		category := categories[transaction.Category]
+		if category == nil {
+			category = categories[uncategorized]
+		}
+
+		if own[category.ID] == nil {
+			own[category.ID] = &cashflowSums{}
+		}
+		own[category.ID].add(transaction.Amount)
+		total.add(transaction.Amount)
+	}
+
+	children := make(map[string][]string)
+	for id, category := range categories {
+		children[category.ParentID] = append(children[category.ParentID], id)
+	}
+
+	report := &models.CategoryReport{
+		From:             start.Format(dateLayout),
+		To:               to.Format(dateLayout),
+		AccountID:        query.AccountID,
+		IncludeTransfers: query.IncludeTransfers,
+		Totals:           total.totals(),
+	}
+	report.Categories = categoryTotals(children[""], categories, children, own)
+	return report, nil
+}
+
+// categoryTotals rolls up the activity of ids and their descendants, leaving out categories
+// without any. The busiest categories come first.
+func categoryTotals(ids []string, categories map[string]*models.Category, children map[string][]string, own map[string]*cashflowSums) []models.CategoryTotal {
+	result := []models.CategoryTotal{}
+	for _, id := range ids {
+		category := categories[id]
+		sums := cashflowSums{}
+		if own[id] != nil {
+			sums = *own[id]
+		}
+
+		nested := categoryTotals(children[id], categories, children, own)
+		for _, child := range nested {
+			sums.income += child.Totals.Income
+			sums.spending += child.Totals.Spending
+			sums.count += child.Totals.Count
+		}
+		if sums.count == 0 {
+			continue
+		}
+
+		total := models.CategoryTotal{
+			ID:     id,
+			Name:   category.Name,
+			Kind:   category.Kind,
+			Icon:   category.Icon,
+			Own:    own[id].totals(),
+			Totals: sums.totals(),
+		}
+		if len(nested) > 0 {
+			total.Children = nested
+		}
+		result = append(result, total)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := math.Abs(result[i].Totals.Net), math.Abs(result[j].Totals.Net)
+		if a != b {
+			return a > b
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// children returns the IDs of the children of every category, keyed by parent ID with ""
+// for the top level. Top-level categories are ordered by kind, and siblings by name. The
+// caller must hold the lock.
+func (s *CategoryService) children() map[string][]string {
+	children := make(map[string][]string)
+	for id, category := range s.categories {
+		children[category.ParentID] = append(children[category.ParentID], id)
+	}
+
+	kindOrder := map[string]int{models.CategoryIncome: 0, models.CategoryExpense: 1, models.CategoryTransfer: 2}
+	for _, ids := range children {
+		sort.Slice(ids, func(i, j int) bool {
+			a, b := s.categories[ids[i]], s.categories[ids[j]]
+			if a.Kind != b.Kind {
+				return kindOrder[a.Kind] < kindOrder[b.Kind]
+			}
+			if nameA, nameB := strings.ToLower(a.Name), strings.ToLower(b.Name); nameA != nameB {
+				return nameA < nameB
+			}
+			return a.ID < b.ID
+		})
+	}
+	return children
+}
+
+// depth returns the level of a category, 1 at the top. The caller must hold the lock.
+func (s *CategoryService) depth(id string) int {
+	depth := 0
+	for category := s.categories[id]; category != nil; category = s.categories[category.ParentID] {
+		depth++
+	}
+	return depth
+}
+
+// height returns the number of levels of a category and its descendants. The caller must
+// hold the lock.
+func (s *CategoryService) height(id string) int {
+	height := 1
+	for _, child := range s.children()[id] {
+		if h := s.height(child) + 1; h > height {
+			height = h
+		}
+	}
+	return height
+}
+
+// isDescendant reports whether id is below ancestor in the tree. The caller must hold the lock.
+func (s *CategoryService) isDescendant(id, ancestor string) bool {
+	for category := s.categories[id]; category != nil && category.ParentID != ""; category = s.categories[category.ParentID] {
+		if category.ParentID == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// setKind gives a category and its descendants kind. The caller must hold the write lock.
+func (s *CategoryService) setKind(id, kind string) {
+	if category := s.categories[id]; category.Kind != kind {
+		changed := *category
+		changed.Kind = kind
+		s.categories[id] = &changed
+	}
+	for _, child := range s.children()[id] {
+		s.setKind(child, kind)
+	}
+}
+
+// validateCategoryID checks the format of a category ID
+func validateCategoryID(validation *ValidationError, id string) {
+	switch {
+	case id == "":
+		validation.Add("id", CodeRequired, "id is required")
+	case len(id) > maxCategoryIDLength:
+		validation.Addf("id", CodeOutOfRange, "id must be at most %d characters", maxCategoryIDLength)
+	case !categorySlug.MatchString(id):
+		validation.Add("id", CodeInvalidFormat, "id must be lowercase letters and digits, separated by _ or -")
+	}
+}
+
+// validateCategoryName checks a category's display name
+func validateCategoryName(validation *ValidationError, name string) {
+	if name == "" {
+		validation.Add("name", CodeRequired, "name is required")
+	} else if len([]rune(name)) > maxCategoryNameLength {
+		validation.Addf("name", CodeOutOfRange, "name must be at most %d characters", maxCategoryNameLength)
+	}
+}
+
+// validateCategoryIcon checks a category's icon, typically an emoji
+func validateCategoryIcon(validation *ValidationError, icon string) {
+	if len([]rune(icon)) > maxCategoryIconLength {
+		validation.Addf("icon", CodeOutOfRange, "icon must be at most %d characters", maxCategoryIconLength)
+	}
+}
+
+// validateCategoryKind checks the kind of a top-level category
+func validateCategoryKind(validation *ValidationError, kind string) {
+	switch kind {
+	case models.CategoryIncome, models.CategoryExpense, models.CategoryTransfer:
+	case "":
+		validation.Add("kind", CodeRequired, "kind is required for a top-level category")
+	default:
+		validation.Add("kind", CodeNotAllowed, "kind must be one of income, expense or transfer")
+	}
+}
+
+// slugify derives a category ID from a name, such as "coffee_shops" from "Coffee shops"
+func slugify(name string) string {
+	var slug strings.Builder
+	separator := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			if separator && slug.Len() > 0 {
+				slug.WriteByte('_')
+			}
+			slug.WriteRune(r)
+			separator = false
+		default:
+			separator = true
+		}
+	}
+	return slug.String()
+}
@@ -0,0 +1,271 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+func TestCategoryService_DefaultTaxonomy(t *testing.T) {
+	transactions := NewTransactionService()
+	service := NewCategoryService(transactions)
+
+	// Every category of the mock transactions is part of the tree
+	all, err := transactions.GetAllTransactions(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, transaction := range all {
+		if !service.HasCategory(transaction.Category) {
+			t.Errorf("Category %s of %s is not in the default taxonomy", transaction.Category, transaction.ID)
+		}
+	}
+
+	// Depth first, with parents before their children
+	categories := service.GetAllCategories()
+	seen := make(map[string]bool)
+	for _, category := range categories {
+		if category.ParentID != "" && !seen[category.ParentID] {
+			t.Errorf("Category %s comes before its parent %s", category.ID, category.ParentID)
+		}
+		seen[category.ID] = true
+	}
+	if len(categories) != len(defaultCategories) || categories[0].ID != "income" || categories[1].ID != "business" {
+		t.Errorf("Unexpected order %+v", categories[:2])
+	}
+}
+
+func TestCategoryService_CreateCategory(t *testing.T) {
+	service := NewCategoryService(NewTransactionService())
+
+	category, err := service.CreateCategory(&models.CategoryRequest{Name: "Coffee shops", ParentID: "restaurants", Icon: "☕"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if category.ID != "coffee_shops" || category.Kind != models.CategoryExpense || !category.Custom {
+		t.Errorf("Unexpected category %+v", category)
+	}
+
+	if _, err := service.CreateCategory(&models.CategoryRequest{Name: "Coffee shops", ParentID: "food"}); !errors.Is(err, ErrCategoryExists) {
+		t.Errorf("Expected category_exists, got %v", err)
+	}
+
+	var validation *ValidationError
+	for name, request := range map[string]models.CategoryRequest{
+		"too deep":         {Name: "Espresso bars", ParentID: "coffee_shops"},
+		"missing kind":     {Name: "Pets"},
+		"unknown kind":     {Name: "Pets", Kind: "other"},
+		"unknown parent":   {Name: "Pets", ParentID: "animals"},
+		"conflicting kind": {Name: "Tips", ParentID: "food", Kind: models.CategoryIncome},
+		"invalid id":       {ID: "Pets!", Name: "Pets", Kind: models.CategoryExpense},
+		"no name":          {Name: "  ", Kind: models.CategoryExpense},
+	} {
+		if _, err := service.CreateCategory(&request); !errors.As(err, &validation) {
+			t.Errorf("%s: expected a validation error, got %v", name, err)
+		}
+	}
+}
+
+func TestCategoryService_RenameAndMove(t *testing.T) {
+	transactions := NewTransactionService()
+	service := NewCategoryService(transactions)
+	transactions.SetCategoryChecker(service)
+	ctx := context.Background()
+
+	// A rename moves the children and the transactions, each at a new version
+	newID, name := "home", "Home"
+	category, err := service.UpdateCategory(ctx, "housing", &models.CategoryUpdate{ID: &newID, Name: &name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if category.ID != "home" || service.HasCategory("housing") {
+		t.Errorf("Expected housing renamed to home, got %+v", category)
+	}
+	if utilities, _ := service.GetCategoryByID("utilities"); utilities.ParentID != "home" {
+		t.Errorf("Expected utilities under home, got %+v", utilities)
+	}
+
+	renamed := "bills"
+	if _, err := service.UpdateCategory(ctx, "utilities", &models.CategoryUpdate{ID: &renamed}); err != nil {
+		t.Fatal(err)
+	}
+	moved, _ := transactions.GetTransactionByID("txn_003")
+	if moved.Category != "bills" || moved.Version != 2 {
+		t.Errorf("Expected txn_003 in bills at version 2, got %+v", moved)
+	}
+
+	// Moving a subtree under another parent gives it the parent's kind
+	parent := "income"
+	if _, err := service.UpdateCategory(ctx, "home", &models.CategoryUpdate{ParentID: &parent}); err != nil {
+		t.Fatal(err)
+	}
+	if bills, _ := service.GetCategoryByID("bills"); bills.Kind != models.CategoryIncome {
+		t.Errorf("Expected the subtree to take the income kind, got %+v", bills)
+	}
+
+	// Cycles, depth and kind changes below the top level are refused
+	var validation *ValidationError
+	for name, update := range map[string]models.CategoryUpdate{
+		"under itself":  {ParentID: strPtr("bills")},
+		"too deep":      {ParentID: strPtr("salary")},
+		"child kind":    {Kind: strPtr(models.CategoryExpense)},
+		"taken id":      {ID: strPtr("bad id")},
+		"unknown kind ": {ParentID: strPtr(""), Kind: strPtr("other")},
+	} {
+		if _, err := service.UpdateCategory(ctx, "home", &update); !errors.As(err, &validation) {
+			t.Errorf("%s: expected a validation error, got %v", name, err)
+		}
+	}
+	if _, err := service.UpdateCategory(ctx, "home", &models.CategoryUpdate{ID: strPtr("food")}); !errors.Is(err, ErrCategoryExists) {
+		t.Errorf("Expected category_exists, got %v", err)
+	}
+	if _, err := service.UpdateCategory(ctx, "missing", &models.CategoryUpdate{}); !errors.Is(err, ErrCategoryNotFound) {
+		t.Errorf("Expected category_not_found, got %v", err)
+	}
+
+	// Transactions can only move into categories of the tree
+	read, _ := transactions.GetTransactionByID("txn_001")
+	if _, err := transactions.UpdateTransaction(ctx, "txn_001", &models.TransactionUpdate{Version: read.Version, Category: strPtr("housing")}); !errors.As(err, &validation) {
+		t.Errorf("Expected the old category to be refused, got %v", err)
+	}
+}
+
+func TestCategoryService_MergeAndDelete(t *testing.T) {
+	transactions := NewTransactionService()
+	service := NewCategoryService(transactions)
+	ctx := context.Background()
+
+	if err := service.DeleteCategory("food"); !errors.Is(err, ErrCategoryInUse) {
+		t.Errorf("Expected category_in_use for a category with children, got %v", err)
+	}
+	if err := service.DeleteCategory("healthcare"); !errors.Is(err, ErrCategoryInUse) {
+		t.Errorf("Expected category_in_use for a category with transactions, got %v", err)
+	}
+
+	var validation *ValidationError
+	for _, into := range []string{"", "transportation", "fuel", "missing"} {
+		if _, err := service.MergeCategory(ctx, "transportation", &models.CategoryMerge{Into: into}); !errors.As(err, &validation) {
+			t.Errorf("Merging into %q: expected a validation error, got %v", into, err)
+		}
+	}
+
+	result, err := service.MergeCategory(ctx, "transportation", &models.CategoryMerge{Into: "shopping"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Category.ID != "shopping" || result.TransactionsUpdated != 1 || result.ChildrenMoved != 2 {
+		t.Errorf("Unexpected merge result %+v", result)
+	}
+	if fuel, _ := service.GetCategoryByID("fuel"); fuel.ParentID != "shopping" {
+		t.Errorf("Expected fuel under shopping, got %+v", fuel)
+	}
+	if moved, _ := transactions.GetTransactionByID("txn_005"); moved.Category != "shopping" {
+		t.Errorf("Expected txn_005 in shopping, got %+v", moved)
+	}
+	if _, err := service.GetCategoryByID("transportation"); !errors.Is(err, ErrCategoryNotFound) {
+		t.Errorf("Expected transportation removed, got %v", err)
+	}
+
+	// Merging into the transfer kind moves the children out of expenses
+	if _, err := service.MergeCategory(ctx, "shopping", &models.CategoryMerge{Into: "transfer"}); err != nil {
+		t.Fatal(err)
+	}
+	if fuel, _ := service.GetCategoryByID("fuel"); fuel.Kind != models.CategoryTransfer {
+		t.Errorf("Expected fuel to take the transfer kind, got %+v", fuel)
+	}
+
+	if err := service.DeleteCategory("fuel"); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.DeleteCategory("fuel"); !errors.Is(err, ErrCategoryNotFound) {
+		t.Errorf("Expected category_not_found, got %v", err)
+	}
+}
+
+func TestCategoryService_DeleteWhileMoving(t *testing.T) {
+	ctx := context.Background()
+
+	// Run with -race: a transaction never lands in a category deleted since it was checked
+	for i := 0; i < 50; i++ {
+		transactions := NewTransactionService()
+		service := NewCategoryService(transactions)
+		transactions.SetCategoryChecker(service)
+		if _, err := service.CreateCategory(&models.CategoryRequest{Name: "Pets", Kind: models.CategoryExpense}); err != nil {
+			t.Fatal(err)
+		}
+
+		var deleted error
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			deleted = service.DeleteCategory("pets")
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = transactions.UpdateTransaction(ctx, "txn_001", &models.TransactionUpdate{Version: 1, Category: strPtr("pets")})
+		}()
+		wg.Wait()
+
+		if moved, _ := transactions.GetTransactionByID("txn_001"); deleted == nil && moved.Category == "pets" {
+			t.Fatalf("Expected txn_001 not to be left in the deleted category, got %+v", moved)
+		}
+	}
+}
+
+func TestCategoryService_Report(t *testing.T) {
+	transactions := NewTransactionService()
+	service := NewCategoryService(transactions)
+	today := time.Now().UTC()
+
+	report, err := service.Report(models.CategoryReportQuery{From: today.AddDate(0, 0, -10), To: today})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Salary, business income and dividends roll up into income; transfers and investments are
+	// left out
+	totals := make(map[string]models.CategoryTotal)
+	for _, category := range report.Categories {
+		totals[category.ID] = category
+	}
+	income := totals["income"]
+	if income.Totals.Income != 7650 || income.Own.Count != 0 || len(income.Children) != 3 || income.Children[0].ID != "salary" {
+		t.Errorf("Unexpected income %+v", income)
+	}
+	if housing := totals["housing"]; housing.Totals.Spending != 120 || housing.Children[0].ID != "utilities" {
+		t.Errorf("Unexpected housing %+v", housing)
+	}
+	if _, ok := totals["investment"]; ok {
+		t.Errorf("Expected the transfer kind to be left out, got %+v", report.Categories)
+	}
+	if report.Categories[0].ID != "income" || report.Totals.Income != 7650 || report.Totals.Spending != 470.5 {
+		t.Errorf("Unexpected report %+v", report)
+	}
+
+	// Categories outside the tree count as uncategorized
+	transactions.ReplaceCategory(context.Background(), "healthcare", "medical")
+	report, _ = service.Report(models.CategoryReportQuery{From: today.AddDate(0, 0, -10), To: today, AccountID: "acc_001", IncludeTransfers: true})
+	var other *models.CategoryTotal
+	for i := range report.Categories {
+		if report.Categories[i].ID == uncategorized {
+			other = &report.Categories[i]
+		}
+	}
+	if other == nil || other.Totals.Spending != 200 || report.AccountID != "acc_001" {
+		t.Errorf("Expected the healthcare spending as uncategorized, got %+v", report.Categories)
+	}
+
+	var validation *ValidationError
+	if _, err := service.Report(models.CategoryReportQuery{From: today, To: today.AddDate(0, 0, -1)}); !errors.As(err, &validation) {
+		t.Errorf("Expected a validation error, got %v", err)
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"errors"
+)
+
+// Error kinds. Every error returned by a service wraps one of these, so callers
+// can branch with errors.Is without matching on message text.
+var (
+	ErrNotFound            = errors.New("not found")
+	ErrConflict            = errors.New("conflict")
+	ErrValidation          = errors.New("validation failed")
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+	ErrRateLimited         = errors.New("rate limited")
+)
+
+// Error is a domain error with a stable machine-readable code
+type Error struct {
+	Kind    error  // one of the Err* kinds above
+	Code    string // stable identifier such as account_not_found
+	Message string
+	Err     error // underlying cause, if any
+}
+
+// newError creates a domain error of the given kind
+func newError(kind error, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// Error returns the message, followed by the cause when there is one
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+// Unwrap exposes both the kind and the cause to errors.Is and errors.As
+func (e *Error) Unwrap() []error {
+	if e.Err != nil {
+		return []error{e.Kind, e.Err}
+	}
+	return []error{e.Kind}
+}
+
+// Is matches errors of the same code, so wrapped copies of a sentinel still match it
+func (e *Error) Is(target error) bool {
+	other, ok := target.(*Error)
+	return ok && other.Code == e.Code
+}
+
+// Wrap returns a copy of e with cause attached
+func (e *Error) Wrap(cause error) *Error {
+	wrapped := *e
+	wrapped.Err = cause
+	return &wrapped
+}
+
+// Domain errors returned by the services
+var (
+	ErrAccountNotFound            = newError(ErrNotFound, "account_not_found", "account not found")
+	ErrTransactionNotFound        = newError(ErrNotFound, "transaction_not_found", "transaction not found")
+	ErrTransactionExists          = newError(ErrConflict, "transaction_exists", "transaction already exists")
+	ErrWebhookNotFound            = newError(ErrNotFound, "webhook_not_found", "webhook not found")
+	ErrAlertRuleNotFound          = newError(ErrNotFound, "alert_rule_not_found", "alert rule not found")
+	ErrScheduledItemNotFound      = newError(ErrNotFound, "scheduled_item_not_found", "scheduled item not found")
+	ErrGoalNotFound               = newError(ErrNotFound, "goal_not_found", "goal not found")
+	ErrAttachmentNotFound         = newError(ErrNotFound, "attachment_not_found", "attachment not found")
+	ErrAttachmentStoreUnavailable = newError(ErrUpstreamUnavailable, "attachment_store_unavailable", "attachment store is unavailable")
+	ErrCategoryNotFound           = newError(ErrNotFound, "category_not_found", "category not found")
+	ErrCategoryExists             = newError(ErrConflict, "category_exists", "category already exists")
+	ErrCategoryInUse              = newError(ErrConflict, "category_in_use", "category still has transactions or children")
+	ErrAccountProviderUnavailable = newError(ErrUpstreamUnavailable, "provider_unavailable", "account provider is unavailable")
+	ErrVersionConflict            = newError(ErrConflict, "version_conflict", "resource has changed since the given version")
+	ErrNotInvestmentAccount       = newError(ErrValidation, "not_investment_account", "account is not an investment account")
+	ErrNotLiabilityAccount        = newError(ErrValidation, "not_liability_account", "account is not a credit or loan account")
+	ErrNotLoanAccount             = newError(ErrValidation, "not_loan_account", "account is not a loan account")
+	ErrNotReconcilableAccount     = newError(ErrValidation, "not_reconcilable_account", "investment accounts are valued from their holdings and cannot be reconciled")
+)
+
+// ErrorCode returns the stable code for err, or "" if it is not a domain error
+func ErrorCode(err error) string {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Code
+	}
+
+	var validation *ValidationError
+	if errors.As(err, &validation) {
+		return "validation_failed"
+	}
+
+	return ""
+}
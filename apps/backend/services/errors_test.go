@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"financial-aggregator-api/backend/models"
+)
+
+func TestError_MatchesKindAndSentinel(t *testing.T) {
+	err := ErrAccountProviderUnavailable.Wrap(context.DeadlineExceeded)
+
+	if !errors.Is(err, ErrAccountProviderUnavailable) {
+		t.Error("Expected wrapped error to match its sentinel")
+	}
+	if !errors.Is(err, ErrUpstreamUnavailable) {
+		t.Error("Expected wrapped error to match its kind")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("Expected wrapped error to match its cause")
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Error("Expected wrapped error not to match another kind")
+	}
+	if ErrorCode(err) != "provider_unavailable" {
+		t.Errorf("Expected provider_unavailable code, got %q", ErrorCode(err))
+	}
+}
+
+func TestServiceErrors_AreTyped(t *testing.T) {
+	accountService := NewAccountService()
+	transactionService := NewTransactionService()
+
+	_, err := accountService.GetAccountByID("missing")
+	if !errors.Is(err, ErrNotFound) || ErrorCode(err) != "account_not_found" {
+		t.Errorf("Expected account_not_found, got %v", err)
+	}
+
+	_, err = accountService.RefreshAccount(context.Background(), "missing")
+	if !errors.Is(err, ErrAccountNotFound) {
+		t.Errorf("Expected ErrAccountNotFound from refresh, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = accountService.RefreshAccount(ctx, "acc_001")
+	if !errors.Is(err, ErrUpstreamUnavailable) {
+		t.Errorf("Expected upstream unavailable when the caller gives up, got %v", err)
+	}
+
+	_, err = transactionService.GetTransactionByID("missing")
+	if !errors.Is(err, ErrNotFound) || ErrorCode(err) != "transaction_not_found" {
+		t.Errorf("Expected transaction_not_found, got %v", err)
+	}
+
+	_, err = transactionService.CreateTransaction(context.Background(), &models.Transaction{ID: "txn_001"})
+	if !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected conflict for a duplicate transaction, got %v", err)
+	}
+
+	_, err = NewWebhookService(nil).CreateSubscription(nil)
+	if !errors.Is(err, ErrValidation) || ErrorCode(err) != "validation_failed" {
+		t.Errorf("Expected validation_failed, got %v", err)
+	}
+}
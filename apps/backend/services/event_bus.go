@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// subscriberBufferSize is how many events a subscriber may fall behind before it is dropped
+const subscriberBufferSize = 64
+
+// EventBus fans out change events to subscribers and keeps a bounded replay buffer
+type EventBus struct {
+	nextID      uint64
+	buffer      []models.Event
+	bufferSize  int
+	subscribers map[chan models.Event]struct{}
+	closed      bool
+	mutex       sync.Mutex
+}
+
+// NewEventBus creates a new EventBus that retains the last bufferSize events for replay
+func NewEventBus(bufferSize int) *EventBus {
+	if bufferSize <= 0 {
+		bufferSize = 1
+	}
+
+	return &EventBus{
+		bufferSize:  bufferSize,
+		buffer:      make([]models.Event, 0, bufferSize),
+		subscribers: make(map[chan models.Event]struct{}),
+	}
+}
+
+// Publish records an event and delivers it to every subscriber
+func (b *EventBus) Publish(eventType string, data interface{}) models.Event {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.nextID++
+	event := models.Event{
+		ID:        b.nextID,
+		Type:      eventType,
+		Data:      data,
+		Timestamp: time.Now(),
+	}
+
+	if len(b.buffer) == b.bufferSize {
+		b.buffer = append(b.buffer[:0], b.buffer[1:]...)
+	}
+	b.buffer = append(b.buffer, event)
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// Slow subscriber: drop it so it reconnects and resumes from the replay buffer
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return event
+}
+
+// Subscribe registers a new subscriber. Events newer than lastEventID still held in the
+// replay buffer are returned so the caller can send them before reading from the channel.
+// The returned function must be called to unsubscribe.
+func (b *EventBus) Subscribe(lastEventID uint64) ([]models.Event, <-chan models.Event, func()) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	var replay []models.Event
+	if lastEventID > 0 {
+		for _, event := range b.buffer {
+			if event.ID > lastEventID {
+				replay = append(replay, event)
+			}
+		}
+	}
+
+	ch := make(chan models.Event, subscriberBufferSize)
+	if b.closed {
+		close(ch)
+		return replay, ch, func() {}
+	}
+	b.subscribers[ch] = struct{}{}
+
+	unsubscribe := func() {
+		b.mutex.Lock()
+		defer b.mutex.Unlock()
+
+		if _, exists := b.subscribers[ch]; exists {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return replay, ch, unsubscribe
+}
+
+// Close disconnects every subscriber and rejects new ones, used during shutdown
+func (b *EventBus) Close() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.closed = true
+	for ch := range b.subscribers {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
+
+// Consume calls handle for every event until ctx is cancelled. If the consumer is dropped
+// for falling behind it resubscribes from the last event it handled.
+func (b *EventBus) Consume(ctx context.Context, handle func(models.Event)) {
+	var lastEventID uint64
+	for {
+		replay, events, unsubscribe := b.Subscribe(lastEventID)
+		for _, event := range replay {
+			handle(event)
+			lastEventID = event.ID
+		}
+
+		open := true
+		for open {
+			select {
+			case <-ctx.Done():
+				unsubscribe()
+				return
+			case event, ok := <-events:
+				if !ok {
+					open = false
+					continue
+				}
+				handle(event)
+				lastEventID = event.ID
+			}
+		}
+		unsubscribe()
+
+		// Dropped or bus closed: pause before resubscribing
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// publishEvent publishes on bus when one is configured
+func publishEvent(bus *EventBus, eventType string, data interface{}) {
+	if bus != nil {
+		bus.Publish(eventType, data)
+	}
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"financial-aggregator-api/backend/models"
+)
+
+func TestEventBus_ReplayIsBounded(t *testing.T) {
+	bus := NewEventBus(3)
+	for i := 0; i < 5; i++ {
+		bus.Publish(models.EventAccountUpdated, i)
+	}
+
+	replay, _, unsubscribe := bus.Subscribe(1)
+	defer unsubscribe()
+
+	if len(replay) != 3 {
+		t.Fatalf("Expected 3 buffered events, got %v", len(replay))
+	}
+	if replay[0].ID != 3 || replay[2].ID != 5 {
+		t.Errorf("Expected events 3..5 to be replayed, got %v..%v", replay[0].ID, replay[2].ID)
+	}
+
+	// A fresh subscriber gets no replay
+	replay, _, unsubscribeFresh := bus.Subscribe(0)
+	defer unsubscribeFresh()
+	if len(replay) != 0 {
+		t.Errorf("Expected no replay without Last-Event-ID, got %v events", len(replay))
+	}
+}
+
+func TestEventBus_PublishFromServices(t *testing.T) {
+	bus := NewEventBus(16)
+	accountService := NewAccountService()
+	accountService.SetEventBus(bus)
+	transactionService := NewTransactionService()
+	transactionService.SetEventBus(bus)
+
+	_, events, unsubscribe := bus.Subscribe(0)
+	defer unsubscribe()
+
+	if _, err := accountService.RefreshAccount(context.Background(), "acc_001"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := accountService.RefreshAccount(context.Background(), "invalid_id"); err == nil {
+		t.Fatal("Expected refresh of unknown account to fail")
+	}
+	if _, err := transactionService.CreateTransaction(context.Background(), &models.Transaction{ID: "txn_new", AccountID: "acc_001"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		models.EventAccountUpdated,
+		models.EventRefreshCompleted,
+		models.EventRefreshFailed,
+		models.EventTransactionCreated,
+	}
+	for _, eventType := range expected {
+		event := <-events
+		if event.Type != eventType {
+			t.Errorf("Expected %v event, got %v", eventType, event.Type)
+		}
+	}
+}
+
+func TestEventBus_CloseDisconnectsSubscribers(t *testing.T) {
+	bus := NewEventBus(4)
+	_, events, unsubscribe := bus.Subscribe(0)
+	defer unsubscribe()
+
+	bus.Close()
+
+	if _, open := <-events; open {
+		t.Error("Expected subscriber channel to be closed")
+	}
+
+	_, events, _ = bus.Subscribe(0)
+	if _, open := <-events; open {
+		t.Error("Expected new subscriptions to be closed after Close")
+	}
+}
@@ -0,0 +1,408 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// maxForecastDays caps the forecast horizon
+const maxForecastDays = 365
+
+// minRecurringOccurrences is how often an item must appear before it counts as recurring
+const minRecurringOccurrences = 3
+
+// recurrenceCadences are the intervals an item may repeat at, in days, with how far a single
+// interval may stray; monthly allows for short months and payments moved off weekends
+var recurrenceCadences = []struct {
+	frequency string
+	days      int
+	tolerance int
+}{
+	{models.FrequencyWeekly, 7, 1},
+	{models.FrequencyBiweekly, 14, 2},
+	{models.FrequencyMonthly, 30, 4},
+	{models.FrequencyQuarterly, 91, 7},
+	{models.FrequencyYearly, 365, 10},
+}
+
+// ForecastService projects account balances from recurring items found in transaction
+// history and from items the user schedules
+type ForecastService struct {
+	accounts     AccountReader
+	transactions *TransactionService
+	items        map[string]*models.ScheduledItem
+	nextItemID   int
+	mutex        sync.RWMutex
+}
+
+// NewForecastService creates a new ForecastService instance
+func NewForecastService(accounts AccountReader, transactions *TransactionService) *ForecastService {
+	return &ForecastService{
+		accounts:     accounts,
+		transactions: transactions,
+		items:        make(map[string]*models.ScheduledItem),
+	}
+}
+
+// CreateScheduledItem records a future income or bill
+func (s *ForecastService) CreateScheduledItem(request *models.ScheduledItemRequest) (*models.ScheduledItem, error) {
+	validation := &ValidationError{}
+	if request.AccountID == "" {
+		validation.Add("account_id", CodeRequired, "account_id is required")
+	}
+	if strings.TrimSpace(request.Description) == "" {
+		validation.Add("description", CodeRequired, "description is required")
+	}
+	if request.Amount == 0 {
+		validation.Add("amount", CodeOutOfRange, "amount must not be zero")
+	}
+	switch request.Frequency {
+	case models.FrequencyOnce, models.FrequencyWeekly, models.FrequencyBiweekly, models.FrequencyMonthly, models.FrequencyQuarterly, models.FrequencyYearly:
+	case "":
+		validation.Add("frequency", CodeRequired, "frequency is required")
+	default:
+		validation.Add("frequency", CodeNotAllowed, "frequency must be one of once, weekly, biweekly, monthly, quarterly or yearly")
+	}
+	if request.StartDate.IsZero() {
+		validation.Add("start_date", CodeRequired, "start_date is required")
+	} else if request.EndDate != nil && request.EndDate.Before(request.StartDate) {
+		validation.Add("end_date", CodeOutOfRange, "end_date must not be before start_date")
+	}
+	if err := validation.Err(); err != nil {
+		return nil, err
+	}
+	if _, err := s.accounts.GetAccountByID(request.AccountID); err != nil {
+		return nil, err
+	}
+
+	item := &models.ScheduledItem{
+		AccountID:   request.AccountID,
+		Description: strings.TrimSpace(request.Description),
+		Amount:      roundCents(request.Amount),
+		Frequency:   request.Frequency,
+		StartDate:   startOfDay(request.StartDate),
+		CreatedAt:   time.Now(),
+	}
+	if request.EndDate != nil {
+		endDate := startOfDay(*request.EndDate)
+		item.EndDate = &endDate
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.nextItemID++
+	item.ID = fmt.Sprintf("sch_%03d", s.nextItemID)
+	s.items[item.ID] = item
+
+	created := *item
+	return &created, nil
+}
+
+// GetScheduledItems returns every scheduled item, ordered by ID
+func (s *ForecastService) GetScheduledItems() ([]*models.ScheduledItem, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	items := make([]*models.ScheduledItem, 0, len(s.items))
+	for _, item := range s.items {
+		copied := *item
+		items = append(items, &copied)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+
+	return items, nil
+}
+
+// DeleteScheduledItem removes a scheduled item
+func (s *ForecastService) DeleteScheduledItem(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.items[id]; !exists {
+		return ErrScheduledItemNotFound
+	}
+	delete(s.items, id)
+
+	return nil
+}
+
+// Forecast projects each account's balance at the end of every day from tomorrow through
+// query.Days from today, starting at its current balance. Checking and savings days are
+// flagged below zero, and below query.Threshold when it is set.
+func (s *ForecastService) Forecast(query models.ForecastQuery) (*models.Forecast, error) {
+	validation := &ValidationError{}
+	if query.Days < 1 || query.Days > maxForecastDays {
+		validation.Addf("days", CodeOutOfRange, "days must be between 1 and %d", maxForecastDays)
+	}
+	if query.Threshold < 0 {
+		validation.Add("threshold", CodeOutOfRange, "threshold must not be negative")
+	}
+	if err := validation.Err(); err != nil {
+		return nil, err
+	}
+
+	var accounts []*models.Account
+	if query.AccountID != "" {
+		account, err := s.accounts.GetAccountByID(query.AccountID)
+		if err != nil {
+			return nil, err
+		}
+		accounts = []*models.Account{account}
+	} else {
+		all, err := s.accounts.GetAllAccounts()
+		if err != nil {
+			return nil, err
+		}
+		accounts = all
+	}
+
+	history, err := s.transactions.GetAllTransactions(nil)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.GetScheduledItems()
+	if err != nil {
+		return nil, err
+	}
+
+	today := startOfDay(time.Now())
+	end := today.AddDate(0, 0, query.Days)
+	forecast := &models.Forecast{
+		From:      today.Format(dateLayout),
+		To:        end.Format(dateLayout),
+		Days:      query.Days,
+		Threshold: query.Threshold,
+		Accounts:  []models.AccountForecast{},
+		Recurring: []models.RecurringItem{},
+	}
+
+	// Entries per account and day
+	included := make(map[string]bool, len(accounts))
+	for _, account := range accounts {
+		included[account.ID] = true
+	}
+	entries := make(map[string]map[string][]models.ForecastEntry)
+	addEntry := func(accountID string, date time.Time, entry models.ForecastEntry) {
+		if entries[accountID] == nil {
+			entries[accountID] = make(map[string][]models.ForecastEntry)
+		}
+		key := date.Format(dateLayout)
+		entries[accountID][key] = append(entries[accountID][key], entry)
+	}
+
+	for _, item := range inferRecurring(history, today) {
+		if !included[item.AccountID] {
+			continue
+		}
+		forecast.Recurring = append(forecast.Recurring, item)
+		last, _ := time.Parse(dateLayout, item.LastDate)
+		for n := 1; ; n++ {
+			date := occurrence(last, item.Frequency, n)
+			if date.After(end) {
+				break
+			}
+			if date.After(today) {
+				addEntry(item.AccountID, date, models.ForecastEntry{Description: item.Description, Amount: item.Amount, Source: models.ForecastRecurring})
+			}
+		}
+	}
+
+	for _, item := range items {
+		if !included[item.AccountID] {
+			continue
+		}
+		for n := 0; ; n++ {
+			date := occurrence(item.StartDate, item.Frequency, n)
+			if date.After(end) || (item.EndDate != nil && date.After(*item.EndDate)) {
+				break
+			}
+			if date.After(today) {
+				addEntry(item.AccountID, date, models.ForecastEntry{Description: item.Description, Amount: item.Amount, Source: models.ForecastScheduled, ItemID: item.ID})
+			}
+			if item.Frequency == models.FrequencyOnce {
+				break
+			}
+		}
+	}
+
+	for _, account := range accounts {
+		flagged := account.AccountType == "checking" || account.AccountType == "savings"
+		projection := models.AccountForecast{
+			AccountID:     account.ID,
+			Name:          account.Name,
+			AccountType:   account.AccountType,
+			Currency:      account.Currency,
+			StartBalance:  roundCents(account.Balance),
+			LowestBalance: roundCents(account.Balance),
+			LowestDate:    today.Format(dateLayout),
+			Days:          make([]models.ForecastDay, 0, query.Days),
+		}
+
+		balance := account.Balance
+		for day := 1; day <= query.Days; day++ {
+			date := today.AddDate(0, 0, day).Format(dateLayout)
+			forecastDay := models.ForecastDay{Date: date, Entries: entries[account.ID][date]}
+			for _, entry := range forecastDay.Entries {
+				balance += entry.Amount
+			}
+			forecastDay.Balance = roundCents(balance)
+
+			if flagged && forecastDay.Balance < 0 {
+				forecastDay.Flags = append(forecastDay.Flags, models.ForecastBelowZero)
+				if projection.FirstBelowZero == "" {
+					projection.FirstBelowZero = date
+				}
+			}
+			if flagged && query.Threshold > 0 && forecastDay.Balance < query.Threshold {
+				forecastDay.Flags = append(forecastDay.Flags, models.ForecastBelowThreshold)
+				if projection.FirstBelowThreshold == "" {
+					projection.FirstBelowThreshold = date
+				}
+			}
+			if forecastDay.Balance < projection.LowestBalance {
+				projection.LowestBalance = forecastDay.Balance
+				projection.LowestDate = date
+			}
+			projection.Days = append(projection.Days, forecastDay)
+		}
+		projection.EndBalance = roundCents(balance)
+		forecast.Accounts = append(forecast.Accounts, projection)
+	}
+
+	return forecast, nil
+}
+
+// inferRecurring finds incomes and bills that repeat at a regular interval in history:
+// at least three times, with every gap close to one cadence. Failed and cancelled
+// transactions, buys and sells are ignored, and so are items that have missed two
+// occurrences by today. Items are ordered by account, next date and description.
+func inferRecurring(history []*models.Transaction, today time.Time) []models.RecurringItem {
+	groups := make(map[string][]*models.Transaction)
+	for _, transaction := range history {
+		if transaction.Amount == 0 || !countsTowardsCashflow(transaction, true, nil) {
+			continue
+		}
+		key := fmt.Sprintf("%s|%s|%t", transaction.AccountID, strings.ToLower(recurringName(transaction)), transaction.Amount > 0)
+		groups[key] = append(groups[key], transaction)
+	}
+
+	items := []models.RecurringItem{}
+	for _, transactions := range groups {
+		if len(transactions) < minRecurringOccurrences {
+			continue
+		}
+		sort.Slice(transactions, func(i, j int) bool { return transactions[i].Date.Before(transactions[j].Date) })
+
+		gaps := make([]float64, 0, len(transactions)-1)
+		for i := 1; i < len(transactions); i++ {
+			gaps = append(gaps, math.Round(startOfDay(transactions[i].Date).Sub(startOfDay(transactions[i-1].Date)).Hours()/24))
+		}
+		frequency, ok := recurringFrequency(gaps)
+		if !ok {
+			continue
+		}
+
+		last := startOfDay(transactions[len(transactions)-1].Date)
+		if occurrence(last, frequency, 2).Before(today) {
+			continue
+		}
+		next := occurrence(last, frequency, 1)
+		for n := 2; !next.After(today); n++ {
+			next = occurrence(last, frequency, n)
+		}
+
+		amounts := make([]float64, len(transactions))
+		categories := make(map[string]int)
+		for i, transaction := range transactions {
+			amounts[i] = transaction.Amount
+			categories[transaction.Category]++
+		}
+
+		latest := transactions[len(transactions)-1]
+		items = append(items, models.RecurringItem{
+			AccountID:   latest.AccountID,
+			Description: recurringName(latest),
+			Category:    mostFrequent(categories),
+			Amount:      roundCents(median(amounts)),
+			Frequency:   frequency,
+			Occurrences: len(transactions),
+			LastDate:    last.Format(dateLayout),
+			NextDate:    next.Format(dateLayout),
+		})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].AccountID != items[j].AccountID {
+			return items[i].AccountID < items[j].AccountID
+		}
+		if items[i].NextDate != items[j].NextDate {
+			return items[i].NextDate < items[j].NextDate
+		}
+		return items[i].Description < items[j].Description
+	})
+	return items
+}
+
+// recurringName groups a transaction by its merchant, or its description without one
+func recurringName(transaction *models.Transaction) string {
+	if transaction.Merchant != "" {
+		return transaction.Merchant
+	}
+	return strings.TrimSpace(transaction.Description)
+}
+
+// recurringFrequency returns the cadence that every gap, in days, fits
+func recurringFrequency(gaps []float64) (string, bool) {
+	typical := median(gaps)
+	for _, cadence := range recurrenceCadences {
+		if math.Abs(typical-float64(cadence.days)) > float64(cadence.tolerance) {
+			continue
+		}
+		for _, gap := range gaps {
+			if math.Abs(gap-float64(cadence.days)) > float64(cadence.tolerance) {
+				return "", false
+			}
+		}
+		return cadence.frequency, true
+	}
+	return "", false
+}
+
+// occurrence returns the nth repeat of anchor at frequency; the 0th is anchor itself
+func occurrence(anchor time.Time, frequency string, n int) time.Time {
+	anchor = startOfDay(anchor)
+	switch frequency {
+	case models.FrequencyWeekly:
+		return anchor.AddDate(0, 0, 7*n)
+	case models.FrequencyBiweekly:
+		return anchor.AddDate(0, 0, 14*n)
+	case models.FrequencyMonthly:
+		return addMonths(anchor, n)
+	case models.FrequencyQuarterly:
+		return addMonths(anchor, 3*n)
+	case models.FrequencyYearly:
+		return addMonths(anchor, 12*n)
+	}
+	return anchor
+}
+
+// median returns the middle value, or the mean of the two middle values
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
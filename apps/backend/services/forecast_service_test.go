@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// newForecastService returns a ForecastService over the mock data plus a biweekly paycheck
+// and monthly rent on acc_001, irregular coffee and a gym membership that stopped
+func newForecastService(t *testing.T) (*ForecastService, time.Time) {
+	t.Helper()
+
+	today := startOfDay(time.Now())
+	rent := today.AddDate(0, 0, -10)
+	gym := today.AddDate(0, -5, 0)
+	transactions := NewTransactionService()
+	for _, transaction := range []*models.Transaction{
+		{ID: "fc_1", AccountID: "acc_001", Description: "ACME CORP PAYROLL", Amount: 1200, Type: "credit", Category: "salary", Date: today.AddDate(0, 0, -31), Status: "completed"},
+		{ID: "fc_2", AccountID: "acc_001", Description: "ACME CORP PAYROLL", Amount: 1150, Type: "credit", Category: "salary", Date: today.AddDate(0, 0, -17), Status: "completed"},
+		{ID: "fc_3", AccountID: "acc_001", Description: "ACME CORP PAYROLL", Amount: 1200, Type: "credit", Category: "salary", Date: today.AddDate(0, 0, -3), Status: "completed"},
+		{ID: "fc_4", AccountID: "acc_001", Description: "Rent", Amount: -1800, Type: "debit", Category: "housing", Date: addMonths(rent, -2), Status: "completed"},
+		{ID: "fc_5", AccountID: "acc_001", Description: "Rent", Amount: -1800, Type: "debit", Category: "housing", Date: addMonths(rent, -1), Status: "completed"},
+		{ID: "fc_6", AccountID: "acc_001", Description: "Rent", Amount: -1800, Type: "debit", Category: "housing", Date: rent, Status: "completed"},
+		{ID: "fc_7", AccountID: "acc_001", Description: "Corner Coffee", Amount: -4, Type: "debit", Category: "food", Date: today.AddDate(0, 0, -25), Status: "completed"},
+		{ID: "fc_8", AccountID: "acc_001", Description: "Corner Coffee", Amount: -4, Type: "debit", Category: "food", Date: today.AddDate(0, 0, -22), Status: "completed"},
+		{ID: "fc_9", AccountID: "acc_001", Description: "Corner Coffee", Amount: -4, Type: "debit", Category: "food", Date: today.AddDate(0, 0, -2), Status: "completed"},
+		{ID: "fc_10", AccountID: "acc_003", Description: "Iron Gym", Amount: -40, Type: "debit", Category: "health", Date: addMonths(gym, -2), Status: "completed"},
+		{ID: "fc_11", AccountID: "acc_003", Description: "Iron Gym", Amount: -40, Type: "debit", Category: "health", Date: addMonths(gym, -1), Status: "completed"},
+		{ID: "fc_12", AccountID: "acc_003", Description: "Iron Gym", Amount: -40, Type: "debit", Category: "health", Date: gym, Status: "completed"},
+	} {
+		if _, err := transactions.CreateTransaction(context.Background(), transaction); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return NewForecastService(NewAccountService(), transactions), today
+}
+
+func TestForecastService_Forecast(t *testing.T) {
+	service, today := newForecastService(t)
+	day := func(days int) string { return today.AddDate(0, 0, days).Format(dateLayout) }
+
+	item, err := service.CreateScheduledItem(&models.ScheduledItemRequest{
+		AccountID: "acc_001", Description: "Car repair", Amount: -3000, Frequency: models.FrequencyOnce, StartDate: today.AddDate(0, 0, 5),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	forecast, err := service.Forecast(models.ForecastQuery{Days: 30, Threshold: 3000, AccountID: "acc_001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if forecast.From != day(0) || forecast.To != day(30) || len(forecast.Accounts) != 1 {
+		t.Fatalf("Expected acc_001 from today for 30 days, got %s to %s with %d accounts", forecast.From, forecast.To, len(forecast.Accounts))
+	}
+
+	// The coffee is irregular, so only the paycheck and the rent recur
+	if len(forecast.Recurring) != 2 {
+		t.Fatalf("Expected 2 recurring items, got %+v", forecast.Recurring)
+	}
+	pay, rent := forecast.Recurring[0], forecast.Recurring[1]
+	if pay.Frequency != models.FrequencyBiweekly || pay.Amount != 1200 || pay.Occurrences != 3 || pay.NextDate != day(11) {
+		t.Errorf("Unexpected paycheck %+v", pay)
+	}
+	if rent.Frequency != models.FrequencyMonthly || rent.Amount != -1800 || rent.Category != "housing" {
+		t.Errorf("Unexpected rent %+v", rent)
+	}
+
+	// 2500.75 - 3000 repair + 1200 - 1800 rent + 1200
+	account := forecast.Accounts[0]
+	if len(account.Days) != 30 || account.StartBalance != 2500.75 || account.EndBalance != 100.75 {
+		t.Errorf("Expected 30 days from 2500.75 to 100.75, got %d days from %v to %v", len(account.Days), account.StartBalance, account.EndBalance)
+	}
+	if account.LowestBalance != -1099.25 || account.LowestDate != rent.NextDate {
+		t.Errorf("Expected the low on rent day %s, got %v on %s", rent.NextDate, account.LowestBalance, account.LowestDate)
+	}
+	if account.FirstBelowZero != day(5) || account.FirstBelowThreshold != day(1) {
+		t.Errorf("Expected below zero on %s and below the threshold on %s, got %s and %s", day(5), day(1), account.FirstBelowZero, account.FirstBelowThreshold)
+	}
+	repair := account.Days[4]
+	if len(repair.Entries) != 1 || repair.Entries[0].ItemID != item.ID || repair.Entries[0].Source != models.ForecastScheduled || len(repair.Flags) != 2 {
+		t.Errorf("Unexpected repair day %+v", repair)
+	}
+}
+
+func TestForecastService_FlagsCashAccountsOnly(t *testing.T) {
+	service, _ := newForecastService(t)
+
+	forecast, err := service.Forecast(models.ForecastQuery{Days: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(forecast.Accounts) != 7 {
+		t.Fatalf("Expected every account, got %d", len(forecast.Accounts))
+	}
+	// The card is below zero but is not a cash account; the stopped gym membership is dropped
+	for _, account := range forecast.Accounts {
+		if account.AccountID == "acc_003" && (account.FirstBelowZero != "" || len(account.Days[0].Flags) != 0) {
+			t.Errorf("Expected no flags on the credit card, got %+v", account)
+		}
+	}
+	for _, item := range forecast.Recurring {
+		if item.AccountID == "acc_003" {
+			t.Errorf("Expected the stopped gym membership to be dropped, got %+v", item)
+		}
+	}
+
+	var validation *ValidationError
+	if _, err := service.Forecast(models.ForecastQuery{Days: 400}); !errors.As(err, &validation) {
+		t.Errorf("Expected a validation error for 400 days, got %v", err)
+	}
+	if _, err := service.Forecast(models.ForecastQuery{Days: 7, AccountID: "missing"}); !errors.Is(err, ErrAccountNotFound) {
+		t.Errorf("Expected account_not_found, got %v", err)
+	}
+}
+
+func TestForecastService_ScheduledItems(t *testing.T) {
+	service, today := newForecastService(t)
+
+	var validation *ValidationError
+	_, err := service.CreateScheduledItem(&models.ScheduledItemRequest{AccountID: "acc_001", Frequency: "daily"})
+	if !errors.As(err, &validation) || len(validation.Fields) != 4 {
+		t.Errorf("Expected description, amount, frequency and start_date to be invalid, got %v", err)
+	}
+	if _, err := service.CreateScheduledItem(&models.ScheduledItemRequest{
+		AccountID: "missing", Description: "Bonus", Amount: 500, Frequency: models.FrequencyOnce, StartDate: today,
+	}); !errors.Is(err, ErrAccountNotFound) {
+		t.Errorf("Expected account_not_found, got %v", err)
+	}
+
+	// A weekly allowance for three weeks
+	end := today.AddDate(0, 0, 21)
+	item, err := service.CreateScheduledItem(&models.ScheduledItemRequest{
+		AccountID: "acc_002", Description: " Allowance ", Amount: -25, Frequency: models.FrequencyWeekly, StartDate: today, EndDate: &end,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if item.ID != "sch_001" || item.Description != "Allowance" {
+		t.Errorf("Unexpected item %+v", item)
+	}
+	forecast, _ := service.Forecast(models.ForecastQuery{Days: 60, AccountID: "acc_002"})
+	if balance := forecast.Accounts[0].EndBalance; balance != 14925 {
+		t.Errorf("Expected three allowances after today, got an end balance of %v", balance)
+	}
+
+	if err := service.DeleteScheduledItem(item.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := service.DeleteScheduledItem(item.ID); !errors.Is(err, ErrScheduledItemNotFound) {
+		t.Errorf("Expected scheduled_item_not_found, got %v", err)
+	}
+	if items, _ := service.GetScheduledItems(); len(items) != 0 {
+		t.Errorf("Expected no items, got %+v", items)
+	}
+}
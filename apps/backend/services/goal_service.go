@@ -0,0 +1,379 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// maxGoalSnapshots bounds the daily progress history kept per goal
+const maxGoalSnapshots = 3650
+
+// daysPerMonth is the average length of a month, used for months left
+const daysPerMonth = 365.25 / 12
+
+// GoalService tracks savings goals against the balances of their linked accounts and keeps
+// a daily history of their progress
+type GoalService struct {
+	goals      map[string]*models.Goal
+	history    map[string][]models.GoalSnapshot
+	nextGoalID int
+	mutex      sync.RWMutex
+
+	accounts AccountReader
+	eventBus *EventBus
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewGoalService creates a new GoalService instance
+func NewGoalService(eventBus *EventBus, accounts AccountReader) *GoalService {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &GoalService{
+		goals:    make(map[string]*models.Goal),
+		history:  make(map[string][]models.GoalSnapshot),
+		accounts: accounts,
+		eventBus: eventBus,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// CreateGoal records a goal, starting from the current linked balance
+func (s *GoalService) CreateGoal(request *models.GoalRequest) (*models.Goal, error) {
+	now := time.Now()
+	if err := s.validateGoal(request, startOfDay(now)); err != nil {
+		return nil, err
+	}
+	amount, err := s.linkedBalance(request.AccountIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.nextGoalID++
+	goal := &models.Goal{
+		ID:           fmt.Sprintf("goal_%03d", s.nextGoalID),
+		Name:         strings.TrimSpace(request.Name),
+		TargetAmount: roundCents(request.TargetAmount),
+		Deadline:     startOfDay(request.Deadline),
+		AccountIDs:   append([]string(nil), request.AccountIDs...),
+		StartAmount:  amount,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	s.goals[goal.ID] = goal
+	s.recordSnapshot(goal, amount, now)
+
+	return withProgress(goal, amount, now), nil
+}
+
+// UpdateGoal replaces a goal's name, target, deadline and linked accounts. The start amount
+// and the history are kept.
+func (s *GoalService) UpdateGoal(id string, request *models.GoalRequest) (*models.Goal, error) {
+	s.mutex.RLock()
+	existing, exists := s.goals[id]
+	var createdAt time.Time
+	if exists {
+		createdAt = existing.CreatedAt
+	}
+	s.mutex.RUnlock()
+	if !exists {
+		return nil, ErrGoalNotFound
+	}
+
+	if err := s.validateGoal(request, startOfDay(createdAt)); err != nil {
+		return nil, err
+	}
+	amount, err := s.linkedBalance(request.AccountIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	current, exists := s.goals[id]
+	if !exists {
+		return nil, ErrGoalNotFound
+	}
+	now := time.Now()
+	updated := *current
+	updated.Name = strings.TrimSpace(request.Name)
+	updated.TargetAmount = roundCents(request.TargetAmount)
+	updated.Deadline = startOfDay(request.Deadline)
+	updated.AccountIDs = append([]string(nil), request.AccountIDs...)
+	updated.UpdatedAt = now
+	s.goals[id] = &updated
+	s.recordSnapshot(&updated, amount, now)
+
+	return withProgress(&updated, amount, now), nil
+}
+
+// GetAllGoals returns every goal with its progress, ordered by ID
+func (s *GoalService) GetAllGoals() ([]*models.Goal, error) {
+	s.mutex.RLock()
+	goals := make([]*models.Goal, 0, len(s.goals))
+	for _, goal := range s.goals {
+		goals = append(goals, goal)
+	}
+	s.mutex.RUnlock()
+
+	sort.Slice(goals, func(i, j int) bool { return goals[i].ID < goals[j].ID })
+
+	now := time.Now()
+	result := make([]*models.Goal, 0, len(goals))
+	for _, goal := range goals {
+		amount, err := s.linkedBalance(goal.AccountIDs)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, withProgress(goal, amount, now))
+	}
+	return result, nil
+}
+
+// GetGoalByID returns a goal with its progress
+func (s *GoalService) GetGoalByID(id string) (*models.Goal, error) {
+	s.mutex.RLock()
+	goal, exists := s.goals[id]
+	s.mutex.RUnlock()
+	if !exists {
+		return nil, ErrGoalNotFound
+	}
+
+	amount, err := s.linkedBalance(goal.AccountIDs)
+	if err != nil {
+		return nil, err
+	}
+	return withProgress(goal, amount, time.Now()), nil
+}
+
+// GetGoalHistory returns a goal's linked balance at the end of each day it changed, oldest
+// first
+func (s *GoalService) GetGoalHistory(id string) ([]models.GoalSnapshot, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if _, exists := s.goals[id]; !exists {
+		return nil, ErrGoalNotFound
+	}
+	return append([]models.GoalSnapshot{}, s.history[id]...), nil
+}
+
+// DeleteGoal removes a goal and its history
+func (s *GoalService) DeleteGoal(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.goals[id]; !exists {
+		return ErrGoalNotFound
+	}
+	delete(s.goals, id)
+	delete(s.history, id)
+
+	return nil
+}
+
+// Start subscribes to the event bus and records progress on every account change
+func (s *GoalService) Start() {
+	if s.eventBus == nil {
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.eventBus.Consume(s.ctx, s.handleEvent)
+	}()
+}
+
+// Stop stops recording progress and waits for the consumer or ctx to expire
+func (s *GoalService) Stop(ctx context.Context) error {
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// handleEvent records the progress of goals linked to an updated account
+func (s *GoalService) handleEvent(event models.Event) {
+	if account, ok := event.Data.(models.Account); ok && event.Type == models.EventAccountUpdated {
+		s.RecordAccount(account)
+	}
+}
+
+// RecordAccount records the progress of every goal linked to account
+func (s *GoalService) RecordAccount(account models.Account) {
+	s.mutex.RLock()
+	var linked []*models.Goal
+	for _, goal := range s.goals {
+		for _, accountID := range goal.AccountIDs {
+			if accountID == account.ID {
+				linked = append(linked, goal)
+				break
+			}
+		}
+	}
+	s.mutex.RUnlock()
+
+	now := time.Now()
+	for _, goal := range linked {
+		amount, err := s.linkedBalance(goal.AccountIDs)
+		if err != nil {
+			continue
+		}
+
+		s.mutex.Lock()
+		if current, exists := s.goals[goal.ID]; exists {
+			s.recordSnapshot(current, amount, now)
+		}
+		s.mutex.Unlock()
+	}
+}
+
+// recordSnapshot sets the goal's snapshot for the day of now, keeping the latest amount of
+// the day. The caller must hold the write lock.
+func (s *GoalService) recordSnapshot(goal *models.Goal, amount float64, now time.Time) {
+	snapshot := models.GoalSnapshot{
+		Date:            startOfDay(now).Format(dateLayout),
+		Amount:          amount,
+		PercentComplete: percentComplete(amount, goal.TargetAmount),
+	}
+
+	history := s.history[goal.ID]
+	if n := len(history); n > 0 && history[n-1].Date == snapshot.Date {
+		history[n-1] = snapshot
+		return
+	}
+	history = append(history, snapshot)
+	if len(history) > maxGoalSnapshots {
+		history = history[len(history)-maxGoalSnapshots:]
+	}
+	s.history[goal.ID] = history
+}
+
+// validateGoal checks a goal request; the deadline must be after start
+func (s *GoalService) validateGoal(request *models.GoalRequest, start time.Time) error {
+	validation := &ValidationError{}
+	if strings.TrimSpace(request.Name) == "" {
+		validation.Add("name", CodeRequired, "name is required")
+	}
+	if request.TargetAmount <= 0 {
+		validation.Add("target_amount", CodeOutOfRange, "target_amount must be positive")
+	}
+	if request.Deadline.IsZero() {
+		validation.Add("deadline", CodeRequired, "deadline is required")
+	} else if !startOfDay(request.Deadline).After(start) {
+		validation.Addf("deadline", CodeOutOfRange, "deadline must be after %s", start.Format(dateLayout))
+	}
+	if len(request.AccountIDs) == 0 {
+		validation.Add("account_ids", CodeRequired, "at least one account is required")
+	}
+
+	seen := make(map[string]bool, len(request.AccountIDs))
+	for i, accountID := range request.AccountIDs {
+		field := fmt.Sprintf("account_ids[%d]", i)
+		if seen[accountID] {
+			validation.Addf(field, CodeNotAllowed, "account %s is linked twice", accountID)
+			continue
+		}
+		seen[accountID] = true
+
+		account, err := s.accounts.GetAccountByID(accountID)
+		if err != nil {
+			validation.Addf(field, CodeInvalidFormat, "account %s not found", accountID)
+			continue
+		}
+		if isLiabilityType(account.AccountType) {
+			validation.Addf(field, CodeNotAllowed, "%s accounts cannot fund a goal", account.AccountType)
+		}
+	}
+
+	return validation.Err()
+}
+
+// linkedBalance returns the sum of the balances of accountIDs
+func (s *GoalService) linkedBalance(accountIDs []string) (float64, error) {
+	var total float64
+	for _, accountID := range accountIDs {
+		account, err := s.accounts.GetAccountByID(accountID)
+		if err != nil {
+			return 0, err
+		}
+		total += account.Balance
+	}
+	return roundCents(total), nil
+}
+
+// withProgress returns a copy of goal with its progress at amount as of now. The expected
+// amount follows a straight line from the start amount on the creation day to the target
+// on the deadline.
+func withProgress(goal *models.Goal, amount float64, now time.Time) *models.Goal {
+	result := *goal
+	result.AccountIDs = append([]string(nil), goal.AccountIDs...)
+
+	today := startOfDay(now)
+	start := startOfDay(goal.CreatedAt)
+	progress := models.GoalProgress{
+		CurrentAmount:   amount,
+		Remaining:       roundCents(math.Max(0, goal.TargetAmount-amount)),
+		PercentComplete: percentComplete(amount, goal.TargetAmount),
+		ExpectedAmount:  goal.TargetAmount,
+	}
+
+	var monthsLeft float64
+	if total := goal.Deadline.Sub(start).Hours(); total > 0 && today.Before(goal.Deadline) {
+		elapsed := math.Max(0, today.Sub(start).Hours())
+		progress.ExpectedAmount = goal.StartAmount + (goal.TargetAmount-goal.StartAmount)*elapsed/total
+		monthsLeft = goal.Deadline.Sub(today).Hours() / 24 / daysPerMonth
+		progress.MonthsLeft = math.Round(monthsLeft*10) / 10
+	}
+	progress.ExpectedAmount = roundCents(math.Min(goal.TargetAmount, progress.ExpectedAmount))
+
+	switch {
+	case progress.Remaining == 0:
+		progress.Status = models.GoalAchieved
+	case !today.Before(goal.Deadline):
+		progress.Status = models.GoalMissed
+	default:
+		progress.RequiredMonthlyContribution = roundCents(progress.Remaining / math.Max(1, monthsLeft))
+		if amount >= progress.ExpectedAmount {
+			progress.Status = models.GoalOnTrack
+		} else {
+			progress.Status = models.GoalBehind
+		}
+	}
+
+	result.Progress = progress
+	return &result
+}
+
+// percentComplete returns amount as a percentage of target, between 0 and 100
+func percentComplete(amount, target float64) float64 {
+	if target <= 0 {
+		return 0
+	}
+	return roundPercent(math.Min(100, math.Max(0, amount/target*100)))
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+func TestGoalService_CreateGoal(t *testing.T) {
+	service := NewGoalService(nil, NewAccountService())
+	deadline := time.Now().AddDate(0, 6, 0)
+
+	goal, err := service.CreateGoal(&models.GoalRequest{Name: " Emergency fund ", TargetAmount: 30000, Deadline: deadline, AccountIDs: []string{"acc_006"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if goal.ID != "goal_001" || goal.Name != "Emergency fund" || goal.StartAmount != 25000 {
+		t.Errorf("Unexpected goal %+v", goal)
+	}
+
+	// A new goal starts on its line, with 5000 to save over about six months
+	progress := goal.Progress
+	if progress.CurrentAmount != 25000 || progress.Remaining != 5000 || progress.PercentComplete != 83.3 || progress.Status != models.GoalOnTrack {
+		t.Errorf("Unexpected progress %+v", progress)
+	}
+	if progress.MonthsLeft < 5.9 || progress.MonthsLeft > 6.1 || progress.RequiredMonthlyContribution < 800 || progress.RequiredMonthlyContribution > 850 {
+		t.Errorf("Expected about 833 a month for six months, got %v for %v", progress.RequiredMonthlyContribution, progress.MonthsLeft)
+	}
+
+	history, err := service.GetGoalHistory(goal.ID)
+	if err != nil || len(history) != 1 || history[0].Amount != 25000 {
+		t.Errorf("Expected the start amount in the history, got %+v (%v)", history, err)
+	}
+
+	var validation *ValidationError
+	if _, err := service.CreateGoal(&models.GoalRequest{}); !errors.As(err, &validation) || len(validation.Fields) != 4 {
+		t.Errorf("Expected name, target_amount, deadline and account_ids to be invalid, got %v", err)
+	}
+	_, err = service.CreateGoal(&models.GoalRequest{Name: "Trip", TargetAmount: 1000, Deadline: time.Now().AddDate(0, 0, -1), AccountIDs: []string{"acc_002", "acc_002", "acc_003", "missing"}})
+	if !errors.As(err, &validation) || len(validation.Fields) != 4 {
+		t.Errorf("Expected a past deadline, a duplicate, a card and a missing account to be invalid, got %v", err)
+	}
+}
+
+func TestGoalService_UpdateAndDelete(t *testing.T) {
+	service := NewGoalService(nil, NewAccountService())
+	request := &models.GoalRequest{Name: "House", TargetAmount: 50000, Deadline: time.Now().AddDate(2, 0, 0), AccountIDs: []string{"acc_006"}}
+	goal, err := service.CreateGoal(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Linking the savings account as well adds its balance; the start amount is kept
+	request.AccountIDs = []string{"acc_006", "acc_002"}
+	updated, err := service.UpdateGoal(goal.ID, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Progress.CurrentAmount != 40000 || updated.StartAmount != 25000 || len(updated.AccountIDs) != 2 {
+		t.Errorf("Unexpected update %+v", updated)
+	}
+	if history, _ := service.GetGoalHistory(goal.ID); len(history) != 1 || history[0].Amount != 40000 {
+		t.Errorf("Expected today's snapshot to be replaced, got %+v", history)
+	}
+
+	if _, err := service.UpdateGoal("goal_999", request); !errors.Is(err, ErrGoalNotFound) {
+		t.Errorf("Expected goal_not_found, got %v", err)
+	}
+	if err := service.DeleteGoal(goal.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.GetGoalHistory(goal.ID); !errors.Is(err, ErrGoalNotFound) {
+		t.Errorf("Expected goal_not_found after delete, got %v", err)
+	}
+}
+
+func TestGoalService_RecordAccount(t *testing.T) {
+	accounts := NewAccountService()
+	service := NewGoalService(nil, accounts)
+	goal, err := service.CreateGoal(&models.GoalRequest{Name: "Car", TargetAmount: 20000, Deadline: time.Now().AddDate(1, 0, 0), AccountIDs: []string{"acc_002"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Earlier days keep their snapshot; the same day keeps its latest amount
+	service.mutex.Lock()
+	service.history[goal.ID][0].Date = "2000-01-01"
+	service.mutex.Unlock()
+
+	refresh, err := accounts.RefreshAccount(context.Background(), "acc_002")
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, _ := accounts.GetAccountByID("acc_002")
+	service.RecordAccount(*account)
+	service.RecordAccount(models.Account{ID: "acc_001"})
+
+	history, _ := service.GetGoalHistory(goal.ID)
+	if len(history) != 2 || history[0].Amount != 15000 || history[1].Amount != roundCents(refresh.NewBalance) {
+		t.Errorf("Expected the start and the refreshed balance, got %+v", history)
+	}
+}
+
+func TestWithProgress(t *testing.T) {
+	goal := &models.Goal{
+		TargetAmount: 22000,
+		StartAmount:  10000,
+		Deadline:     time.Date(2027, time.January, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt:    time.Date(2026, time.January, 1, 9, 30, 0, 0, time.UTC),
+	}
+	midyear := time.Date(2026, time.July, 2, 15, 0, 0, 0, time.UTC)
+
+	// 182 of 365 days in, the line is at 10000 + 12000 * 182 / 365
+	behind := withProgress(goal, 15000, midyear).Progress
+	if behind.ExpectedAmount != 15983.56 || behind.Status != models.GoalBehind || behind.MonthsLeft != 6 {
+		t.Errorf("Unexpected progress %+v", behind)
+	}
+	if want := roundCents(7000 / (183 / daysPerMonth)); behind.RequiredMonthlyContribution != want {
+		t.Errorf("Expected %v a month, got %v", want, behind.RequiredMonthlyContribution)
+	}
+	if status := withProgress(goal, 16000, midyear).Progress.Status; status != models.GoalOnTrack {
+		t.Errorf("Expected on_track above the line, got %s", status)
+	}
+
+	late := time.Date(2027, time.January, 2, 0, 0, 0, 0, time.UTC)
+	missed := withProgress(goal, 20000, late).Progress
+	if missed.Status != models.GoalMissed || missed.RequiredMonthlyContribution != 0 || missed.MonthsLeft != 0 || missed.ExpectedAmount != 22000 {
+		t.Errorf("Unexpected progress after the deadline %+v", missed)
+	}
+	achieved := withProgress(goal, 23000, late).Progress
+	if achieved.Status != models.GoalAchieved || achieved.Remaining != 0 || achieved.PercentComplete != 100 {
+		t.Errorf("Unexpected progress over the target %+v", achieved)
+	}
+}
@@ -0,0 +1,265 @@
+package services
+
+import (
+	"math"
+	"sort"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// InvestmentService derives the holdings of investment accounts from their buys and sells,
+// valued at the prices of a price feed
+type InvestmentService struct {
+	accounts     *AccountService
+	transactions *TransactionService
+	prices       *PriceFeed
+}
+
+// NewInvestmentService creates a new InvestmentService instance
+func NewInvestmentService(accounts *AccountService, transactions *TransactionService, prices *PriceFeed) *InvestmentService {
+	return &InvestmentService{
+		accounts:     accounts,
+		transactions: transactions,
+		prices:       prices,
+	}
+}
+
+// Holdings returns the positions, open lots, cash and investment transactions of an
+// investment account
+func (s *InvestmentService) Holdings(accountID string) (*models.Holdings, error) {
+	account, err := s.accounts.GetAccountByID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.AccountType != "investment" {
+		return nil, ErrNotInvestmentAccount
+	}
+
+	history, err := s.history(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	holdings := s.value(accountID, history)
+	holdings.Currency = account.Currency
+	for i := len(history) - 1; i >= 0; i-- {
+		if isInvestmentType(history[i].Type) {
+			holdings.Transactions = append(holdings.Transactions, *history[i])
+		}
+	}
+	return holdings, nil
+}
+
+// TotalValue returns the market value of an account's holdings plus its cash. It does not
+// read the account, so the account service can call it while refreshing the balance.
+func (s *InvestmentService) TotalValue(accountID string) (float64, error) {
+	history, err := s.history(accountID)
+	if err != nil {
+		return 0, err
+	}
+	return s.value(accountID, history).TotalValue, nil
+}
+
+// history returns the settled and pending transactions of an account, oldest first
+func (s *InvestmentService) history(accountID string) ([]*models.Transaction, error) {
+	all, err := s.transactions.GetAllTransactions(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var history []*models.Transaction
+	for _, transaction := range all {
+		if transaction.AccountID == accountID && transaction.Status != "failed" && transaction.Status != "cancelled" {
+			history = append(history, transaction)
+		}
+	}
+	// GetAllTransactions is newest first; replay needs the reverse, with the same tie-break
+	for i, j := 0, len(history)-1; i < j; i, j = i+1, j-1 {
+		history[i], history[j] = history[j], history[i]
+	}
+	return history, nil
+}
+
+// value replays history and values the open positions
+func (s *InvestmentService) value(accountID string, history []*models.Transaction) *models.Holdings {
+	ledger := newLotLedger(models.CostBasisFIFO)
+	for _, transaction := range history {
+		ledger.apply(transaction)
+	}
+
+	holdings := &models.Holdings{
+		AccountID:    accountID,
+		Cash:         roundCents(ledger.cash),
+		Holdings:     []models.Holding{},
+		Transactions: []models.Transaction{},
+	}
+
+	symbols := make([]string, 0, len(ledger.lots))
+	for symbol := range ledger.lots {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+
+	var marketValue, costBasis float64
+	for _, symbol := range symbols {
+		lots := ledger.lots[symbol]
+		if len(lots) == 0 {
+			continue
+		}
+
+		holding := models.Holding{Symbol: symbol, Price: ledger.lastTrade[symbol].Price, Lots: make([]models.Lot, len(lots))}
+		for i, lot := range lots {
+			holding.Quantity += lot.Quantity
+			holding.CostBasis += lot.CostBasis
+			lot.Quantity = roundQuantity(lot.Quantity)
+			lot.UnitCost = roundCents(lot.UnitCost)
+			lot.CostBasis = roundCents(lot.CostBasis)
+			holding.Lots[i] = lot
+		}
+		if price, ok := s.prices.Price(symbol); ok {
+			asOf := price.AsOf
+			holding.Price = price.Price
+			holding.PriceAsOf = &asOf
+			holding.Priced = true
+		}
+
+		value := holding.Quantity * holding.Price
+		marketValue += value
+		costBasis += holding.CostBasis
+
+		holding.AverageCost = roundCents(holding.CostBasis / holding.Quantity)
+		holding.MarketValue = roundCents(value)
+		holding.UnrealizedGain = roundCents(value - holding.CostBasis)
+		holding.Quantity = roundQuantity(holding.Quantity)
+		holding.CostBasis = roundCents(holding.CostBasis)
+		holdings.Holdings = append(holdings.Holdings, holding)
+	}
+
+	holdings.MarketValue = roundCents(marketValue)
+	holdings.CostBasis = roundCents(costBasis)
+	holdings.UnrealizedGain = roundCents(marketValue - costBasis)
+	holdings.TotalValue = roundCents(marketValue + ledger.cash)
+	return holdings
+}
+
+// lotLedger replays an account's history: every amount moves cash, buys open lots and sells
+// close them, the oldest first (FIFO) or a share of every lot (average cost). Selling more
+// than is held closes what there is.
+type lotLedger struct {
+	method    string
+	cash      float64
+	lots      map[string][]models.Lot // open lots per symbol, oldest first
+	lastTrade map[string]models.Price // last trade price and time per symbol
+	realized  map[string]float64      // realized gain per symbol
+}
+
+// newLotLedger creates an empty ledger matching sells by method
+func newLotLedger(method string) *lotLedger {
+	return &lotLedger{
+		method:    method,
+		lots:      make(map[string][]models.Lot),
+		lastTrade: make(map[string]models.Price),
+		realized:  make(map[string]float64),
+	}
+}
+
+// apply replays one transaction
+func (l *lotLedger) apply(transaction *models.Transaction) {
+	l.cash += transaction.Amount
+	if transaction.Quantity <= 0 || transaction.Symbol == "" {
+		return
+	}
+
+	symbol := transaction.Symbol
+	switch transaction.Type {
+	case models.TransactionBuy:
+		// The cost includes any commission, which is the part of the amount beyond the price
+		cost := -transaction.Amount
+		if cost <= 0 {
+			cost = transaction.Quantity * transaction.Price
+		}
+		l.lots[symbol] = append(l.lots[symbol], models.Lot{
+			TransactionID: transaction.ID,
+			Acquired:      transaction.Date,
+			Quantity:      transaction.Quantity,
+			UnitCost:      cost / transaction.Quantity,
+			CostBasis:     cost,
+		})
+	case models.TransactionSell:
+		l.sell(symbol, transaction)
+	default:
+		return
+	}
+
+	if transaction.Price > 0 {
+		l.lastTrade[symbol] = models.Price{Symbol: symbol, Price: transaction.Price, Currency: transaction.Currency, AsOf: transaction.Date}
+	}
+}
+
+// sell closes lots for a sale and records the gain over their cost
+func (l *lotLedger) sell(symbol string, transaction *models.Transaction) {
+	lots := l.lots[symbol]
+	held := 0.0
+	for _, lot := range lots {
+		held += lot.Quantity
+	}
+	sold := math.Min(transaction.Quantity, held)
+	if sold <= quantityEpsilon {
+		return
+	}
+
+	// Proceeds are net of commission; only the part for units actually held is matched
+	proceeds := transaction.Amount
+	if proceeds <= 0 {
+		proceeds = transaction.Quantity * transaction.Price
+	}
+	proceeds *= sold / transaction.Quantity
+
+	cost := 0.0
+	if l.method == models.CostBasisAverage {
+		share := sold / held
+		for i := range lots {
+			cost += lots[i].CostBasis * share
+			lots[i].Quantity -= lots[i].Quantity * share
+			lots[i].CostBasis = lots[i].Quantity * lots[i].UnitCost
+		}
+	} else {
+		remaining := sold
+		for i := range lots {
+			if remaining <= quantityEpsilon {
+				break
+			}
+			closed := math.Min(lots[i].Quantity, remaining)
+			cost += closed * lots[i].UnitCost
+			lots[i].Quantity -= closed
+			lots[i].CostBasis = lots[i].Quantity * lots[i].UnitCost
+			remaining -= closed
+		}
+	}
+
+	open := lots[:0]
+	for _, lot := range lots {
+		if lot.Quantity > quantityEpsilon {
+			open = append(open, lot)
+		}
+	}
+	l.lots[symbol] = open
+	l.realized[symbol] += proceeds - cost
+}
+
+// quantityEpsilon is the quantity below which a lot counts as fully sold
+const quantityEpsilon = 1e-9
+
+// roundQuantity rounds a number of units to six decimals
+func roundQuantity(quantity float64) float64 {
+	return math.Round(quantity*1e6) / 1e6
+}
+
+// isInvestmentType reports whether a transaction type is a buy, sell, dividend or fee
+func isInvestmentType(transactionType string) bool {
+	switch transactionType {
+	case models.TransactionBuy, models.TransactionSell, models.TransactionDividend, models.TransactionFee:
+		return true
+	}
+	return false
+}
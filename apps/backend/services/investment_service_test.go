@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+func TestInvestmentService_Holdings(t *testing.T) {
+	accounts := NewAccountService()
+	service := NewInvestmentService(accounts, NewTransactionService(), DefaultPriceFeed())
+
+	holdings, err := service.Holdings("acc_004")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Selling 30 VTI closed part of the oldest lot
+	if len(holdings.Holdings) != 2 || holdings.Holdings[0].Symbol != "AAPL" || holdings.Holdings[1].Symbol != "VTI" {
+		t.Fatalf("Expected AAPL and VTI, got %+v", holdings.Holdings)
+	}
+	vti := holdings.Holdings[1]
+	if vti.Quantity != 120 || len(vti.Lots) != 2 || vti.Lots[0].TransactionID != "txn_012" || vti.Lots[0].Quantity != 70 {
+		t.Errorf("Expected 120 VTI in two lots, 70 left of txn_012, got %+v", vti)
+	}
+	// The first buy's $4.95 commission is part of its cost
+	if vti.CostBasis != 25003.47 || vti.MarketValue != 31848 || vti.UnrealizedGain != 6844.53 || !vti.Priced {
+		t.Errorf("Unexpected VTI valuation %+v", vti)
+	}
+
+	if holdings.Cash != 8820.05 || holdings.MarketValue != 43253 || holdings.TotalValue != 52073.05 {
+		t.Errorf("Unexpected totals: cash %v, market value %v, total %v", holdings.Cash, holdings.MarketValue, holdings.TotalValue)
+	}
+	if len(holdings.Transactions) != 6 || holdings.Transactions[0].ID != "txn_006" {
+		t.Errorf("Expected the six investment transactions, newest first, got %d", len(holdings.Transactions))
+	}
+
+	// The account balance follows the holdings once the valuer is set
+	accounts.SetHoldingsValuer(service)
+	account, _ := accounts.GetAccountByID("acc_004")
+	if account.Balance != holdings.TotalValue || account.Version != 1 {
+		t.Errorf("Expected balance %v at version 1, got %v at %d", holdings.TotalValue, account.Balance, account.Version)
+	}
+	response, err := accounts.RefreshAccount(context.Background(), "acc_004")
+	if err != nil || response.NewBalance != holdings.TotalValue {
+		t.Errorf("Expected a refresh to keep the derived balance, got %+v (%v)", response, err)
+	}
+
+	if _, err := service.Holdings("acc_001"); !errors.Is(err, ErrNotInvestmentAccount) {
+		t.Errorf("Expected not_investment_account for a checking account, got %v", err)
+	}
+	if _, err := service.Holdings("missing"); !errors.Is(err, ErrAccountNotFound) {
+		t.Errorf("Expected account_not_found, got %v", err)
+	}
+}
+
+func TestInvestmentService_UnpricedHoldingUsesLastTrade(t *testing.T) {
+	prices := NewPriceFeed([]models.Price{{Symbol: "aapl", Price: 200, Currency: "USD"}})
+	service := NewInvestmentService(NewAccountService(), NewTransactionService(), prices)
+
+	holdings, err := service.Holdings("acc_004")
+	if err != nil {
+		t.Fatal(err)
+	}
+	aapl, vti := holdings.Holdings[0], holdings.Holdings[1]
+	if !aapl.Priced || aapl.MarketValue != 10000 {
+		t.Errorf("Expected AAPL priced at 200, got %+v", aapl)
+	}
+	if vti.Priced || vti.Price != 240 || vti.PriceAsOf != nil {
+		t.Errorf("Expected VTI at its last sale price of 240, got %+v", vti)
+	}
+}
+
+func TestPriceFeed_ReloadsChangedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prices.json")
+	write := func(content string, modTime time.Time) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	start := time.Now().Add(-time.Hour)
+	write(`{"prices": [{"symbol": "VTI", "price": 250, "currency": "USD"}]}`, start)
+	feed, err := LoadPriceFeed(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if price, ok := feed.Price("vti"); !ok || price.Price != 250 {
+		t.Fatalf("Expected VTI at 250, got %+v", price)
+	}
+
+	write(`{"prices": [{"symbol": "VTI", "price": 260, "currency": "USD"}]}`, start.Add(time.Minute))
+	if price, _ := feed.Price("VTI"); price.Price != 260 {
+		t.Errorf("Expected the updated price 260, got %v", price.Price)
+	}
+
+	// A broken update keeps the last good prices
+	write(`{"prices": [`, start.Add(2*time.Minute))
+	if price, ok := feed.Price("VTI"); !ok || price.Price != 260 {
+		t.Errorf("Expected the previous price after a bad update, got %+v", price)
+	}
+
+	if _, err := LoadPriceFeed(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected an error for a missing price feed")
+	}
+}
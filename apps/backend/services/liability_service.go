@@ -0,0 +1,287 @@
+package services
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// maxAmortizationPayments caps a schedule, so a payment that barely covers the interest does
+// not produce centuries of rows
+const maxAmortizationPayments = 1200
+
+// LiabilityService reports on credit and loan accounts: what is owed, credit utilization and
+// how loans pay down
+type LiabilityService struct {
+	accounts *AccountService
+}
+
+// NewLiabilityService creates a new LiabilityService instance
+func NewLiabilityService(accounts *AccountService) *LiabilityService {
+	return &LiabilityService{
+		accounts: accounts,
+	}
+}
+
+// Liabilities returns every credit and loan account, with the total owed and the overall
+// utilization of the accounts that have a credit limit
+func (s *LiabilityService) Liabilities() (*models.LiabilityReport, error) {
+	accounts, err := s.accounts.GetAllAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	today := startOfDay(time.Now())
+	report := &models.LiabilityReport{Liabilities: []models.LiabilityDetails{}}
+	var owedOnCredit float64
+	for _, account := range accounts {
+		if !isLiabilityType(account.AccountType) {
+			continue
+		}
+		details := liabilityDetails(account, today)
+		report.TotalOwed += details.Owed
+		if account.CreditLimit > 0 {
+			report.TotalCreditLimit += account.CreditLimit
+			owedOnCredit += details.Owed
+		}
+		report.Liabilities = append(report.Liabilities, *details)
+	}
+
+	report.TotalOwed = roundCents(report.TotalOwed)
+	report.TotalCreditLimit = roundCents(report.TotalCreditLimit)
+	if report.TotalCreditLimit > 0 {
+		utilization := roundPercent(owedOnCredit / report.TotalCreditLimit * 100)
+		report.Utilization = &utilization
+	}
+	return report, nil
+}
+
+// Liability returns one credit or loan account with its terms
+func (s *LiabilityService) Liability(accountID string) (*models.LiabilityDetails, error) {
+	account, err := s.accounts.GetAccountByID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if !isLiabilityType(account.AccountType) {
+		return nil, ErrNotLiabilityAccount
+	}
+	return liabilityDetails(account, startOfDay(time.Now())), nil
+}
+
+// UpdateLiability changes the terms of a credit or loan account and returns the result
+func (s *LiabilityService) UpdateLiability(ctx context.Context, accountID string, update *models.LiabilityUpdate) (*models.LiabilityDetails, error) {
+	account, err := s.accounts.UpdateLiability(ctx, accountID, update)
+	if err != nil {
+		return nil, err
+	}
+	return liabilityDetails(account, startOfDay(time.Now())), nil
+}
+
+// Amortization returns the monthly payments that pay a loan off, starting at its next due
+// date. The payment is the loan's minimum payment or, without one, the payment that repays
+// the principal over the term. An extra payment goes to principal every month.
+func (s *LiabilityService) Amortization(accountID string, query models.AmortizationQuery) (*models.AmortizationSchedule, error) {
+	account, err := s.accounts.GetAccountByID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.AccountType != "loan" {
+		return nil, ErrNotLoanAccount
+	}
+
+	validation := &ValidationError{}
+	if query.ExtraPayment < 0 {
+		validation.Add("extra_payment", CodeOutOfRange, "extra_payment must not be negative")
+	}
+	payment := loanPayment(account.Liability)
+	if payment <= 0 {
+		validation.Add("minimum_payment", CodeRequired, "the loan needs a minimum_payment, or a principal and term_months")
+	}
+	if err := validation.Err(); err != nil {
+		return nil, err
+	}
+
+	today := startOfDay(time.Now())
+	owed := math.Max(0, -account.Balance)
+	apr := account.Liability.APR
+	first := nextDueDate(account.Liability, today)
+	payments, ok := amortize(owed, apr, payment+query.ExtraPayment, first)
+	if !ok {
+		validation.Add("minimum_payment", CodeOutOfRange, "the payment does not pay the loan off")
+		return nil, validation
+	}
+
+	schedule := &models.AmortizationSchedule{
+		AccountID:    account.ID,
+		Currency:     account.Currency,
+		Owed:         roundCents(owed),
+		APR:          apr,
+		Payment:      roundCents(payment),
+		ExtraPayment: roundCents(query.ExtraPayment),
+		PaymentsLeft: len(payments),
+		PayoffDate:   today.Format(dateLayout),
+		Payments:     payments,
+	}
+	if len(payments) > 0 {
+		schedule.PayoffDate = payments[len(payments)-1].Date
+	}
+	schedule.TotalInterest, schedule.TotalPaid = amortizationTotals(payments)
+
+	if query.ExtraPayment > 0 {
+		if without, ok := amortize(owed, apr, payment, first); ok {
+			interest, _ := amortizationTotals(without)
+			saved := roundCents(interest - schedule.TotalInterest)
+			schedule.InterestSaved = &saved
+		}
+	}
+	return schedule, nil
+}
+
+// liabilityDetails describes a credit or loan account as of today
+func liabilityDetails(account *models.Account, today time.Time) *models.LiabilityDetails {
+	owed := math.Max(0, -account.Balance)
+	details := &models.LiabilityDetails{
+		AccountID:   account.ID,
+		Name:        account.Name,
+		AccountType: account.AccountType,
+		Currency:    account.Currency,
+		Owed:        roundCents(owed),
+		CreditLimit: account.CreditLimit,
+		Version:     account.Version,
+	}
+	if utilization, ok := CreditUtilization(*account); ok {
+		available := roundCents(math.Max(0, account.CreditLimit-owed))
+		utilization = roundPercent(utilization)
+		details.AvailableCredit = &available
+		details.Utilization = &utilization
+	}
+
+	liability := account.Liability
+	if liability == nil {
+		return details
+	}
+	details.APR = liability.APR
+	details.MonthlyInterest = roundCents(owed * liability.APR / 1200)
+	details.StatementDay = liability.StatementDay
+	details.DueDate = liability.DueDate
+	details.MinimumPayment = liability.MinimumPayment
+	details.Principal = liability.Principal
+	details.TermMonths = liability.TermMonths
+	details.OriginatedOn = liability.OriginatedOn
+	if liability.StatementDay > 0 {
+		statement := dayOfMonth(today, liability.StatementDay)
+		if statement.Before(today) {
+			statement = dayOfMonth(today.AddDate(0, 0, 1-today.Day()).AddDate(0, 1, 0), liability.StatementDay)
+		}
+		details.NextStatementDate = statement.Format(dateLayout)
+	}
+
+	if account.AccountType == "loan" {
+		if payment := loanPayment(liability); payment > 0 {
+			if payments, ok := amortize(owed, liability.APR, payment, nextDueDate(liability, today)); ok && len(payments) > 0 {
+				interest, _ := amortizationTotals(payments)
+				details.PaymentsLeft = len(payments)
+				details.PayoffDate = payments[len(payments)-1].Date
+				details.RemainingInterest = &interest
+			}
+		}
+	}
+	return details
+}
+
+// loanPayment returns the monthly payment of a loan: its minimum payment, or the level
+// payment that repays the principal over the term
+func loanPayment(liability *models.Liability) float64 {
+	if liability == nil {
+		return 0
+	}
+	if liability.MinimumPayment > 0 {
+		return liability.MinimumPayment
+	}
+	if liability.Principal <= 0 || liability.TermMonths <= 0 {
+		return 0
+	}
+
+	rate := liability.APR / 1200
+	if rate == 0 {
+		return roundCents(liability.Principal / float64(liability.TermMonths))
+	}
+	return roundCents(liability.Principal * rate / (1 - math.Pow(1+rate, -float64(liability.TermMonths))))
+}
+
+// amortize pays owed down by payment a month from first, charging a month's interest on the
+// balance before each payment. It reports false when the payment never pays the loan off.
+func amortize(owed, apr, payment float64, first time.Time) ([]models.AmortizationPayment, bool) {
+	payments := []models.AmortizationPayment{}
+	balance := roundCents(owed)
+	for number := 1; balance > 0; number++ {
+		if number > maxAmortizationPayments {
+			return nil, false
+		}
+
+		interest := roundCents(balance * apr / 1200)
+		principal := roundCents(payment - interest)
+		if principal <= 0 {
+			return nil, false
+		}
+		if principal > balance {
+			principal = balance
+		}
+		balance = roundCents(balance - principal)
+
+		payments = append(payments, models.AmortizationPayment{
+			Number:    number,
+			Date:      addMonths(first, number-1).Format(dateLayout),
+			Payment:   roundCents(principal + interest),
+			Principal: principal,
+			Interest:  interest,
+			Balance:   balance,
+		})
+	}
+	return payments, true
+}
+
+// amortizationTotals returns the interest and the total paid over a schedule
+func amortizationTotals(payments []models.AmortizationPayment) (interest, paid float64) {
+	for _, payment := range payments {
+		interest += payment.Interest
+		paid += payment.Payment
+	}
+	return roundCents(interest), roundCents(paid)
+}
+
+// nextDueDate returns the first payment date on or after today: the due date, moved on by
+// whole months if it has passed, or a month from today without one
+func nextDueDate(liability *models.Liability, today time.Time) time.Time {
+	if liability == nil || liability.DueDate == nil {
+		return addMonths(today, 1)
+	}
+	due := startOfDay(*liability.DueDate)
+	for months := 1; due.Before(today); months++ {
+		due = addMonths(startOfDay(*liability.DueDate), months)
+	}
+	return due
+}
+
+// addMonths adds months to t, keeping the day of the month where the month is long enough
+// and using its last day otherwise
+func addMonths(t time.Time, months int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	return dayOfMonth(firstOfMonth, t.Day())
+}
+
+// dayOfMonth returns day of t's month, or the month's last day if it is shorter
+func dayOfMonth(t time.Time, day int) time.Time {
+	lastDay := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(t.Year(), t.Month(), day, 0, 0, 0, 0, time.UTC)
+}
+
+// roundPercent rounds a percentage to one decimal
+func roundPercent(percent float64) float64 {
+	return math.Round(percent*10) / 10
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+func TestLiabilityService_Liabilities(t *testing.T) {
+	service := NewLiabilityService(NewAccountService())
+
+	report, err := service.Liabilities()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Liabilities) != 2 || report.Liabilities[0].AccountID != "acc_003" || report.Liabilities[1].AccountID != "acc_007" {
+		t.Fatalf("Expected the card and the loan, got %+v", report.Liabilities)
+	}
+	// Only the card has a limit, so overall utilization is the card's
+	if report.TotalOwed != 19530.13 || report.TotalCreditLimit != 5000 || report.Utilization == nil || *report.Utilization != 24 {
+		t.Errorf("Unexpected totals %+v", report)
+	}
+
+	card := report.Liabilities[0]
+	if *card.AvailableCredit != 3799.5 || card.MonthlyInterest != 25 || card.NextStatementDate == "" {
+		t.Errorf("Unexpected card details %+v", card)
+	}
+
+	// 18 of 60 payments are made
+	loan := report.Liabilities[1]
+	if loan.Utilization != nil || loan.PaymentsLeft != 42 || loan.RemainingInterest == nil || loan.PayoffDate == "" {
+		t.Errorf("Unexpected loan details %+v", loan)
+	}
+}
+
+func TestLiabilityService_Amortization(t *testing.T) {
+	service := NewLiabilityService(NewAccountService())
+
+	schedule, err := service.Amortization("acc_007", models.AmortizationQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schedule.PaymentsLeft != 42 || len(schedule.Payments) != 42 || schedule.Payment != 489.04 {
+		t.Fatalf("Expected 42 payments of 489.04, got %d of %v", schedule.PaymentsLeft, schedule.Payment)
+	}
+	first, last := schedule.Payments[0], schedule.Payments[41]
+	if first.Interest != 99.13 || first.Principal != 389.91 || first.Balance != 17939.72 {
+		t.Errorf("Unexpected first payment %+v", first)
+	}
+	if last.Balance != 0 || schedule.PayoffDate != last.Date {
+		t.Errorf("Expected the last payment to clear the loan, got %+v", last)
+	}
+	if schedule.TotalPaid != roundCents(schedule.Owed+schedule.TotalInterest) || schedule.InterestSaved != nil {
+		t.Errorf("Unexpected totals %+v", schedule)
+	}
+
+	// Paying extra shortens the loan and saves interest
+	faster, err := service.Amortization("acc_007", models.AmortizationQuery{ExtraPayment: 200})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if faster.PaymentsLeft >= schedule.PaymentsLeft || faster.InterestSaved == nil || *faster.InterestSaved != roundCents(schedule.TotalInterest-faster.TotalInterest) {
+		t.Errorf("Expected an extra payment to save interest, got %d payments saving %v", faster.PaymentsLeft, faster.InterestSaved)
+	}
+
+	if _, err := service.Amortization("acc_003", models.AmortizationQuery{}); !errors.Is(err, ErrNotLoanAccount) {
+		t.Errorf("Expected not_loan_account for a card, got %v", err)
+	}
+	var validation *ValidationError
+	if _, err := service.Amortization("acc_007", models.AmortizationQuery{ExtraPayment: -1}); !errors.As(err, &validation) {
+		t.Errorf("Expected a validation error for a negative extra payment, got %v", err)
+	}
+}
+
+func TestLiabilityService_UpdateLiability(t *testing.T) {
+	accounts := NewAccountService()
+	service := NewLiabilityService(accounts)
+
+	// Without a minimum payment, the payment repays the principal over the term
+	apr, payment, principal, term := 0.0, 0.0, 12000.0, 12
+	details, err := service.UpdateLiability(context.Background(), "acc_007", &models.LiabilityUpdate{
+		Version: 1, APR: &apr, MinimumPayment: &payment, Principal: &principal, TermMonths: &term,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if details.Version != 2 || details.APR != 0 || details.MonthlyInterest != 0 || details.PaymentsLeft != 19 {
+		t.Errorf("Expected 19 interest-free payments of 1000, got %+v", details)
+	}
+	schedule, _ := service.Amortization("acc_007", models.AmortizationQuery{})
+	if schedule.Payment != 1000 || schedule.TotalInterest != 0 {
+		t.Errorf("Expected 1000 a month without interest, got %+v", schedule)
+	}
+
+	if _, err := service.UpdateLiability(context.Background(), "acc_007", &models.LiabilityUpdate{Version: 1, APR: &apr}); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("Expected version_conflict for a stale version, got %v", err)
+	}
+	if _, err := service.UpdateLiability(context.Background(), "acc_001", &models.LiabilityUpdate{Version: 1, APR: &apr}); !errors.Is(err, ErrNotLiabilityAccount) {
+		t.Errorf("Expected not_liability_account for checking, got %v", err)
+	}
+
+	var validation *ValidationError
+	badAPR, limit := 120.0, 1000.0
+	_, err = service.UpdateLiability(context.Background(), "acc_007", &models.LiabilityUpdate{Version: 2, APR: &badAPR, CreditLimit: &limit})
+	if !errors.As(err, &validation) || len(validation.Fields) != 2 {
+		t.Errorf("Expected apr and credit_limit to be invalid for a loan, got %v", err)
+	}
+	_, err = service.UpdateLiability(context.Background(), "acc_003", &models.LiabilityUpdate{Version: 1, Principal: &principal})
+	if !errors.As(err, &validation) || validation.Fields[0].Field != "principal" {
+		t.Errorf("Expected principal to be invalid for a card, got %v", err)
+	}
+
+	// An update that changes nothing keeps the version
+	day := 5
+	account, err := accounts.UpdateLiability(context.Background(), "acc_003", &models.LiabilityUpdate{Version: 1, StatementDay: &day})
+	if err != nil || account.Version != 1 {
+		t.Errorf("Expected no change at version 1, got %+v (%v)", account, err)
+	}
+}
+
+func TestAddMonths(t *testing.T) {
+	jan31 := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+	for months, want := range map[int]string{1: "2024-02-29", 2: "2024-03-31", 13: "2025-02-28"} {
+		if got := addMonths(jan31, months).Format(dateLayout); got != want {
+			t.Errorf("addMonths(%d) = %s, want %s", months, got, want)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// defaultMerchantRules maps common card descriptors to merchant names
+//
+//go:embed merchant_rules.json
+var defaultMerchantRules []byte
+
+// MerchantRule names the merchant of descriptors containing any of its patterns. A pattern
+// matches at the start of a word of the cleaned, upper-case descriptor.
+type MerchantRule struct {
+	Merchant string   `json:"merchant"`
+	Patterns []string `json:"patterns"`
+}
+
+// merchantPattern is one pattern of a rule
+type merchantPattern struct {
+	pattern  string
+	merchant string
+}
+
+// Descriptor noise removed before matching
+var (
+	processorPrefix = regexp.MustCompile(`^(SQ|TST|PAYPAL|PP|SP)\s?\*\s*`)
+	descriptorDate  = regexp.MustCompile(`\b\d{1,2}/\d{1,2}(/\d{2,4})?\b|\b\d{4}-\d{2}-\d{2}\b`)
+	cardSuffix      = regexp.MustCompile(`\b(CARD|ACCT)\s*(ENDING\s*(IN\s*)?)?[X*#]*\d{4}\b|[X*]{2,}\d{2,4}\b`)
+	storeNumber     = regexp.MustCompile(`(#|\bNO\.?\s?|\bSTORE\s)\s*\d+\b`)
+	terminalID      = regexp.MustCompile(`\b[A-Z]*\d{3,}[A-Z0-9]*\b`)
+)
+
+// leadingNoise are words that introduce a descriptor without naming the merchant
+var leadingNoise = map[string]bool{
+	"POS": true, "ACH": true, "DEBIT": true, "CHECKCARD": true, "CARD": true,
+	"PURCHASE": true, "VISA": true, "RECURRING": true, "PREAUTH": true,
+}
+
+// MerchantNormalizer turns raw transaction descriptors into merchant names
+type MerchantNormalizer struct {
+	patterns []merchantPattern // longest first, so the most specific pattern wins
+}
+
+// NewMerchantNormalizer creates a new MerchantNormalizer instance from rules
+func NewMerchantNormalizer(rules []MerchantRule) *MerchantNormalizer {
+	normalizer := &MerchantNormalizer{}
+	for _, rule := range rules {
+		for _, pattern := range rule.Patterns {
+			if pattern = strings.ToUpper(strings.TrimSpace(pattern)); pattern != "" && rule.Merchant != "" {
+				normalizer.patterns = append(normalizer.patterns, merchantPattern{pattern: pattern, merchant: rule.Merchant})
+			}
+		}
+	}
+	sort.SliceStable(normalizer.patterns, func(i, j int) bool {
+		return len(normalizer.patterns[i].pattern) > len(normalizer.patterns[j].pattern)
+	})
+	return normalizer
+}
+
+// DefaultMerchantNormalizer returns a normalizer with the built-in rules
+func DefaultMerchantNormalizer() *MerchantNormalizer {
+	normalizer, err := parseMerchantRules(defaultMerchantRules)
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in merchant rules: %v", err))
+	}
+	return normalizer
+}
+
+// LoadMerchantNormalizer reads rules from a JSON file in the format of merchant_rules.json
+func LoadMerchantNormalizer(path string) (*MerchantNormalizer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseMerchantRules(data)
+}
+
+// parseMerchantRules decodes a rules file
+func parseMerchantRules(data []byte) (*MerchantNormalizer, error) {
+	var file struct {
+		Rules []MerchantRule `json:"rules"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	return NewMerchantNormalizer(file.Rules), nil
+}
+
+// Normalize returns the merchant name for a descriptor: the merchant of the matching rule,
+// or else the cleaned descriptor in title case. It returns "" when nothing is left.
+func (n *MerchantNormalizer) Normalize(descriptor string) string {
+	cleaned := CleanDescriptor(descriptor)
+	if cleaned == "" {
+		return ""
+	}
+
+	padded := " " + cleaned
+	for _, p := range n.patterns {
+		if strings.Contains(padded, " "+p.pattern) {
+			return p.merchant
+		}
+	}
+
+	return titleCase(cleaned)
+}
+
+// CleanDescriptor upper-cases a descriptor and strips payment processor prefixes, dates,
+// card suffixes, store numbers and terminal IDs
+func CleanDescriptor(descriptor string) string {
+	cleaned := strings.ToUpper(strings.TrimSpace(descriptor))
+	cleaned = processorPrefix.ReplaceAllString(cleaned, "")
+	cleaned = descriptorDate.ReplaceAllString(cleaned, " ")
+	cleaned = cardSuffix.ReplaceAllString(cleaned, " ")
+	cleaned = storeNumber.ReplaceAllString(cleaned, " ")
+	cleaned = terminalID.ReplaceAllString(cleaned, " ")
+
+	// Keep words with a letter or digit, then drop leading noise such as "POS"
+	var words []string
+	for _, word := range strings.Fields(cleaned) {
+		word = strings.Trim(word, "*#-.,:;/")
+		if strings.IndexFunc(word, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) < 0 {
+			continue
+		}
+		if len(words) == 0 && leadingNoise[word] {
+			continue
+		}
+		words = append(words, word)
+	}
+
+	return strings.Join(words, " ")
+}
+
+// titleCase capitalises the first letter of each word
+func titleCase(s string) string {
+	words := strings.Fields(strings.ToLower(s))
+	for i, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"financial-aggregator-api/backend/models"
+)
+
+func TestMerchantNormalizer_Normalize(t *testing.T) {
+	normalizer := DefaultMerchantNormalizer()
+
+	tests := []struct {
+		descriptor string
+		want       string
+	}{
+		{"POS 1234 WHOLEFDS #102", "Whole Foods Market"},
+		{"WHOLEFDS MKT 10233 AUSTIN TX 03/14", "Whole Foods Market"},
+		{"SQ *BLUE BOTTLE COFFEE", "Blue Bottle Coffee"},
+		{"AMZN Mktp US*2K3L45TR1", "Amazon"},
+		{"UBER EATS 8005928996 CA", "Uber Eats"},
+		{"UBER *TRIP HELP.UBER.COM", "Uber"},
+		{"CHECKCARD 0314 STARBUCKS STORE 0459 SEATTLE", "Starbucks"},
+		{"Netflix.com CARD 4421", "Netflix"},
+		{"DEBIT CARD PURCHASE XXXX5521 CORNER BAKERY 2024-03-14", "Corner Bakery"},
+		{"Grocery Store Purchase", "Grocery Store Purchase"},
+		{"POS 1234", ""},
+	}
+	for _, test := range tests {
+		if got := normalizer.Normalize(test.descriptor); got != test.want {
+			t.Errorf("Normalize(%q) = %q, want %q (cleaned %q)", test.descriptor, got, test.want, CleanDescriptor(test.descriptor))
+		}
+	}
+}
+
+func TestMerchantNormalizer_RulesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "merchants.json")
+	rules := `{"rules": [{"merchant": "Corner Bakery Cafe", "patterns": ["corner bakery", "CORNER BAK"]}]}`
+	if err := os.WriteFile(path, []byte(rules), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	normalizer, err := LoadMerchantNormalizer(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service := NewTransactionService()
+	created, err := service.CreateTransaction(context.Background(), &models.Transaction{ID: "txn_bakery", Description: "POS CORNER BAKERY #12"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.Merchant != "Corner Bakery" {
+		t.Errorf("Expected the built-in rules to title-case an unknown merchant, got %q", created.Merchant)
+	}
+
+	// New rules apply to stored transactions without changing their version
+	service.SetMerchantNormalizer(normalizer)
+	transaction, _ := service.GetTransactionByID("txn_bakery")
+	if transaction.Merchant != "Corner Bakery Cafe" || transaction.Version != 1 {
+		t.Errorf("Expected Corner Bakery Cafe at version 1, got %q at %d", transaction.Merchant, transaction.Version)
+	}
+
+	// A new description derives the merchant again
+	description := "CORNER BAK 0031 DALLAS"
+	updated, err := service.UpdateTransaction(context.Background(), "txn_bakery", &models.TransactionUpdate{Version: 1, Description: &description})
+	if err != nil || updated.Merchant != "Corner Bakery Cafe" {
+		t.Errorf("Expected the merchant to follow the description, got %+v (%v)", updated, err)
+	}
+
+	if _, err := LoadMerchantNormalizer(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("Expected an error for a missing rules file")
+	}
+}
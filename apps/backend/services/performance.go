@@ -0,0 +1,265 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// Performance returns the returns, gains, dividends and fees of an investment account over a
+// period. A zero From starts at the account's first transaction and a zero To ends today.
+// Deposits and withdrawals are the account's non-investment transactions; dividends and fees
+// are part of the return. Positions are valued at the feed price as of each date, or at the
+// last trade price when that is more recent.
+func (s *InvestmentService) Performance(accountID string, query models.PerformanceQuery) (*models.Performance, error) {
+	account, err := s.accounts.GetAccountByID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.AccountType != "investment" {
+		return nil, ErrNotInvestmentAccount
+	}
+
+	history, err := s.history(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to := startOfDay(query.From), startOfDay(query.To)
+	if query.From.IsZero() {
+		from = startOfDay(time.Now())
+		if len(history) > 0 {
+			from = startOfDay(history[0].Date)
+		}
+	}
+	if query.To.IsZero() {
+		to = startOfDay(time.Now())
+	}
+
+	validation := &ValidationError{}
+	switch query.Method {
+	case models.CostBasisFIFO, models.CostBasisAverage:
+	default:
+		validation.Add("method", CodeNotAllowed, "method must be one of fifo or average")
+	}
+	if to.Before(from) {
+		validation.Add("to", CodeOutOfRange, "to must not be before from")
+	}
+	if err := validation.Err(); err != nil {
+		return nil, err
+	}
+
+	// Replay up to the start, then through the half-open period [start, end)
+	start, end := from, to.AddDate(0, 0, 1)
+	ledger := newLotLedger(query.Method)
+	i := 0
+	for ; i < len(history) && history[i].Date.Before(start); i++ {
+		ledger.apply(history[i])
+	}
+
+	startValue := s.marketValue(ledger, start) + ledger.cash
+	realizedAtStart := make(map[string]float64, len(ledger.realized))
+	for symbol, gain := range ledger.realized {
+		realizedAtStart[symbol] = gain
+	}
+
+	// The time-weighted return links the returns between external flows; sub-periods that
+	// start with nothing invested have no return
+	growth := 1.0
+	previous := startValue
+	flows := []cashFlow{{date: start, amount: -startValue}}
+	var contributions, dividendIncome, fees float64
+	dividends := make(map[string]float64)
+	for ; i < len(history) && history[i].Date.Before(end); i++ {
+		transaction := history[i]
+		if !isInvestmentType(transaction.Type) {
+			before := s.marketValue(ledger, transaction.Date) + ledger.cash
+			if previous > 0 {
+				growth *= before / previous
+			}
+			previous = before + transaction.Amount
+			contributions += transaction.Amount
+			flows = append(flows, cashFlow{date: transaction.Date, amount: -transaction.Amount})
+		}
+
+		switch transaction.Type {
+		case models.TransactionDividend:
+			dividendIncome += transaction.Amount
+			if transaction.Symbol != "" {
+				dividends[transaction.Symbol] += transaction.Amount
+			}
+		case models.TransactionFee:
+			fees -= transaction.Amount
+		}
+		ledger.apply(transaction)
+	}
+
+	marketValue := s.marketValue(ledger, end)
+	endValue := marketValue + ledger.cash
+	if previous > 0 {
+		growth *= endValue / previous
+	}
+	flows = append(flows, cashFlow{date: end, amount: endValue})
+
+	performance := &models.Performance{
+		AccountID:          accountID,
+		Currency:           account.Currency,
+		From:               from.Format(dateLayout),
+		To:                 to.Format(dateLayout),
+		Method:             query.Method,
+		StartValue:         roundCents(startValue),
+		EndValue:           roundCents(endValue),
+		NetContributions:   roundCents(contributions),
+		TimeWeightedReturn: roundCents((growth - 1) * 100),
+		DividendIncome:     roundCents(dividendIncome),
+		Fees:               roundCents(fees),
+		Securities:         []models.SecurityPerformance{},
+	}
+	if days := end.Sub(start).Hours() / 24; days >= 365 {
+		annualized := roundCents((math.Pow(growth, 365/days) - 1) * 100)
+		performance.AnnualizedReturn = &annualized
+	}
+	if rate, ok := xirr(flows); ok {
+		percent := roundCents(rate * 100)
+		performance.MoneyWeightedReturn = &percent
+	}
+
+	symbols := make(map[string]bool)
+	for symbol, lots := range ledger.lots {
+		if len(lots) > 0 {
+			symbols[symbol] = true
+		}
+	}
+	for symbol, gain := range ledger.realized {
+		if gain != realizedAtStart[symbol] {
+			symbols[symbol] = true
+		}
+	}
+	for symbol := range dividends {
+		symbols[symbol] = true
+	}
+
+	var realized, unrealized float64
+	for symbol := range symbols {
+		security := models.SecurityPerformance{
+			Symbol:       symbol,
+			RealizedGain: ledger.realized[symbol] - realizedAtStart[symbol],
+			Dividends:    roundCents(dividends[symbol]),
+		}
+		for _, lot := range ledger.lots[symbol] {
+			security.Quantity += lot.Quantity
+			security.CostBasis += lot.CostBasis
+		}
+		security.MarketValue = security.Quantity * s.priceAt(ledger, symbol, end)
+		security.UnrealizedGain = security.MarketValue - security.CostBasis
+		realized += security.RealizedGain
+		unrealized += security.UnrealizedGain
+
+		security.Quantity = roundQuantity(security.Quantity)
+		security.CostBasis = roundCents(security.CostBasis)
+		security.MarketValue = roundCents(security.MarketValue)
+		security.RealizedGain = roundCents(security.RealizedGain)
+		security.UnrealizedGain = roundCents(security.UnrealizedGain)
+		performance.Securities = append(performance.Securities, security)
+	}
+	sort.Slice(performance.Securities, func(a, b int) bool {
+		return performance.Securities[a].Symbol < performance.Securities[b].Symbol
+	})
+	performance.RealizedGain = roundCents(realized)
+	performance.UnrealizedGain = roundCents(unrealized)
+	return performance, nil
+}
+
+// marketValue values the open positions of a ledger as of t
+func (s *InvestmentService) marketValue(ledger *lotLedger, t time.Time) float64 {
+	value := 0.0
+	for symbol, lots := range ledger.lots {
+		quantity := 0.0
+		for _, lot := range lots {
+			quantity += lot.Quantity
+		}
+		if quantity > 0 {
+			value += quantity * s.priceAt(ledger, symbol, t)
+		}
+	}
+	return value
+}
+
+// priceAt returns the feed price of symbol as of t, or the ledger's last trade price when
+// that is more recent or the feed has none
+func (s *InvestmentService) priceAt(ledger *lotLedger, symbol string, t time.Time) float64 {
+	trade := ledger.lastTrade[symbol]
+	if price, ok := s.prices.PriceAt(symbol, t); ok && !price.AsOf.Before(trade.AsOf) {
+		return price.Price
+	}
+	return trade.Price
+}
+
+// cashFlow is an amount paid into (negative) or out of (positive) a portfolio on a date
+type cashFlow struct {
+	date   time.Time
+	amount float64
+}
+
+// xirr returns the annual rate at which the flows have a net present value of zero. Newton's
+// method usually converges within a few steps; when it does not, bisection takes over. There
+// is no rate unless the flows include both payments in and out.
+func xirr(flows []cashFlow) (float64, bool) {
+	var in, out bool
+	for _, flow := range flows {
+		in = in || flow.amount < 0
+		out = out || flow.amount > 0
+	}
+	if !in || !out {
+		return 0, false
+	}
+
+	first := flows[0].date
+	npv := func(rate float64) (value, derivative float64) {
+		for _, flow := range flows {
+			years := flow.date.Sub(first).Hours() / 24 / 365
+			discount := math.Pow(1+rate, years)
+			value += flow.amount / discount
+			derivative -= years * flow.amount / (discount * (1 + rate))
+		}
+		return value, derivative
+	}
+
+	rate := 0.1
+	for step := 0; step < 50; step++ {
+		value, derivative := npv(rate)
+		if math.Abs(value) < 1e-7 {
+			return rate, true
+		}
+		if derivative == 0 {
+			break
+		}
+		next := rate - value/derivative
+		if next <= -1 || math.IsNaN(next) || math.IsInf(next, 0) {
+			break
+		}
+		rate = next
+	}
+
+	low, high := -0.9999, 100.0
+	lowValue, _ := npv(low)
+	highValue, _ := npv(high)
+	if lowValue*highValue > 0 {
+		return 0, false
+	}
+	for step := 0; step < 200; step++ {
+		mid := (low + high) / 2
+		value, _ := npv(mid)
+		if math.Abs(value) < 1e-7 || high-low < 1e-12 {
+			return mid, true
+		}
+		if value*lowValue < 0 {
+			high = mid
+		} else {
+			low, lowValue = mid, value
+		}
+	}
+	return (low + high) / 2, true
+}
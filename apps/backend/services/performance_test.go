@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// newPerformanceService returns an investment service whose acc_004 also has a year of
+// trading in one security, X, in 2020
+func newPerformanceService(t *testing.T) *InvestmentService {
+	t.Helper()
+
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2020, month, d, 12, 0, 0, 0, time.UTC)
+	}
+	prices := NewPriceFeed([]models.Price{
+		{Symbol: "X", Price: 100, Currency: "USD", AsOf: time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{Symbol: "X", Price: 110, Currency: "USD", AsOf: time.Date(2020, time.June, 30, 0, 0, 0, 0, time.UTC)},
+		{Symbol: "X", Price: 121, Currency: "USD", AsOf: time.Date(2020, time.December, 31, 0, 0, 0, 0, time.UTC)},
+	})
+
+	transactions := NewTransactionService()
+	for _, transaction := range []*models.Transaction{
+		{ID: "pf_1", AccountID: "acc_004", Description: "Deposit", Amount: 10000, Type: "transfer", Category: "transfer", Date: day(time.January, 1), Status: "completed"},
+		{ID: "pf_2", AccountID: "acc_004", Description: "Buy X", Amount: -10000, Type: "buy", Category: "investment", Date: day(time.January, 2), Status: "completed", Symbol: "X", Quantity: 100, Price: 100},
+		{ID: "pf_3", AccountID: "acc_004", Description: "Deposit", Amount: 11000, Type: "transfer", Category: "transfer", Date: day(time.July, 1), Status: "completed"},
+		{ID: "pf_4", AccountID: "acc_004", Description: "Buy X", Amount: -11000, Type: "buy", Category: "investment", Date: day(time.July, 2), Status: "completed", Symbol: "X", Quantity: 100, Price: 110},
+		{ID: "pf_5", AccountID: "acc_004", Description: "Sell X", Amount: 5750, Type: "sell", Category: "investment", Date: day(time.October, 1), Status: "completed", Symbol: "X", Quantity: 50, Price: 115},
+		{ID: "pf_6", AccountID: "acc_004", Description: "X dividend", Amount: 100, Type: "dividend", Category: "investment", Date: day(time.December, 15), Status: "completed", Symbol: "X"},
+	} {
+		if _, err := transactions.CreateTransaction(context.Background(), transaction); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return NewInvestmentService(NewAccountService(), transactions, prices)
+}
+
+func TestInvestmentService_Performance(t *testing.T) {
+	service := newPerformanceService(t)
+	query := models.PerformanceQuery{
+		From:   time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2020, time.December, 31, 0, 0, 0, 0, time.UTC),
+		Method: models.CostBasisFIFO,
+	}
+
+	performance, err := service.Performance("acc_004", query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 10% in the first half, then 22000 grows to 24000; the July deposit does not count.
+	// 2020 has 366 days, so the annualized return is slightly lower.
+	if performance.TimeWeightedReturn != 20 || performance.AnnualizedReturn == nil || *performance.AnnualizedReturn != 19.94 {
+		t.Errorf("Expected a 20%% time-weighted return, got %+v", performance)
+	}
+	if performance.StartValue != 0 || performance.EndValue != 24000 || performance.NetContributions != 21000 {
+		t.Errorf("Unexpected values: start %v, end %v, contributions %v", performance.StartValue, performance.EndValue, performance.NetContributions)
+	}
+	if performance.MoneyWeightedReturn == nil || *performance.MoneyWeightedReturn < 18 || *performance.MoneyWeightedReturn > 22 {
+		t.Errorf("Expected a money-weighted return near 20%%, got %v", performance.MoneyWeightedReturn)
+	}
+
+	// FIFO sells the 50 from the first lot, bought at 100
+	if performance.RealizedGain != 750 || performance.UnrealizedGain != 2150 || performance.DividendIncome != 100 {
+		t.Errorf("Unexpected FIFO gains %+v", performance)
+	}
+	if len(performance.Securities) != 1 || performance.Securities[0].Symbol != "X" || performance.Securities[0].Quantity != 150 || performance.Securities[0].Dividends != 100 {
+		t.Errorf("Unexpected securities %+v", performance.Securities)
+	}
+
+	// Average cost sells at 105 a unit
+	query.Method = models.CostBasisAverage
+	performance, err = service.Performance("acc_004", query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if performance.RealizedGain != 500 || performance.UnrealizedGain != 2400 || performance.TimeWeightedReturn != 20 {
+		t.Errorf("Unexpected average cost gains %+v", performance)
+	}
+}
+
+func TestInvestmentService_PerformanceSubPeriod(t *testing.T) {
+	service := newPerformanceService(t)
+
+	// The second half starts with 200 X at 110 and contributes nothing
+	performance, err := service.Performance("acc_004", models.PerformanceQuery{
+		From:   time.Date(2020, time.July, 3, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2020, time.December, 31, 0, 0, 0, 0, time.UTC),
+		Method: models.CostBasisFIFO,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if performance.StartValue != 22000 || performance.NetContributions != 0 || performance.TimeWeightedReturn != 9.09 {
+		t.Errorf("Unexpected second half %+v", performance)
+	}
+	if performance.AnnualizedReturn != nil {
+		t.Errorf("Expected no annualized return for half a year, got %v", *performance.AnnualizedReturn)
+	}
+	// Gains realized before the period do not count
+	performance, _ = service.Performance("acc_004", models.PerformanceQuery{
+		From:   time.Date(2020, time.November, 1, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2020, time.December, 31, 0, 0, 0, 0, time.UTC),
+		Method: models.CostBasisFIFO,
+	})
+	if performance.RealizedGain != 0 || performance.DividendIncome != 100 {
+		t.Errorf("Expected only the dividend in November and December, got %+v", performance)
+	}
+}
+
+func TestInvestmentService_PerformanceValidation(t *testing.T) {
+	service := newPerformanceService(t)
+
+	var validation *ValidationError
+	_, err := service.Performance("acc_004", models.PerformanceQuery{
+		From:   time.Date(2020, time.March, 1, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC),
+		Method: "lifo",
+	})
+	if !errors.As(err, &validation) || len(validation.Fields) != 2 {
+		t.Errorf("Expected method and to to be invalid, got %v", err)
+	}
+	if _, err := service.Performance("acc_001", models.PerformanceQuery{Method: models.CostBasisFIFO}); !errors.Is(err, ErrNotInvestmentAccount) {
+		t.Errorf("Expected not_investment_account, got %v", err)
+	}
+}
+
+func TestXIRR(t *testing.T) {
+	start := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	rate, ok := xirr([]cashFlow{
+		{date: start, amount: -1000},
+		{date: start.AddDate(0, 0, 365), amount: 1100},
+	})
+	if !ok || math.Abs(rate-0.1) > 1e-6 {
+		t.Errorf("Expected 10%%, got %v (%v)", rate, ok)
+	}
+
+	if _, ok := xirr([]cashFlow{{date: start, amount: -1000}}); ok {
+		t.Error("Expected no rate without a payment out")
+	}
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// defaultPrices are the prices used without a price feed file
+//
+//go:embed prices.json
+var defaultPrices []byte
+
+// PriceFeed serves security prices. A symbol may have several prices with different as_of
+// times, for valuing past dates. A feed loaded from a file reads it again whenever the file
+// changes, so an external job can update prices in place.
+type PriceFeed struct {
+	path    string
+	mutex   sync.Mutex
+	modTime time.Time
+	prices  map[string][]models.Price // per upper-case symbol, oldest first
+}
+
+// NewPriceFeed creates a new PriceFeed instance with fixed prices
+func NewPriceFeed(prices []models.Price) *PriceFeed {
+	feed := &PriceFeed{}
+	feed.setPrices(prices)
+	return feed
+}
+
+// DefaultPriceFeed returns a feed with the built-in prices
+func DefaultPriceFeed() *PriceFeed {
+	prices, err := parsePrices(defaultPrices)
+	if err != nil {
+		panic(fmt.Sprintf("invalid built-in prices: %v", err))
+	}
+	return NewPriceFeed(prices)
+}
+
+// LoadPriceFeed reads prices from a JSON file in the format of prices.json
+func LoadPriceFeed(path string) (*PriceFeed, error) {
+	feed := &PriceFeed{path: path}
+	if err := feed.reload(); err != nil {
+		return nil, err
+	}
+	return feed, nil
+}
+
+// Price returns the latest price of symbol, if the feed has one
+func (f *PriceFeed) Price(symbol string) (models.Price, bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.reloadIfChanged()
+	history := f.prices[strings.ToUpper(symbol)]
+	if len(history) == 0 {
+		return models.Price{}, false
+	}
+	return history[len(history)-1], true
+}
+
+// PriceAt returns the latest price of symbol as of t, if the feed has one that old
+func (f *PriceFeed) PriceAt(symbol string, t time.Time) (models.Price, bool) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.reloadIfChanged()
+	history := f.prices[strings.ToUpper(symbol)]
+	i := sort.Search(len(history), func(i int) bool { return history[i].AsOf.After(t) })
+	if i == 0 {
+		return models.Price{}, false
+	}
+	return history[i-1], true
+}
+
+// reloadIfChanged reads the feed file again if it changed; the caller holds the mutex
+func (f *PriceFeed) reloadIfChanged() {
+	if f.path != "" {
+		if info, err := os.Stat(f.path); err == nil && !info.ModTime().Equal(f.modTime) {
+			// Keep serving the previous prices if the new file is unreadable
+			if err := f.reload(); err != nil {
+				log.Printf("Price feed %s could not be reloaded: %v", f.path, err)
+			}
+		}
+	}
+}
+
+// reload reads the feed file; the caller holds the mutex unless the feed is not shared yet
+func (f *PriceFeed) reload() error {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return err
+	}
+	prices, err := parsePrices(data)
+	if err != nil {
+		return err
+	}
+
+	f.setPrices(prices)
+	f.modTime = info.ModTime()
+	return nil
+}
+
+// setPrices replaces the prices, keyed by upper-case symbol and ordered by as_of
+func (f *PriceFeed) setPrices(prices []models.Price) {
+	f.prices = make(map[string][]models.Price)
+	for _, price := range prices {
+		price.Symbol = strings.ToUpper(price.Symbol)
+		f.prices[price.Symbol] = append(f.prices[price.Symbol], price)
+	}
+	for _, history := range f.prices {
+		sort.SliceStable(history, func(i, j int) bool { return history[i].AsOf.Before(history[j].AsOf) })
+	}
+}
+
+// parsePrices decodes a price feed file
+func parsePrices(data []byte) ([]models.Price, error) {
+	var file struct {
+		Prices []models.Price `json:"prices"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	for _, price := range file.Prices {
+		if price.Symbol == "" || price.Price < 0 {
+			return nil, fmt.Errorf("invalid price for %q", price.Symbol)
+		}
+	}
+	return file.Prices, nil
+}
@@ -0,0 +1,375 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// maxBalanceObservations bounds the reported balances kept per account
+const maxBalanceObservations = 1000
+
+// balanceObservation is a balance reported by the provider
+type balanceObservation struct {
+	at      time.Time
+	balance float64
+}
+
+// accountLedger is what reconciliation knows about an account
+type accountLedger struct {
+	anchor       models.ReconciliationAnchor
+	observations []balanceObservation // oldest first
+	statements   []models.Statement   // oldest first
+}
+
+// ReconciliationService checks account balances against their transaction history. Each
+// account starts from the balance implied when it was first seen, before any of its
+// transactions, and moves its anchor forward with every reconciled statement.
+type ReconciliationService struct {
+	ledgers         map[string]*accountLedger
+	nextStatementID int
+	mutex           sync.RWMutex
+
+	accounts     AccountReader
+	transactions *TransactionService
+	eventBus     *EventBus
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewReconciliationService creates a new ReconciliationService instance
+func NewReconciliationService(eventBus *EventBus, accounts AccountReader, transactions *TransactionService) *ReconciliationService {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &ReconciliationService{
+		ledgers:      make(map[string]*accountLedger),
+		accounts:     accounts,
+		transactions: transactions,
+		eventBus:     eventBus,
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+}
+
+// SeedBalances records the opening anchor of accounts, taking their current balances as
+// right. Accounts seen later are seeded on first use.
+func (s *ReconciliationService) SeedBalances(accounts []*models.Account) error {
+	all, err := s.transactions.GetAllTransactions(nil)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	for _, account := range accounts {
+		if account.AccountType != "investment" {
+			s.ledger(account, all, now)
+		}
+	}
+	return nil
+}
+
+// Reconcile compares the account's reported balance with its anchor plus the transactions
+// since, and finds when the current discrepancy first appeared
+func (s *ReconciliationService) Reconcile(accountID string) (*models.Reconciliation, error) {
+	account, err := s.reconcilableAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+	all, err := s.transactions.GetAllTransactions(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	ledger := s.ledger(account, all, time.Now())
+	anchor := ledger.anchor
+	observations := append([]balanceObservation(nil), ledger.observations...)
+	s.mutex.Unlock()
+
+	transactions := transactionsSince(all, accountID, anchor.Date)
+	reconciliation := &models.Reconciliation{
+		AccountID:        accountID,
+		Currency:         account.Currency,
+		Anchor:           anchor,
+		TransactionCount: len(transactions),
+		History:          []models.BalanceCheck{},
+	}
+
+	// Each reported balance is checked against the transactions dated up to it
+	running, next := anchor.Balance, 0
+	for _, observation := range observations {
+		if observation.at.Before(anchor.Date) {
+			continue
+		}
+		for next < len(transactions) && !transactions[next].Date.After(observation.at) {
+			running += transactions[next].Amount
+			next++
+		}
+		reconciliation.History = append(reconciliation.History, balanceCheck(observation.at, observation.balance, running))
+	}
+
+	// The current balance is checked against every transaction, and counts as reported even
+	// when the event for it has not arrived yet
+	for _, transaction := range transactions[next:] {
+		running += transaction.Amount
+	}
+	current := balanceCheck(account.LastUpdated, account.Balance, running)
+	if current.At.Before(anchor.Date) {
+		current.At = anchor.Date
+	}
+	if n := len(reconciliation.History); n > 0 && reconciliation.History[n-1].Reported == current.Reported {
+		current.At = reconciliation.History[n-1].At
+		reconciliation.History[n-1] = current
+	} else {
+		reconciliation.History = append(reconciliation.History, current)
+	}
+
+	reconciliation.ReportedBalance = current.Reported
+	reconciliation.CalculatedBalance = current.Calculated
+	reconciliation.Discrepancy = current.Discrepancy
+	reconciliation.Status = models.ReconciliationBalanced
+	if current.Discrepancy != 0 {
+		reconciliation.Status = models.ReconciliationDiscrepancy
+		for i := len(reconciliation.History) - 1; i >= 0 && reconciliation.History[i].Discrepancy != 0; i-- {
+			since := reconciliation.History[i].At
+			reconciliation.DiscrepancySince = &since
+		}
+	}
+	return reconciliation, nil
+}
+
+// GetStatements returns the reconciled statements of an account, oldest first
+func (s *ReconciliationService) GetStatements(accountID string) ([]models.Statement, error) {
+	if _, err := s.reconcilableAccount(accountID); err != nil {
+		return nil, err
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	statements := []models.Statement{}
+	if ledger, exists := s.ledgers[accountID]; exists {
+		statements = append(statements, ledger.statements...)
+	}
+	return statements, nil
+}
+
+// ReconcileStatement marks a statement as reconciled. Its closing balance becomes the anchor,
+// so later reconciliations only count transactions after its end date. Any difference from
+// the calculated balance is reported as the statement's adjustment.
+func (s *ReconciliationService) ReconcileStatement(accountID string, request *models.StatementRequest) (*models.Statement, error) {
+	account, err := s.reconcilableAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	validation := &ValidationError{}
+	var endDate time.Time
+	if request.EndDate == "" {
+		validation.Add("end_date", CodeRequired, "end_date is required")
+	} else if endDate, err = time.Parse(dateLayout, request.EndDate); err != nil {
+		validation.Add("end_date", CodeInvalidFormat, "end_date must be a date in YYYY-MM-DD format")
+	}
+	if request.Balance == nil {
+		validation.Add("balance", CodeRequired, "balance is required")
+	}
+	if err := validation.Err(); err != nil {
+		return nil, err
+	}
+
+	all, err := s.transactions.GetAllTransactions(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	ledger := s.ledger(account, all, now)
+	next := endDate.AddDate(0, 0, 1)
+	if !endDate.Before(startOfDay(now.UTC())) {
+		validation.Add("end_date", CodeOutOfRange, "end_date must be before today")
+	} else if ledger.anchor.Source == models.AnchorStatement && !next.After(ledger.anchor.Date) {
+		validation.Addf("end_date", CodeOutOfRange, "end_date must be after %s, the end of statement %s", ledger.anchor.Date.AddDate(0, 0, -1).Format(dateLayout), ledger.anchor.StatementID)
+	}
+	if err := validation.Err(); err != nil {
+		return nil, err
+	}
+
+	// The opening anchor comes before every transaction, so a statement may end before it too
+	calculated := ledger.anchor.Balance
+	for _, transaction := range transactionsSince(all, accountID, ledger.anchor.Date) {
+		if transaction.Date.Before(next) {
+			calculated += transaction.Amount
+		}
+	}
+
+	s.nextStatementID++
+	statement := models.Statement{
+		ID:                fmt.Sprintf("stmt_%03d", s.nextStatementID),
+		AccountID:         accountID,
+		EndDate:           request.EndDate,
+		Balance:           roundCents(*request.Balance),
+		CalculatedBalance: roundCents(calculated),
+		ReconciledAt:      now,
+	}
+	statement.Adjustment = roundCents(statement.Balance - statement.CalculatedBalance)
+
+	ledger.statements = append(ledger.statements, statement)
+	ledger.anchor = models.ReconciliationAnchor{
+		Source:      models.AnchorStatement,
+		StatementID: statement.ID,
+		Date:        next,
+		Balance:     statement.Balance,
+	}
+	return &statement, nil
+}
+
+// Start subscribes to the event bus and records every balance the provider reports
+func (s *ReconciliationService) Start() {
+	if s.eventBus == nil {
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.eventBus.Consume(s.ctx, s.handleEvent)
+	}()
+}
+
+// Stop stops recording balances and waits for the consumer or ctx to expire
+func (s *ReconciliationService) Stop(ctx context.Context) error {
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// handleEvent records the balance of an updated account
+func (s *ReconciliationService) handleEvent(event models.Event) {
+	if account, ok := event.Data.(models.Account); ok && event.Type == models.EventAccountUpdated {
+		s.RecordBalance(account)
+	}
+}
+
+// RecordBalance records a reported balance, if it changed since the last one
+func (s *ReconciliationService) RecordBalance(account models.Account) {
+	if account.AccountType == "investment" {
+		return
+	}
+
+	s.mutex.RLock()
+	_, seeded := s.ledgers[account.ID]
+	s.mutex.RUnlock()
+	var all []*models.Transaction
+	if !seeded {
+		var err error
+		if all, err = s.transactions.GetAllTransactions(nil); err != nil {
+			return
+		}
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	at := account.LastUpdated
+	if at.IsZero() {
+		at = time.Now()
+	}
+	ledger := s.ledger(&account, all, at)
+	if n := len(ledger.observations); n > 0 && ledger.observations[n-1].balance == account.Balance {
+		return
+	}
+	ledger.observations = append(ledger.observations, balanceObservation{at: at, balance: account.Balance})
+	if len(ledger.observations) > maxBalanceObservations {
+		ledger.observations = ledger.observations[len(ledger.observations)-maxBalanceObservations:]
+	}
+}
+
+// reconcilableAccount returns the account, unless it is valued from holdings
+func (s *ReconciliationService) reconcilableAccount(accountID string) (*models.Account, error) {
+	account, err := s.accounts.GetAccountByID(accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.AccountType == "investment" {
+		return nil, ErrNotReconcilableAccount
+	}
+	return account, nil
+}
+
+// ledger returns the ledger of account, seeding it when the account is first seen: the
+// opening anchor is the reported balance less every transaction, at the start of the day
+// of the earliest. The caller must hold the write lock.
+func (s *ReconciliationService) ledger(account *models.Account, all []*models.Transaction, now time.Time) *accountLedger {
+	if ledger, exists := s.ledgers[account.ID]; exists {
+		return ledger
+	}
+
+	opening, start := account.Balance, startOfDay(now)
+	for _, transaction := range transactionsSince(all, account.ID, time.Time{}) {
+		opening -= transaction.Amount
+		if transaction.Date.Before(start) {
+			start = startOfDay(transaction.Date)
+		}
+	}
+
+	ledger := &accountLedger{
+		anchor: models.ReconciliationAnchor{
+			Source:  models.AnchorOpening,
+			Date:    start,
+			Balance: roundCents(opening),
+		},
+		observations: []balanceObservation{{at: now, balance: account.Balance}},
+	}
+	s.ledgers[account.ID] = ledger
+	return ledger
+}
+
+// transactionsSince returns the transactions of an account dated from since on that move its
+// balance, oldest first. Failed and cancelled transactions never do.
+func transactionsSince(all []*models.Transaction, accountID string, since time.Time) []*models.Transaction {
+	var result []*models.Transaction
+	for _, transaction := range all {
+		if transaction.AccountID != accountID || transaction.Date.Before(since) {
+			continue
+		}
+		if transaction.Status == "failed" || transaction.Status == "cancelled" {
+			continue
+		}
+		result = append(result, transaction)
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Date.Before(result[j].Date) })
+	return result
+}
+
+// balanceCheck compares a reported balance with a calculated one
+func balanceCheck(at time.Time, reported, calculated float64) models.BalanceCheck {
+	return models.BalanceCheck{
+		At:          at,
+		Reported:    roundCents(reported),
+		Calculated:  roundCents(calculated),
+		Discrepancy: roundCents(reported - calculated),
+	}
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// fakeAccounts serves accounts whose balances a test sets directly
+type fakeAccounts map[string]*models.Account
+
+func (f fakeAccounts) GetAllAccounts() ([]*models.Account, error) {
+	accounts := make([]*models.Account, 0, len(f))
+	for _, account := range f {
+		copied := *account
+		accounts = append(accounts, &copied)
+	}
+	return accounts, nil
+}
+
+func (f fakeAccounts) GetAccountByID(id string) (*models.Account, error) {
+	account, exists := f[id]
+	if !exists {
+		return nil, ErrAccountNotFound
+	}
+	copied := *account
+	return &copied, nil
+}
+
+func newReconciliationFixture(t *testing.T) (*ReconciliationService, fakeAccounts, *TransactionService) {
+	t.Helper()
+
+	accounts := fakeAccounts{
+		"acc_001": {ID: "acc_001", AccountType: "checking", Balance: 2500.75, Currency: "USD", LastUpdated: time.Now().Add(-2 * time.Hour)},
+		"acc_004": {ID: "acc_004", AccountType: "investment", Balance: 45000.25, Currency: "USD"},
+	}
+	transactions := NewTransactionService()
+	service := NewReconciliationService(nil, accounts, transactions)
+	all, _ := accounts.GetAllAccounts()
+	if err := service.SeedBalances(all); err != nil {
+		t.Fatal(err)
+	}
+	return service, accounts, transactions
+}
+
+func TestReconciliationService_Reconcile(t *testing.T) {
+	service, accounts, transactions := newReconciliationFixture(t)
+
+	// The opening anchor is the seeded balance less the five acc_001 transactions
+	reconciliation, err := service.Reconcile("acc_001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reconciliation.Status != models.ReconciliationBalanced || reconciliation.Anchor.Source != models.AnchorOpening ||
+		reconciliation.Anchor.Balance != -2148.75 || reconciliation.TransactionCount != 5 || reconciliation.DiscrepancySince != nil {
+		t.Errorf("Unexpected reconciliation %+v", reconciliation)
+	}
+
+	// A refresh that moves the balance without a transaction is a discrepancy from then on
+	refreshed := time.Now().Add(time.Minute)
+	accounts["acc_001"].Balance = 2510.75
+	accounts["acc_001"].LastUpdated = refreshed
+	service.RecordBalance(*accounts["acc_001"])
+	accounts["acc_001"].Balance = 2512.25
+	accounts["acc_001"].LastUpdated = refreshed.Add(time.Minute)
+	service.RecordBalance(*accounts["acc_001"])
+
+	reconciliation, _ = service.Reconcile("acc_001")
+	if reconciliation.Status != models.ReconciliationDiscrepancy || reconciliation.Discrepancy != 11.5 ||
+		reconciliation.DiscrepancySince == nil || !reconciliation.DiscrepancySince.Equal(refreshed) || len(reconciliation.History) != 3 {
+		t.Errorf("Expected a discrepancy of 11.50 since the first refresh, got %+v", reconciliation)
+	}
+
+	// The missing transaction arriving late clears it
+	if _, err := transactions.CreateTransaction(context.Background(), &models.Transaction{ID: "txn_late", AccountID: "acc_001", Amount: 11.5, Date: refreshed, Status: "completed"}); err != nil {
+		t.Fatal(err)
+	}
+	reconciliation, _ = service.Reconcile("acc_001")
+	if reconciliation.Status != models.ReconciliationBalanced || reconciliation.DiscrepancySince != nil {
+		t.Errorf("Expected the late transaction to balance the account, got %+v", reconciliation)
+	}
+
+	// The current balance counts even before its event is recorded
+	accounts["acc_001"].Balance = 2500
+	reconciliation, _ = service.Reconcile("acc_001")
+	if reconciliation.Discrepancy != -12.25 || reconciliation.DiscrepancySince == nil {
+		t.Errorf("Expected the unrecorded balance to be checked, got %+v", reconciliation)
+	}
+
+	if _, err := service.Reconcile("acc_004"); !errors.Is(err, ErrNotReconcilableAccount) {
+		t.Errorf("Expected not_reconcilable_account, got %v", err)
+	}
+	if _, err := service.Reconcile("missing"); !errors.Is(err, ErrAccountNotFound) {
+		t.Errorf("Expected account_not_found, got %v", err)
+	}
+}
+
+func TestReconciliationService_ReconcileStatement(t *testing.T) {
+	service, _, _ := newReconciliationFixture(t)
+	today := time.Now().UTC()
+	endDate := today.AddDate(0, 0, -10).Format("2006-01-02")
+
+	// Nothing happened before the transactions, so the calculated balance is the opening one
+	balance := -2150.0
+	statement, err := service.ReconcileStatement("acc_001", &models.StatementRequest{EndDate: endDate, Balance: &balance})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if statement.ID != "stmt_001" || statement.CalculatedBalance != -2148.75 || statement.Adjustment != -1.25 {
+		t.Errorf("Unexpected statement %+v", statement)
+	}
+
+	// The statement anchors later reconciliations, so its adjustment carries forward
+	reconciliation, _ := service.Reconcile("acc_001")
+	if reconciliation.Anchor.Source != models.AnchorStatement || reconciliation.Anchor.StatementID != "stmt_001" || reconciliation.Discrepancy != 1.25 {
+		t.Errorf("Unexpected reconciliation %+v", reconciliation)
+	}
+
+	var validation *ValidationError
+	for name, request := range map[string]models.StatementRequest{
+		"before the last statement": {EndDate: endDate, Balance: &balance},
+		"today":                     {EndDate: today.Format("2006-01-02"), Balance: &balance},
+		"no balance":                {EndDate: today.AddDate(0, 0, -1).Format("2006-01-02")},
+		"bad date":                  {EndDate: "yesterday", Balance: &balance},
+	} {
+		if _, err := service.ReconcileStatement("acc_001", &request); !errors.As(err, &validation) {
+			t.Errorf("%s: expected a validation error, got %v", name, err)
+		}
+	}
+
+	statements, err := service.GetStatements("acc_001")
+	if err != nil || len(statements) != 1 {
+		t.Errorf("Expected one statement, got %+v (%v)", statements, err)
+	}
+	if _, err := service.ReconcileStatement("acc_004", &models.StatementRequest{EndDate: endDate, Balance: &balance}); !errors.Is(err, ErrNotReconcilableAccount) {
+		t.Errorf("Expected not_reconcilable_account, got %v", err)
+	}
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"sync"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// AccountRefresher is the subset of AccountService used by the sync scheduler
+type AccountRefresher interface {
+	GetAllAccounts() ([]*models.Account, error)
+	RefreshAccount(ctx context.Context, accountID string) (*models.AccountRefreshResponse, error)
+}
+
+// SyncConfig controls how often accounts are refreshed in the background
+type SyncConfig struct {
+	Enabled     bool
+	Interval    time.Duration // time between sync cycles
+	Jitter      time.Duration // random delay added to each cycle
+	MinAge      time.Duration // accounts updated more recently than this are skipped
+	BaseBackoff time.Duration // delay after the first failure, doubled on each retry
+	MaxBackoff  time.Duration // upper bound for the failure delay
+}
+
+// DefaultSyncConfig returns the configuration used when nothing is overridden
+func DefaultSyncConfig() SyncConfig {
+	return SyncConfig{
+		Enabled:     true,
+		Interval:    15 * time.Minute,
+		Jitter:      30 * time.Second,
+		MinAge:      10 * time.Minute,
+		BaseBackoff: 1 * time.Minute,
+		MaxBackoff:  1 * time.Hour,
+	}
+}
+
+// syncState tracks failures for a single account
+type syncState struct {
+	failures    int
+	nextAttempt time.Time
+}
+
+// SyncScheduler periodically refreshes every active account
+type SyncScheduler struct {
+	refresher AccountRefresher
+	config    SyncConfig
+
+	state map[string]*syncState
+	mutex sync.Mutex
+
+	now    func() time.Time
+	jitter func(time.Duration) time.Duration
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewSyncScheduler creates a new SyncScheduler instance
+func NewSyncScheduler(refresher AccountRefresher, config SyncConfig) *SyncScheduler {
+	return &SyncScheduler{
+		refresher: refresher,
+		config:    config,
+		state:     make(map[string]*syncState),
+		now:       time.Now,
+		jitter: func(max time.Duration) time.Duration {
+			if max <= 0 {
+				return 0
+			}
+			return time.Duration(rand.Int63n(int64(max))) // #nosec G404 -- jitter does not need crypto randomness
+		},
+	}
+}
+
+// Start launches the background sync loop; it returns immediately
+func (s *SyncScheduler) Start() {
+	if !s.config.Enabled || s.config.Interval <= 0 || s.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go s.run(ctx)
+	log.Printf("[sync] scheduler started (interval %s, jitter %s)", s.config.Interval, s.config.Jitter)
+}
+
+// Stop cancels the sync loop and waits for the current cycle to finish or ctx to expire
+func (s *SyncScheduler) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+
+	s.cancel()
+	select {
+	case <-s.done:
+		log.Println("[sync] scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run waits for the interval plus jitter and then syncs, until ctx is cancelled
+func (s *SyncScheduler) run(ctx context.Context) {
+	defer close(s.done)
+
+	for {
+		timer := time.NewTimer(s.config.Interval + s.jitter(s.config.Jitter))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.syncOnce(ctx)
+		}
+	}
+}
+
+// syncOnce refreshes every account that is due and returns how many were refreshed
+func (s *SyncScheduler) syncOnce(ctx context.Context) int {
+	accounts, err := s.refresher.GetAllAccounts()
+	if err != nil {
+		log.Printf("[sync] failed to list accounts: %v", err)
+		return 0
+	}
+
+	// Background refreshes are attributed to the scheduler in the audit log
+	syncCtx := WithAuditActor(ctx, "system:sync", "")
+
+	refreshed := 0
+	for _, account := range accounts {
+		if ctx.Err() != nil {
+			break
+		}
+
+		if !s.isDue(account) {
+			continue
+		}
+
+		if _, err := s.refresher.RefreshAccount(syncCtx, account.ID); err != nil {
+			delay := s.recordFailure(account.ID)
+			log.Printf("[sync] refresh of %s failed, retrying in %s: %v", account.ID, delay, err)
+			continue
+		}
+
+		s.recordSuccess(account.ID)
+		refreshed++
+	}
+
+	return refreshed
+}
+
+// isDue reports whether an account should be refreshed in the current cycle
+func (s *SyncScheduler) isDue(account *models.Account) bool {
+	if !account.IsActive {
+		return false
+	}
+
+	now := s.now()
+	if s.config.MinAge > 0 && now.Sub(account.LastUpdated) < s.config.MinAge {
+		return false
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if state, exists := s.state[account.ID]; exists && now.Before(state.nextAttempt) {
+		return false
+	}
+
+	return true
+}
+
+// recordFailure bumps the failure count for an account and returns the backoff applied
+func (s *SyncScheduler) recordFailure(accountID string) time.Duration {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	state, exists := s.state[accountID]
+	if !exists {
+		state = &syncState{}
+		s.state[accountID] = state
+	}
+	state.failures++
+
+	delay := s.config.BaseBackoff
+	for i := 1; i < state.failures && delay < s.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if s.config.MaxBackoff > 0 && delay > s.config.MaxBackoff {
+		delay = s.config.MaxBackoff
+	}
+
+	state.nextAttempt = s.now().Add(delay)
+	return delay
+}
+
+// recordSuccess clears any backoff for an account
+func (s *SyncScheduler) recordSuccess(accountID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.state, accountID)
+}
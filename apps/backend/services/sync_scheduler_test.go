@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// fakeRefresher records refresh calls and fails for the configured accounts
+type fakeRefresher struct {
+	accounts []*models.Account
+	failing  map[string]bool
+	calls    map[string]int
+	mutex    sync.Mutex
+}
+
+func (f *fakeRefresher) GetAllAccounts() ([]*models.Account, error) {
+	return f.accounts, nil
+}
+
+func (f *fakeRefresher) RefreshAccount(ctx context.Context, accountID string) (*models.AccountRefreshResponse, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.calls[accountID]++
+	if f.failing[accountID] {
+		return nil, errors.New("upstream unavailable")
+	}
+	return &models.AccountRefreshResponse{AccountID: accountID, Success: true}, nil
+}
+
+func newTestScheduler(refresher AccountRefresher, now *time.Time) *SyncScheduler {
+	config := DefaultSyncConfig()
+	config.MinAge = 10 * time.Minute
+	config.BaseBackoff = time.Minute
+	config.MaxBackoff = 4 * time.Minute
+
+	scheduler := NewSyncScheduler(refresher, config)
+	scheduler.now = func() time.Time { return *now }
+	return scheduler
+}
+
+func TestSyncScheduler_SkipsInactiveAndRecentAccounts(t *testing.T) {
+	now := time.Now()
+	refresher := &fakeRefresher{
+		accounts: []*models.Account{
+			{ID: "stale", IsActive: true, LastUpdated: now.Add(-time.Hour)},
+			{ID: "recent", IsActive: true, LastUpdated: now.Add(-time.Minute)},
+			{ID: "inactive", IsActive: false, LastUpdated: now.Add(-time.Hour)},
+		},
+		calls: make(map[string]int),
+	}
+	scheduler := newTestScheduler(refresher, &now)
+
+	if refreshed := scheduler.syncOnce(context.Background()); refreshed != 1 {
+		t.Errorf("Expected 1 account refreshed, got %v", refreshed)
+	}
+
+	if refresher.calls["stale"] != 1 {
+		t.Errorf("Expected stale account to be refreshed once, got %v", refresher.calls["stale"])
+	}
+	if refresher.calls["recent"] != 0 || refresher.calls["inactive"] != 0 {
+		t.Errorf("Expected recent and inactive accounts to be skipped, got %v", refresher.calls)
+	}
+}
+
+func TestSyncScheduler_BacksOffOnFailure(t *testing.T) {
+	now := time.Now()
+	refresher := &fakeRefresher{
+		accounts: []*models.Account{
+			{ID: "broken", IsActive: true, LastUpdated: now.Add(-time.Hour)},
+		},
+		failing: map[string]bool{"broken": true},
+		calls:   make(map[string]int),
+	}
+	scheduler := newTestScheduler(refresher, &now)
+
+	scheduler.syncOnce(context.Background())
+	scheduler.syncOnce(context.Background())
+	if refresher.calls["broken"] != 1 {
+		t.Errorf("Expected retry to wait for backoff, got %v calls", refresher.calls["broken"])
+	}
+
+	// Second failure doubles the delay to two minutes
+	now = now.Add(61 * time.Second)
+	scheduler.syncOnce(context.Background())
+	now = now.Add(61 * time.Second)
+	scheduler.syncOnce(context.Background())
+	if refresher.calls["broken"] != 2 {
+		t.Errorf("Expected backoff to double after second failure, got %v calls", refresher.calls["broken"])
+	}
+
+	// Backoff is capped at MaxBackoff
+	for i := 0; i < 5; i++ {
+		scheduler.recordFailure("broken")
+	}
+	if delay := scheduler.recordFailure("broken"); delay != 4*time.Minute {
+		t.Errorf("Expected backoff to be capped at 4m, got %v", delay)
+	}
+
+	// A successful refresh clears the backoff
+	refresher.failing["broken"] = false
+	scheduler.recordSuccess("broken")
+	scheduler.syncOnce(context.Background())
+	if refresher.calls["broken"] != 3 {
+		t.Errorf("Expected refresh after backoff was cleared, got %v calls", refresher.calls["broken"])
+	}
+}
+
+func TestSyncScheduler_StartStop(t *testing.T) {
+	refresher := &fakeRefresher{calls: make(map[string]int)}
+	config := DefaultSyncConfig()
+	config.Interval = time.Millisecond
+	config.Jitter = time.Millisecond
+
+	scheduler := NewSyncScheduler(refresher, config)
+	scheduler.Start()
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := scheduler.Stop(ctx); err != nil {
+		t.Errorf("Expected scheduler to stop cleanly, got %v", err)
+	}
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// maxFileNameLength caps the stored name of an attachment
+const maxFileNameLength = 255
+
+// AddAttachment stores content as an attachment of a transaction. The content type is
+// detected from the content, and both it and the size must be within the configured limits.
+func (s *TransactionService) AddAttachment(ctx context.Context, transactionID, fileName string, content io.Reader) (*models.Attachment, error) {
+	s.mutex.RLock()
+	store, config := s.attachments, s.attachmentConfig
+	current, exists := s.transactions[transactionID]
+	s.mutex.RUnlock()
+	if !exists {
+		return nil, ErrTransactionNotFound
+	}
+	if store == nil {
+		return nil, ErrAttachmentStoreUnavailable
+	}
+
+	data, err := io.ReadAll(io.LimitReader(content, config.MaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+
+	validation := &ValidationError{}
+	contentType := detectContentType(data)
+	switch {
+	case len(data) == 0:
+		validation.Add("file", CodeRequired, "file must not be empty")
+	case int64(len(data)) > config.MaxBytes:
+		validation.Addf("file", CodeOutOfRange, "file must be at most %d bytes", config.MaxBytes)
+	case !allowedContentType(config, contentType):
+		validation.Addf("file", CodeNotAllowed, "%s files are not allowed; use one of %s", contentType, strings.Join(config.AllowedTypes, ", "))
+	}
+	if len(current.Attachments) >= config.MaxPerTransaction {
+		validation.Addf("file", CodeOutOfRange, "a transaction can have at most %d attachments", config.MaxPerTransaction)
+	}
+	if err := validation.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	s.nextAttachmentID++
+	attachment := models.Attachment{
+		ID:          fmt.Sprintf("att_%03d", s.nextAttachmentID),
+		FileName:    cleanFileName(fileName),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		UploadedAt:  time.Now(),
+	}
+	s.mutex.Unlock()
+
+	// Upload without holding the lock, then record the attachment on the latest version
+	key := attachmentKey(transactionID, attachment.ID)
+	if err := store.Put(ctx, key, contentType, data); err != nil {
+		return nil, ErrAttachmentStoreUnavailable.Wrap(err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	current = s.transactions[transactionID]
+	if len(current.Attachments) >= config.MaxPerTransaction {
+		_ = store.Delete(ctx, key)
+		validation.Addf("file", CodeOutOfRange, "a transaction can have at most %d attachments", config.MaxPerTransaction)
+		return nil, validation
+	}
+
+	transaction := copyTransaction(current)
+	transaction.Attachments = append(transaction.Attachments, attachment)
+	transaction.Version++
+	s.transactions[transactionID] = transaction
+
+	recordAudit(ctx, s.audit, models.AuditTransactionUpdated, "transaction", transactionID, *current, *transaction)
+	publishEvent(s.events, models.EventTransactionUpdated, *transaction)
+
+	return &attachment, nil
+}
+
+// GetAttachment returns an attachment of a transaction and its content, which the caller
+// must close
+func (s *TransactionService) GetAttachment(ctx context.Context, transactionID, attachmentID string) (*models.Attachment, io.ReadCloser, error) {
+	s.mutex.RLock()
+	store := s.attachments
+	attachment, err := s.findAttachment(transactionID, attachmentID)
+	s.mutex.RUnlock()
+	if err != nil {
+		return nil, nil, err
+	}
+	if store == nil {
+		return nil, nil, ErrAttachmentStoreUnavailable
+	}
+
+	content, err := store.Get(ctx, attachmentKey(transactionID, attachmentID))
+	if errors.Is(err, errAttachmentMissing) {
+		return nil, nil, ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, nil, ErrAttachmentStoreUnavailable.Wrap(err)
+	}
+	return attachment, content, nil
+}
+
+// DeleteAttachment removes an attachment from a transaction and its content from the store
+func (s *TransactionService) DeleteAttachment(ctx context.Context, transactionID, attachmentID string) error {
+	s.mutex.RLock()
+	store := s.attachments
+	_, err := s.findAttachment(transactionID, attachmentID)
+	s.mutex.RUnlock()
+	if err != nil {
+		return err
+	}
+	if store == nil {
+		return ErrAttachmentStoreUnavailable
+	}
+
+	if err := store.Delete(ctx, attachmentKey(transactionID, attachmentID)); err != nil {
+		return ErrAttachmentStoreUnavailable.Wrap(err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	current := s.transactions[transactionID]
+	transaction := copyTransaction(current)
+	transaction.Attachments = transaction.Attachments[:0]
+	for _, attachment := range current.Attachments {
+		if attachment.ID != attachmentID {
+			transaction.Attachments = append(transaction.Attachments, attachment)
+		}
+	}
+	if len(transaction.Attachments) == len(current.Attachments) {
+		return ErrAttachmentNotFound
+	}
+	if len(transaction.Attachments) == 0 {
+		transaction.Attachments = nil
+	}
+	transaction.Version++
+	s.transactions[transactionID] = transaction
+
+	recordAudit(ctx, s.audit, models.AuditTransactionUpdated, "transaction", transactionID, *current, *transaction)
+	publishEvent(s.events, models.EventTransactionUpdated, *transaction)
+
+	return nil
+}
+
+// findAttachment returns a copy of an attachment of a transaction. The caller must hold the
+// lock.
+func (s *TransactionService) findAttachment(transactionID, attachmentID string) (*models.Attachment, error) {
+	transaction, exists := s.transactions[transactionID]
+	if !exists {
+		return nil, ErrTransactionNotFound
+	}
+	for _, attachment := range transaction.Attachments {
+		if attachment.ID == attachmentID {
+			copied := attachment
+			return &copied, nil
+		}
+	}
+	return nil, ErrAttachmentNotFound
+}
+
+// attachmentKey is where the content of an attachment is kept in the store
+func attachmentKey(transactionID, attachmentID string) string {
+	return transactionID + "/" + attachmentID
+}
+
+// detectContentType sniffs the media type of data, without parameters such as charset
+func detectContentType(data []byte) string {
+	contentType := http.DetectContentType(data)
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return contentType
+}
+
+// allowedContentType reports whether config accepts contentType
+func allowedContentType(config AttachmentConfig, contentType string) bool {
+	for _, allowed := range config.AllowedTypes {
+		if strings.EqualFold(allowed, contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanFileName keeps the base name of an uploaded file, dropping any directories and
+// control characters
+func cleanFileName(fileName string) string {
+	fileName = path.Base(strings.ReplaceAll(fileName, "\\", "/"))
+	fileName = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, fileName)
+	if fileName == "" || fileName == "." || fileName == "/" {
+		return "attachment"
+	}
+	if runes := []rune(fileName); len(runes) > maxFileNameLength {
+		fileName = string(runes[:maxFileNameLength])
+	}
+	return fileName
+}
+
+// AttachmentLimits returns the limits uploads are checked against
+func (s *TransactionService) AttachmentLimits() AttachmentConfig {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.attachmentConfig
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// pdf is the start of a PDF file, enough for its type to be detected
+var pdf = []byte("%PDF-1.7\n%receipt\n")
+
+func TestTransactionService_Attachments(t *testing.T) {
+	service := NewTransactionService()
+	config := DefaultAttachmentConfig()
+	config.MaxBytes = 64
+	config.MaxPerTransaction = 2
+	service.SetAttachmentStore(NewLocalAttachmentStore(t.TempDir()), config)
+	ctx := context.Background()
+
+	attachment, err := service.AddAttachment(ctx, "txn_001", `C:\scans\lunch.pdf`, bytes.NewReader(pdf))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attachment.ID != "att_001" || attachment.FileName != "lunch.pdf" || attachment.ContentType != "application/pdf" || attachment.Size != int64(len(pdf)) {
+		t.Errorf("Unexpected attachment %+v", attachment)
+	}
+	stored, _ := service.GetTransactionByID("txn_001")
+	if len(stored.Attachments) != 1 || stored.Version != 2 {
+		t.Errorf("Expected the attachment at version 2, got %+v", stored)
+	}
+
+	found, content, err := service.GetAttachment(ctx, "txn_001", attachment.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := io.ReadAll(content)
+	content.Close()
+	if !bytes.Equal(data, pdf) || found.FileName != "lunch.pdf" {
+		t.Errorf("Expected the uploaded content back, got %q for %+v", data, found)
+	}
+
+	var validation *ValidationError
+	for name, file := range map[string][]byte{
+		"empty":     nil,
+		"too large": append(append([]byte{}, pdf...), bytes.Repeat([]byte{' '}, 64)...),
+		"text":      []byte("just some text"),
+	} {
+		if _, err := service.AddAttachment(ctx, "txn_001", "file", bytes.NewReader(file)); !errors.As(err, &validation) {
+			t.Errorf("%s: expected a validation error, got %v", name, err)
+		}
+	}
+
+	if _, err := service.AddAttachment(ctx, "txn_001", "second.pdf", bytes.NewReader(pdf)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := service.AddAttachment(ctx, "txn_001", "third.pdf", bytes.NewReader(pdf)); !errors.As(err, &validation) {
+		t.Errorf("Expected the per-transaction limit to apply, got %v", err)
+	}
+	if _, err := service.AddAttachment(ctx, "missing", "file.pdf", bytes.NewReader(pdf)); !errors.Is(err, ErrTransactionNotFound) {
+		t.Errorf("Expected transaction_not_found, got %v", err)
+	}
+
+	if err := service.DeleteAttachment(ctx, "txn_001", attachment.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := service.GetAttachment(ctx, "txn_001", attachment.ID); !errors.Is(err, ErrAttachmentNotFound) {
+		t.Errorf("Expected attachment_not_found after delete, got %v", err)
+	}
+	if err := service.DeleteAttachment(ctx, "txn_002", attachment.ID); !errors.Is(err, ErrAttachmentNotFound) {
+		t.Errorf("Expected attachment_not_found on another transaction, got %v", err)
+	}
+}
+
+func TestTransactionService_AttachmentsWithoutStore(t *testing.T) {
+	service := NewTransactionService()
+
+	if _, err := service.AddAttachment(context.Background(), "txn_001", "file.pdf", bytes.NewReader(pdf)); !errors.Is(err, ErrAttachmentStoreUnavailable) {
+		t.Errorf("Expected attachment_store_unavailable, got %v", err)
+	}
+}
+
+func TestCleanFileName(t *testing.T) {
+	tests := map[string]string{
+		"receipt.pdf":             "receipt.pdf",
+		"../../etc/passwd":        "passwd",
+		`C:\Users\me\receipt.png`: "receipt.png",
+		"bad\r\nname.pdf":         "badname.pdf",
+		"":                        "attachment",
+		"dir/":                    "dir",
+		strings.Repeat("a", 300):  strings.Repeat("a", maxFileNameLength),
+	}
+	for input, want := range tests {
+		if got := cleanFileName(input); got != want {
+			t.Errorf("cleanFileName(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -1,30 +1,100 @@
 package services
 
 import (
-	"errors"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"reflect"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"financial-aggregator-api/backend/models"
 )
 
+// Limits on user annotations
+const (
+	maxTags        = 20
+	maxTagLength   = 40
+	maxNotesLength = 2000
+)
+
 // TransactionService handles transaction-related business logic
 type TransactionService struct {
-	transactions map[string]*models.Transaction
-	mutex        sync.RWMutex
+	transactions     map[string]*models.Transaction
+	mutex            sync.RWMutex
+	events           *EventBus
+	audit            *AuditLog
+	merchants        *MerchantNormalizer
+	categories       CategoryChecker
+	attachments      AttachmentStore
+	attachmentConfig AttachmentConfig
+	nextAttachmentID int
 }
 
 // NewTransactionService creates a new TransactionService instance
 func NewTransactionService() *TransactionService {
 	service := &TransactionService{
-		transactions: make(map[string]*models.Transaction),
+		transactions:     make(map[string]*models.Transaction),
+		merchants:        DefaultMerchantNormalizer(),
+		attachmentConfig: DefaultAttachmentConfig(),
 	}
 	service.initializeMockData()
 	return service
 }
 
-// GetAllTransactions returns all transactions with optional filtering
+// SetEventBus configures where transaction change events are published
+func (s *TransactionService) SetEventBus(events *EventBus) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.events = events
+}
+
+// SetAuditLog configures where transaction changes are recorded
+func (s *TransactionService) SetAuditLog(audit *AuditLog) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.audit = audit
+}
+
+// SetCategoryChecker makes updates accept only categories known to checker. Without one, any
+// non-empty category is accepted.
+func (s *TransactionService) SetCategoryChecker(checker CategoryChecker) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.categories = checker
+}
+
+// SetAttachmentStore configures where attachment content is kept and the limits on uploads.
+// Without a store, uploads fail with ErrAttachmentStoreUnavailable.
+func (s *TransactionService) SetAttachmentStore(store AttachmentStore, config AttachmentConfig) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.attachments = store
+	s.attachmentConfig = config
+}
+
+// SetMerchantNormalizer replaces the merchant rules and derives the merchant of every stored
+// transaction again. Merchants are derived data, so this neither changes versions nor audits.
+func (s *TransactionService) SetMerchantNormalizer(merchants *MerchantNormalizer) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.merchants = merchants
+	for id, current := range s.transactions {
+		transaction := copyTransaction(current)
+		transaction.Merchant = s.merchantOf(transaction)
+		s.transactions[id] = transaction
+	}
+}
+
+// GetAllTransactions returns copies of all transactions with optional filtering
 func (s *TransactionService) GetAllTransactions(filter *models.TransactionFilter) ([]*models.Transaction, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
@@ -39,10 +109,7 @@ func (s *TransactionService) GetAllTransactions(filter *models.TransactionFilter
 	// Apply filters
 	transactions = s.applyFilters(transactions, filter)
 
-	// Sort by date (newest first)
-	sort.Slice(transactions, func(i, j int) bool {
-		return transactions[i].Date.After(transactions[j].Date)
-	})
+	sortTransactions(transactions)
 
 	// Apply pagination
 	if filter != nil {
@@ -67,20 +134,220 @@ func (s *TransactionService) GetAllTransactions(filter *models.TransactionFilter
 		transactions = transactions[start:end]
 	}
 
-	return transactions, nil
+	return copyTransactions(transactions), nil
+}
+
+// GetTransactionsPage returns up to filter.Limit transactions after cursor, newest first,
+// and the cursor for the next page ("" on the last page). Offset is ignored.
+func (s *TransactionService) GetTransactionsPage(filter *models.TransactionFilter, cursor string) ([]*models.Transaction, string, error) {
+	after, err := decodeTransactionCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var transactions []*models.Transaction
+	for _, transaction := range s.transactions {
+		transactions = append(transactions, transaction)
+	}
+	transactions = s.applyFilters(transactions, filter)
+	sortTransactions(transactions)
+
+	// Skip everything up to and including the cursor position
+	start := 0
+	if after != nil {
+		start = sort.Search(len(transactions), func(i int) bool {
+			return transactionBefore(after, transactions[i])
+		})
+	}
+
+	limit := 50
+	if filter != nil && filter.Limit > 0 {
+		limit = filter.Limit
+	}
+
+	end := start + limit
+	if end >= len(transactions) {
+		return copyTransactions(transactions[start:]), "", nil
+	}
+
+	page := copyTransactions(transactions[start:end])
+	return page, encodeTransactionCursor(page[len(page)-1]), nil
 }
 
-// GetTransactionByID returns a transaction by ID
+// GetTransactionByID returns a copy of a transaction by ID
 func (s *TransactionService) GetTransactionByID(id string) (*models.Transaction, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
 
 	transaction, exists := s.transactions[id]
 	if !exists {
-		return nil, errors.New("transaction not found")
+		return nil, ErrTransactionNotFound
 	}
 
-	return transaction, nil
+	return copyTransaction(transaction), nil
+}
+
+// CreateTransaction stores a copy of a new transaction, such as one imported from a
+// provider, at version 1. Without a merchant, one is derived from the description. Buys and
+// sells need a symbol and a positive quantity.
+func (s *TransactionService) CreateTransaction(ctx context.Context, transaction *models.Transaction) (*models.Transaction, error) {
+	validation := &ValidationError{}
+	if transaction == nil || transaction.ID == "" {
+		validation.Add("id", CodeRequired, "transaction ID is required")
+		return nil, validation
+	}
+	if transaction.Type == models.TransactionBuy || transaction.Type == models.TransactionSell {
+		if transaction.Symbol == "" {
+			validation.Add("symbol", CodeRequired, "symbol is required for buys and sells")
+		}
+		if transaction.Quantity <= 0 {
+			validation.Add("quantity", CodeOutOfRange, "quantity must be positive")
+		}
+		if transaction.Price < 0 {
+			validation.Add("price", CodeOutOfRange, "price must not be negative")
+		}
+	}
+	if err := validation.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.transactions[transaction.ID]; exists {
+		return nil, ErrTransactionExists
+	}
+
+	transaction = copyTransaction(transaction)
+	transaction.Version = 1
+	if transaction.Merchant == "" {
+		transaction.Merchant = s.merchantOf(transaction)
+	}
+	if transaction.Date.IsZero() {
+		transaction.Date = time.Now()
+	}
+
+	s.transactions[transaction.ID] = transaction
+	recordAudit(ctx, s.audit, models.AuditTransactionCreated, "transaction", transaction.ID, nil, *transaction)
+	publishEvent(s.events, models.EventTransactionCreated, *transaction)
+
+	return copyTransaction(transaction), nil
+}
+
+// UpdateTransaction applies update to a transaction if it is still at update.Version, and
+// returns the result. A stale version fails with ErrVersionConflict; an update that changes
+// nothing keeps the version.
+func (s *TransactionService) UpdateTransaction(ctx context.Context, id string, update *models.TransactionUpdate) (*models.Transaction, error) {
+	s.mutex.RLock()
+	categories := s.categories
+	s.mutex.RUnlock()
+
+	if update.Category == nil || categories == nil {
+		return s.updateTransaction(ctx, id, update, true)
+	}
+
+	// The category is held until the update is applied, as lock order is categories first
+	var transaction *models.Transaction
+	err := categories.WithCategory(strings.TrimSpace(*update.Category), func(exists bool) error {
+		var err error
+		transaction, err = s.updateTransaction(ctx, id, update, exists)
+		return err
+	})
+	return transaction, err
+}
+
+// updateTransaction validates and applies update, given whether its category exists
+func (s *TransactionService) updateTransaction(ctx context.Context, id string, update *models.TransactionUpdate, categoryExists bool) (*models.Transaction, error) {
+	validation := &ValidationError{}
+	if update.Version <= 0 {
+		validation.Add("version", CodeRequired, "version is required")
+	}
+	if update.Category != nil {
+		category := strings.TrimSpace(*update.Category)
+		if category == "" {
+			validation.Add("category", CodeRequired, "category must not be empty")
+		} else if !categoryExists {
+			validation.Addf("category", CodeNotAllowed, "category %s does not exist", category)
+		}
+	}
+	var tags []string
+	if update.Tags != nil {
+		tags = normalizeTags(*update.Tags, validation)
+	}
+	if update.Notes != nil && len([]rune(strings.TrimSpace(*update.Notes))) > maxNotesLength {
+		validation.Addf("notes", CodeOutOfRange, "notes must be at most %d characters", maxNotesLength)
+	}
+	if err := validation.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	current, exists := s.transactions[id]
+	if !exists {
+		return nil, ErrTransactionNotFound
+	}
+	if current.Version != update.Version {
+		return nil, ErrVersionConflict
+	}
+
+	// Change a copy and swap it in, so copies handed out earlier never change underneath readers
+	transaction := copyTransaction(current)
+	if update.Category != nil {
+		transaction.Category = strings.TrimSpace(*update.Category)
+	}
+	if update.Description != nil && *update.Description != transaction.Description {
+		transaction.Description = *update.Description
+		transaction.Merchant = s.merchantOf(transaction)
+	}
+	if update.Tags != nil {
+		transaction.Tags = tags
+	}
+	if update.Notes != nil {
+		transaction.Notes = strings.TrimSpace(*update.Notes)
+	}
+	if reflect.DeepEqual(transaction, current) {
+		return transaction, nil
+	}
+	transaction.Version++
+	s.transactions[id] = transaction
+
+	recordAudit(ctx, s.audit, models.AuditTransactionUpdated, "transaction", id, *current, *transaction)
+	publishEvent(s.events, models.EventTransactionUpdated, *transaction)
+
+	return copyTransaction(transaction), nil
+}
+
+// ReplaceCategory moves every transaction in category from to category to, each as a change
+// of its own, and returns how many moved
+func (s *TransactionService) ReplaceCategory(ctx context.Context, from, to string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// In ID order, so the audit log and the events are the same on every run
+	var ids []string
+	for id, transaction := range s.transactions {
+		if transaction.Category == from {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		current := s.transactions[id]
+		transaction := copyTransaction(current)
+		transaction.Category = to
+		transaction.Version++
+		s.transactions[id] = transaction
+
+		recordAudit(ctx, s.audit, models.AuditTransactionUpdated, "transaction", id, *current, *transaction)
+		publishEvent(s.events, models.EventTransactionUpdated, *transaction)
+	}
+	return len(ids)
 }
 
 // GetTransactionsByAccountID returns transactions for a specific account
@@ -92,6 +359,53 @@ func (s *TransactionService) GetTransactionsByAccountID(accountID string, limit
 	return s.GetAllTransactions(filter)
 }
 
+// GetTransactionsByAccountIDs returns the transactions of several accounts in one pass, newest
+// first. filter.AccountID is ignored; Limit and Offset apply to each account separately.
+func (s *TransactionService) GetTransactionsByAccountIDs(accountIDs []string, filter *models.TransactionFilter) (map[string][]*models.Transaction, error) {
+	wanted := make(map[string]bool, len(accountIDs))
+	for _, id := range accountIDs {
+		wanted[id] = true
+	}
+
+	perAccount := models.TransactionFilter{}
+	if filter != nil {
+		perAccount = *filter
+	}
+	perAccount.AccountID = ""
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var transactions []*models.Transaction
+	for _, transaction := range s.transactions {
+		if wanted[transaction.AccountID] {
+			transactions = append(transactions, transaction)
+		}
+	}
+	transactions = s.applyFilters(transactions, &perAccount)
+	sortTransactions(transactions)
+
+	limit := perAccount.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	grouped := make(map[string][]*models.Transaction, len(accountIDs))
+	skipped := make(map[string]int, len(accountIDs))
+	for _, transaction := range transactions {
+		accountID := transaction.AccountID
+		if skipped[accountID] < perAccount.Offset {
+			skipped[accountID]++
+			continue
+		}
+		if len(grouped[accountID]) < limit {
+			grouped[accountID] = append(grouped[accountID], copyTransaction(transaction))
+		}
+	}
+
+	return grouped, nil
+}
+
 // applyFilters applies the given filters to the transactions
 func (s *TransactionService) applyFilters(transactions []*models.Transaction, filter *models.TransactionFilter) []*models.Transaction {
 	if filter == nil {
@@ -110,6 +424,9 @@ func (s *TransactionService) applyFilters(transactions []*models.Transaction, fi
 		if filter.Type != "" && transaction.Type != filter.Type {
 			continue
 		}
+		if len(filter.Types) > 0 && !containsString(filter.Types, transaction.Type) {
+			continue
+		}
 
 		// Category filter
 		if filter.Category != "" && transaction.Category != filter.Category {
@@ -121,6 +438,11 @@ func (s *TransactionService) applyFilters(transactions []*models.Transaction, fi
 			continue
 		}
 
+		// Tag filter
+		if filter.Tag != "" && !hasTag(transaction, filter.Tag) {
+			continue
+		}
+
 		// Date range filter
 		if filter.StartDate != nil && transaction.Date.Before(*filter.StartDate) {
 			continue
@@ -206,9 +528,10 @@ func (s *TransactionService) initializeMockData() {
 			AccountID:   "acc_004",
 			Amount:      150.00,
 			Currency:    "USD",
-			Type:        "credit",
-			Category:    "investment",
+			Type:        models.TransactionDividend,
+			Category:    "dividends",
 			Description: "Dividend Payment",
+			Symbol:      "VTI",
 			Date:        now.Add(-6 * time.Hour),
 			Status:      "completed",
 			Reference:   "DIV001234567",
@@ -261,9 +584,213 @@ func (s *TransactionService) initializeMockData() {
 			Status:      "completed",
 			Reference:   "EMG001234567",
 		},
+		{
+			ID:          "txn_011",
+			AccountID:   "acc_004",
+			Amount:      40000.00,
+			Currency:    "USD",
+			Type:        "credit",
+			Category:    "transfer",
+			Description: "Contribution from Checking",
+			Date:        now.AddDate(0, 0, -420),
+			Status:      "completed",
+			Reference:   "CON001234567",
+		},
+		{
+			ID:          "txn_012",
+			AccountID:   "acc_004",
+			Amount:      -20004.95,
+			Currency:    "USD",
+			Type:        models.TransactionBuy,
+			Category:    "investment",
+			Description: "Buy 100 VTI",
+			Date:        now.AddDate(0, 0, -400),
+			Status:      "completed",
+			Reference:   "BUY001234567",
+			Symbol:      "VTI",
+			Quantity:    100,
+			Price:       200.00,
+		},
+		{
+			ID:          "txn_013",
+			AccountID:   "acc_004",
+			Amount:      -7500.00,
+			Currency:    "USD",
+			Type:        models.TransactionBuy,
+			Category:    "investment",
+			Description: "Buy 50 AAPL",
+			Date:        now.AddDate(0, 0, -300),
+			Status:      "completed",
+			Reference:   "BUY001234568",
+			Symbol:      "AAPL",
+			Quantity:    50,
+			Price:       150.00,
+		},
+		{
+			ID:          "txn_014",
+			AccountID:   "acc_004",
+			Amount:      -11000.00,
+			Currency:    "USD",
+			Type:        models.TransactionBuy,
+			Category:    "investment",
+			Description: "Buy 50 VTI",
+			Date:        now.AddDate(0, 0, -200),
+			Status:      "completed",
+			Reference:   "BUY001234569",
+			Symbol:      "VTI",
+			Quantity:    50,
+			Price:       220.00,
+		},
+		{
+			ID:          "txn_015",
+			AccountID:   "acc_004",
+			Amount:      7200.00,
+			Currency:    "USD",
+			Type:        models.TransactionSell,
+			Category:    "investment",
+			Description: "Sell 30 VTI",
+			Date:        now.AddDate(0, 0, -60),
+			Status:      "completed",
+			Reference:   "SEL001234567",
+			Symbol:      "VTI",
+			Quantity:    30,
+			Price:       240.00,
+		},
+		{
+			ID:          "txn_016",
+			AccountID:   "acc_004",
+			Amount:      -25.00,
+			Currency:    "USD",
+			Type:        models.TransactionFee,
+			Category:    "fees",
+			Description: "Advisory Fee",
+			Date:        now.AddDate(0, 0, -30),
+			Status:      "completed",
+			Reference:   "FEE001234567",
+		},
 	}
 
 	for _, transaction := range mockTransactions {
+		transaction.Version = 1
+		transaction.Merchant = s.merchantOf(transaction)
 		s.transactions[transaction.ID] = transaction
 	}
 }
+
+// merchantOf derives the merchant of a transaction from its description. Trades have none.
+func (s *TransactionService) merchantOf(transaction *models.Transaction) string {
+	if transaction.Type == models.TransactionBuy || transaction.Type == models.TransactionSell {
+		return ""
+	}
+	return s.merchants.Normalize(transaction.Description)
+}
+
+// copyTransaction returns a copy of transaction that callers may keep or modify
+func copyTransaction(transaction *models.Transaction) *models.Transaction {
+	copied := *transaction
+	if transaction.Tags != nil {
+		copied.Tags = append([]string(nil), transaction.Tags...)
+	}
+	if transaction.Attachments != nil {
+		copied.Attachments = append([]models.Attachment(nil), transaction.Attachments...)
+	}
+	return &copied
+}
+
+// copyTransactions copies each transaction in a list
+func copyTransactions(transactions []*models.Transaction) []*models.Transaction {
+	copies := make([]*models.Transaction, len(transactions))
+	for i, transaction := range transactions {
+		copies[i] = copyTransaction(transaction)
+	}
+	return copies
+}
+
+// normalizeTags lowercases and trims tags, drops duplicates and sorts them, reporting
+// invalid tags to validation. An empty list removes every tag.
+func normalizeTags(tags []string, validation *ValidationError) []string {
+	if len(tags) > maxTags {
+		validation.Addf("tags", CodeOutOfRange, "at most %d tags are allowed", maxTags)
+		return nil
+	}
+
+	seen := make(map[string]bool, len(tags))
+	normalized := []string{}
+	for i, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		switch {
+		case tag == "":
+			validation.Addf(fmt.Sprintf("tags[%d]", i), CodeRequired, "tags must not be empty")
+		case len([]rune(tag)) > maxTagLength:
+			validation.Addf(fmt.Sprintf("tags[%d]", i), CodeOutOfRange, "tags must be at most %d characters", maxTagLength)
+		case !seen[tag]:
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+	sort.Strings(normalized)
+
+	if len(normalized) == 0 {
+		return nil
+	}
+	return normalized
+}
+
+// hasTag reports whether transaction has tag, ignoring case
+func hasTag(transaction *models.Transaction, tag string) bool {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	for _, candidate := range transaction.Tags {
+		if candidate == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// sortTransactions orders transactions newest first, breaking ties by ID so pages are stable
+func sortTransactions(transactions []*models.Transaction) {
+	sort.Slice(transactions, func(i, j int) bool {
+		return transactionBefore(transactions[i], transactions[j])
+	})
+}
+
+// transactionBefore reports whether a sorts before b
+func transactionBefore(a, b *models.Transaction) bool {
+	if !a.Date.Equal(b.Date) {
+		return a.Date.After(b.Date)
+	}
+	return a.ID > b.ID
+}
+
+// encodeTransactionCursor returns an opaque cursor for the position after transaction
+func encodeTransactionCursor(transaction *models.Transaction) string {
+	raw := strconv.FormatInt(transaction.Date.UnixNano(), 10) + ":" + transaction.ID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeTransactionCursor parses a cursor into a sort key; an empty cursor is the first page
+func decodeTransactionCursor(cursor string) (*models.Transaction, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	invalid := &ValidationError{}
+	invalid.Add("cursor", CodeInvalidFormat, "cursor is not valid")
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, invalid
+	}
+
+	nanos, id, found := strings.Cut(string(raw), ":")
+	if !found || id == "" {
+		return nil, invalid
+	}
+
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, invalid
+	}
+
+	return &models.Transaction{ID: id, Date: time.Unix(0, unixNano)}, nil
+}
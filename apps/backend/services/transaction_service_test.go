@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+func TestTransactionService_GetTransactionsPage(t *testing.T) {
+	service := NewTransactionService()
+
+	all, err := service.GetAllTransactions(&models.TransactionFilter{Limit: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Walking the cursor visits every transaction exactly once, in order
+	var walked []string
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > len(all) {
+			t.Fatal("Cursor pagination did not terminate")
+		}
+
+		page, next, err := service.GetTransactionsPage(&models.TransactionFilter{Limit: 3}, cursor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, transaction := range page {
+			walked = append(walked, transaction.ID)
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	if len(walked) != len(all) {
+		t.Fatalf("Expected %d transactions across pages, got %d", len(all), len(walked))
+	}
+	for i := range all {
+		if walked[i] != all[i].ID {
+			t.Errorf("Position %d: expected %s, got %s", i, all[i].ID, walked[i])
+		}
+	}
+}
+
+func TestTransactionService_GetTransactionsPageStableUnderInserts(t *testing.T) {
+	service := NewTransactionService()
+
+	first, cursor, err := service.GetTransactionsPage(&models.TransactionFilter{Limit: 2}, "")
+	if err != nil || cursor == "" {
+		t.Fatalf("Expected a first page with a cursor, got %v %q", err, cursor)
+	}
+
+	// A newer transaction must not shift the next page
+	_, err = service.CreateTransaction(context.Background(), &models.Transaction{
+		ID:        "txn_new",
+		AccountID: "acc_001",
+		Amount:    -1,
+		Date:      time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, _, err := service.GetTransactionsPage(&models.TransactionFilter{Limit: 2}, cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, transaction := range second {
+		if transaction.ID == first[len(first)-1].ID || transaction.ID == "txn_new" {
+			t.Errorf("Unexpected %s on the second page", transaction.ID)
+		}
+	}
+}
+
+func TestTransactionService_GetTransactionsPageInvalidCursor(t *testing.T) {
+	service := NewTransactionService()
+
+	_, _, err := service.GetTransactionsPage(nil, "not a cursor")
+	if !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected a validation error for a bad cursor, got %v", err)
+	}
+}
+
+func TestTransactionService_UpdateTransactionVersions(t *testing.T) {
+	service := NewTransactionService()
+	ctx := context.Background()
+
+	read, err := service.GetTransactionByID("txn_002")
+	if err != nil || read.Version != 1 {
+		t.Fatalf("Expected txn_002 at version 1, got %+v (%v)", read, err)
+	}
+
+	category := "payroll"
+	updated, err := service.UpdateTransaction(ctx, "txn_002", &models.TransactionUpdate{Version: read.Version, Category: &category})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Version != 2 || updated.Category != "payroll" {
+		t.Errorf("Expected payroll at version 2, got %+v", updated)
+	}
+
+	// The copy read earlier is unaffected by the update
+	if read.Category == "payroll" || read.Version != 1 {
+		t.Errorf("Expected the earlier copy to be unchanged, got %+v", read)
+	}
+
+	// A writer still holding version 1 loses
+	description := "stale"
+	_, err = service.UpdateTransaction(ctx, "txn_002", &models.TransactionUpdate{Version: 1, Description: &description})
+	if !errors.Is(err, ErrVersionConflict) || !errors.Is(err, ErrConflict) {
+		t.Errorf("Expected a version conflict, got %v", err)
+	}
+
+	// An update that changes nothing keeps the version
+	unchanged, err := service.UpdateTransaction(ctx, "txn_002", &models.TransactionUpdate{Version: 2, Category: &category})
+	if err != nil || unchanged.Version != 2 {
+		t.Errorf("Expected version 2 to be kept, got %+v (%v)", unchanged, err)
+	}
+
+	if _, err := service.UpdateTransaction(ctx, "txn_002", &models.TransactionUpdate{}); !errors.Is(err, ErrValidation) {
+		t.Errorf("Expected a validation error without a version, got %v", err)
+	}
+	if _, err := service.UpdateTransaction(ctx, "missing", &models.TransactionUpdate{Version: 1}); !errors.Is(err, ErrTransactionNotFound) {
+		t.Errorf("Expected transaction_not_found, got %v", err)
+	}
+
+	// Callers cannot change stored transactions through returned pointers
+	updated.Category = "tampered"
+	if stored, _ := service.GetTransactionByID("txn_002"); stored.Category != "payroll" {
+		t.Errorf("Expected the stored transaction to be unchanged, got %s", stored.Category)
+	}
+}
+
+func TestTransactionService_TagsAndNotes(t *testing.T) {
+	service := NewTransactionService()
+	ctx := context.Background()
+
+	tags := []string{" Work", "reimbursable", "work"}
+	notes := "  Team lunch  "
+	updated, err := service.UpdateTransaction(ctx, "txn_003", &models.TransactionUpdate{Version: 1, Tags: &tags, Notes: &notes})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updated.Tags) != 2 || updated.Tags[0] != "reimbursable" || updated.Tags[1] != "work" || updated.Notes != "Team lunch" || updated.Version != 2 {
+		t.Errorf("Expected sorted lowercase tags and trimmed notes, got %+v", updated)
+	}
+
+	// The same tags in another order change nothing
+	reordered := []string{"WORK", "reimbursable"}
+	if unchanged, err := service.UpdateTransaction(ctx, "txn_003", &models.TransactionUpdate{Version: 2, Tags: &reordered}); err != nil || unchanged.Version != 2 {
+		t.Errorf("Expected version 2 to be kept, got %+v (%v)", unchanged, err)
+	}
+
+	tagged, err := service.GetAllTransactions(&models.TransactionFilter{Tag: "Work"})
+	if err != nil || len(tagged) != 1 || tagged[0].ID != "txn_003" {
+		t.Errorf("Expected only txn_003 tagged work, got %+v (%v)", tagged, err)
+	}
+
+	// Callers cannot change stored tags through returned slices
+	updated.Tags[0] = "tampered"
+	if stored, _ := service.GetTransactionByID("txn_003"); stored.Tags[0] != "reimbursable" {
+		t.Errorf("Expected the stored tags to be unchanged, got %v", stored.Tags)
+	}
+
+	none := []string{}
+	empty := ""
+	cleared, err := service.UpdateTransaction(ctx, "txn_003", &models.TransactionUpdate{Version: 2, Tags: &none, Notes: &empty})
+	if err != nil || cleared.Tags != nil || cleared.Notes != "" {
+		t.Errorf("Expected tags and notes to be removed, got %+v (%v)", cleared, err)
+	}
+
+	invalid := []string{"ok", "", strings.Repeat("x", maxTagLength+1)}
+	long := strings.Repeat("n", maxNotesLength+1)
+	var validation *ValidationError
+	_, err = service.UpdateTransaction(ctx, "txn_003", &models.TransactionUpdate{Version: 3, Tags: &invalid, Notes: &long})
+	if !errors.As(err, &validation) || len(validation.Fields) != 3 {
+		t.Errorf("Expected an empty tag, a long tag and long notes to be invalid, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// Field error codes
+const (
+	CodeRequired      = "required"
+	CodeInvalidFormat = "invalid_format"
+	CodeOutOfRange    = "out_of_range"
+	CodeNotAllowed    = "not_allowed"
+	CodeInvalidJSON   = "invalid_json"
+)
+
+// ValidationError lists every invalid field of a request
+type ValidationError struct {
+	Fields []models.FieldError
+}
+
+// Add records an invalid field
+func (e *ValidationError) Add(field, code, message string) {
+	e.Fields = append(e.Fields, models.FieldError{Field: field, Code: code, Message: message})
+}
+
+// Addf records an invalid field with a formatted message
+func (e *ValidationError) Addf(field, code, format string, args ...interface{}) {
+	e.Add(field, code, fmt.Sprintf(format, args...))
+}
+
+// HasErrors reports whether any field is invalid
+func (e *ValidationError) HasErrors() bool {
+	return len(e.Fields) > 0
+}
+
+// Err returns e if any field is invalid, or nil
+func (e *ValidationError) Err() error {
+	if !e.HasErrors() {
+		return nil
+	}
+	return e
+}
+
+// Unwrap makes validation errors match ErrValidation
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+// Error joins the field messages
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Field+": "+field.Message)
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
@@ -0,0 +1,647 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// maxDeliveriesPerSubscription bounds the delivery log kept for each subscription
+const maxDeliveriesPerSubscription = 100
+
+// webhookWorkers is how many deliveries are sent at once; maxQueuedDeliveries bounds the
+// deliveries waiting for a worker, beyond which new ones fail at once
+const (
+	webhookWorkers      = 8
+	maxQueuedDeliveries = 1000
+)
+
+// errPrivateWebhookHost is returned when connecting to a webhook address that is not public
+var errPrivateWebhookHost = errors.New("webhook host resolves to a private, loopback or link-local address")
+
+// webhookEventTypes lists the event types a subscription may ask for
+var webhookEventTypes = map[string]bool{
+	models.EventAccountUpdated:          true,
+	models.EventTransactionCreated:      true,
+	models.EventTransactionUpdated:      true,
+	models.EventRefreshCompleted:        true,
+	models.EventRefreshFailed:           true,
+	models.EventBalanceThresholdCrossed: true,
+	models.EventAlertTriggered:          true,
+}
+
+// WebhookService manages webhook subscriptions and delivers events to them
+type WebhookService struct {
+	subscriptions  map[string]*models.WebhookSubscription
+	deliveries     map[string][]*models.WebhookDelivery
+	balances       map[string]float64
+	nextID         int
+	nextDeliveryID int
+	mutex          sync.RWMutex
+
+	eventBus          *EventBus
+	client            *http.Client
+	maxAttempts       int
+	baseDelay         time.Duration
+	allowPrivateHosts bool
+
+	queue          chan webhookJob
+	workersStarted bool
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	stopping bool // set under the write lock, so no worker is added to wg once Stop waits
+}
+
+// webhookJob is a delivery waiting for a worker
+type webhookJob struct {
+	subscription models.WebhookSubscription
+	delivery     *models.WebhookDelivery
+	payload      models.WebhookPayload
+}
+
+// NewWebhookService creates a new WebhookService instance
+func NewWebhookService(eventBus *EventBus) *WebhookService {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	service := &WebhookService{
+		subscriptions: make(map[string]*models.WebhookSubscription),
+		deliveries:    make(map[string][]*models.WebhookDelivery),
+		balances:      make(map[string]float64),
+		eventBus:      eventBus,
+		maxAttempts:   5,
+		baseDelay:     time.Second,
+		queue:         make(chan webhookJob, maxQueuedDeliveries),
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+
+	// Addresses are checked as they are dialled, so a host that resolves differently after
+	// validation, or a redirect, cannot reach a private address either
+	dialer := &net.Dialer{Timeout: 10 * time.Second, Control: service.checkDialAddress}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	service.client = &http.Client{Timeout: 10 * time.Second, Transport: transport}
+
+	return service
+}
+
+// SetAllowPrivateHosts lets subscriptions use loopback, private and link-local addresses,
+// such as a receiver on the same machine during development
+func (s *WebhookService) SetAllowPrivateHosts(allow bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.allowPrivateHosts = allow
+}
+
+// SignWebhookPayload returns the hex HMAC-SHA256 of "timestamp.body" using secret
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SeedBalances records current balances so the first update can detect threshold crossings
+func (s *WebhookService) SeedBalances(accounts []*models.Account) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, account := range accounts {
+		s.balances[account.ID] = account.Balance
+	}
+}
+
+// CreateSubscription registers a new webhook subscription
+func (s *WebhookService) CreateSubscription(request *models.WebhookSubscriptionRequest) (*models.WebhookSubscription, error) {
+	if err := s.validateWebhookRequest(request); err != nil {
+		return nil, err
+	}
+
+	secret := request.Secret
+	if secret == "" {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.nextID++
+	now := time.Now()
+	subscription := &models.WebhookSubscription{
+		ID:                fmt.Sprintf("wh_%03d", s.nextID),
+		URL:               request.URL,
+		EventTypes:        append([]string(nil), request.EventTypes...),
+		Secret:            secret,
+		BalanceThresholds: append([]float64(nil), request.BalanceThresholds...),
+		IsActive:          request.IsActive == nil || *request.IsActive,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	s.subscriptions[subscription.ID] = subscription
+
+	// The secret is only revealed once, in the creation response
+	created := *subscription
+	return &created, nil
+}
+
+// GetAllSubscriptions returns all webhook subscriptions
+func (s *WebhookService) GetAllSubscriptions() ([]*models.WebhookSubscription, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	subscriptions := make([]*models.WebhookSubscription, 0, len(s.subscriptions))
+	for _, subscription := range s.subscriptions {
+		subscriptions = append(subscriptions, redactSubscription(subscription))
+	}
+
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].ID < subscriptions[j].ID
+	})
+
+	return subscriptions, nil
+}
+
+// GetSubscriptionByID returns a webhook subscription by ID
+func (s *WebhookService) GetSubscriptionByID(id string) (*models.WebhookSubscription, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	subscription, exists := s.subscriptions[id]
+	if !exists {
+		return nil, ErrWebhookNotFound
+	}
+
+	return redactSubscription(subscription), nil
+}
+
+// UpdateSubscription replaces the settings of a webhook subscription
+func (s *WebhookService) UpdateSubscription(id string, request *models.WebhookSubscriptionRequest) (*models.WebhookSubscription, error) {
+	if err := s.validateWebhookRequest(request); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	subscription, exists := s.subscriptions[id]
+	if !exists {
+		return nil, ErrWebhookNotFound
+	}
+
+	subscription.URL = request.URL
+	subscription.EventTypes = append([]string(nil), request.EventTypes...)
+	subscription.BalanceThresholds = append([]float64(nil), request.BalanceThresholds...)
+	if request.Secret != "" {
+		subscription.Secret = request.Secret
+	}
+	if request.IsActive != nil {
+		subscription.IsActive = *request.IsActive
+	}
+	subscription.UpdatedAt = time.Now()
+
+	return redactSubscription(subscription), nil
+}
+
+// DeleteSubscription removes a webhook subscription and its delivery log
+func (s *WebhookService) DeleteSubscription(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.subscriptions[id]; !exists {
+		return ErrWebhookNotFound
+	}
+
+	delete(s.subscriptions, id)
+	delete(s.deliveries, id)
+	return nil
+}
+
+// GetDeliveries returns the delivery log of a subscription, newest first
+func (s *WebhookService) GetDeliveries(id string) ([]*models.WebhookDelivery, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if _, exists := s.subscriptions[id]; !exists {
+		return nil, ErrWebhookNotFound
+	}
+
+	entries := s.deliveries[id]
+	deliveries := make([]*models.WebhookDelivery, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		delivery := *entries[i]
+		deliveries = append(deliveries, &delivery)
+	}
+
+	return deliveries, nil
+}
+
+// SendTestEvent queues a webhook.test event for a subscription
+func (s *WebhookService) SendTestEvent(id string) (*models.WebhookDelivery, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	subscription, exists := s.subscriptions[id]
+	if !exists {
+		return nil, ErrWebhookNotFound
+	}
+
+	data := map[string]string{
+		"subscription_id": id,
+		"message":         "This is a test event",
+	}
+
+	delivery := s.queueDelivery(subscription, models.EventWebhookTest, data)
+	return &delivery, nil
+}
+
+// Start subscribes to the event bus and dispatches matching events
+func (s *WebhookService) Start() {
+	if s.eventBus == nil {
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.eventBus.Consume(s.ctx, s.handleEvent)
+	}()
+}
+
+// Stop stops dispatching and waits for in-flight deliveries or ctx to expire. Deliveries
+// still waiting for a worker, and those queued from then on, fail at once.
+func (s *WebhookService) Stop(ctx context.Context) error {
+	s.mutex.Lock()
+	s.stopping = true
+	s.mutex.Unlock()
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// handleEvent queues deliveries for every subscription interested in event
+func (s *WebhookService) handleEvent(event models.Event) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var crossings []models.BalanceThresholdCrossing
+	if account, ok := event.Data.(models.Account); ok && event.Type == models.EventAccountUpdated {
+		previous, known := s.balances[account.ID]
+		s.balances[account.ID] = account.Balance
+		if known {
+			crossings = s.collectCrossings(account.ID, previous, account.Balance)
+		}
+	}
+
+	for _, subscription := range s.subscriptions {
+		if !subscription.IsActive {
+			continue
+		}
+
+		if containsString(subscription.EventTypes, event.Type) {
+			s.queueDelivery(subscription, event.Type, event.Data)
+		}
+
+		if !containsString(subscription.EventTypes, models.EventBalanceThresholdCrossed) {
+			continue
+		}
+		for _, crossing := range crossings {
+			if containsFloat(subscription.BalanceThresholds, crossing.Threshold) {
+				s.queueDelivery(subscription, models.EventBalanceThresholdCrossed, crossing)
+			}
+		}
+	}
+}
+
+// collectCrossings returns every subscribed threshold a balance change passed through
+func (s *WebhookService) collectCrossings(accountID string, previous, current float64) []models.BalanceThresholdCrossing {
+	seen := make(map[float64]bool)
+	var crossings []models.BalanceThresholdCrossing
+
+	for _, subscription := range s.subscriptions {
+		for _, threshold := range subscription.BalanceThresholds {
+			if seen[threshold] {
+				continue
+			}
+
+			direction := ""
+			if previous < threshold && current >= threshold {
+				direction = "above"
+			} else if previous >= threshold && current < threshold {
+				direction = "below"
+			}
+			if direction == "" {
+				continue
+			}
+
+			seen[threshold] = true
+			crossings = append(crossings, models.BalanceThresholdCrossing{
+				AccountID:       accountID,
+				Threshold:       threshold,
+				PreviousBalance: previous,
+				NewBalance:      current,
+				Direction:       direction,
+			})
+		}
+	}
+
+	return crossings
+}
+
+// queueDelivery records a pending delivery and hands it to the delivery workers.
+// The caller must hold the write lock.
+func (s *WebhookService) queueDelivery(subscription *models.WebhookSubscription, eventType string, data interface{}) models.WebhookDelivery {
+	s.nextDeliveryID++
+	delivery := &models.WebhookDelivery{
+		ID:             fmt.Sprintf("dlv_%06d", s.nextDeliveryID),
+		SubscriptionID: subscription.ID,
+		EventType:      eventType,
+		Status:         models.DeliveryPending,
+		CreatedAt:      time.Now(),
+	}
+
+	entries := append(s.deliveries[subscription.ID], delivery)
+	if len(entries) > maxDeliveriesPerSubscription {
+		entries = entries[len(entries)-maxDeliveriesPerSubscription:]
+	}
+	s.deliveries[subscription.ID] = entries
+
+	if s.stopping {
+		delivery.Status = models.DeliveryFailed
+		delivery.Error = "webhook service is shutting down"
+		return *delivery
+	}
+
+	payload := models.WebhookPayload{
+		ID:        delivery.ID,
+		Type:      eventType,
+		CreatedAt: delivery.CreatedAt,
+		Data:      data,
+	}
+
+	if !s.workersStarted {
+		s.workersStarted = true
+		for i := 0; i < webhookWorkers; i++ {
+			s.wg.Add(1)
+			go s.work()
+		}
+	}
+
+	// The queue is never waited on, as the workers need the lock to record their attempts
+	select {
+	case s.queue <- webhookJob{subscription: *subscription, delivery: delivery, payload: payload}:
+	default:
+		delivery.Status = models.DeliveryFailed
+		delivery.Error = "too many deliveries are waiting to be sent"
+	}
+
+	return *delivery
+}
+
+// work sends queued deliveries one at a time until Stop, then fails those still queued
+func (s *WebhookService) work() {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case job := <-s.queue:
+			s.deliver(job.subscription, job.delivery, job.payload)
+		case <-s.ctx.Done():
+			for {
+				select {
+				case job := <-s.queue:
+					s.recordAttempt(job.delivery, 0, errors.New("delivery cancelled during shutdown"), true)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// deliver POSTs a payload, retrying with exponential backoff until it succeeds or gives up
+func (s *WebhookService) deliver(subscription models.WebhookSubscription, delivery *models.WebhookDelivery, payload models.WebhookPayload) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		s.recordAttempt(delivery, 0, err, false)
+		return
+	}
+
+	delay := s.baseDelay
+	for attempt := 1; attempt <= s.maxAttempts; attempt++ {
+		statusCode, err := s.post(subscription, payload, body)
+		retryable := (err != nil && !errors.Is(err, errPrivateWebhookHost)) || statusCode >= 500 ||
+			statusCode == http.StatusRequestTimeout || statusCode == http.StatusTooManyRequests
+		if err == nil && (statusCode < 200 || statusCode >= 300) {
+			err = fmt.Errorf("endpoint returned status %d", statusCode)
+		}
+
+		final := err == nil || !retryable || attempt == s.maxAttempts
+		s.recordAttempt(delivery, statusCode, err, final)
+		if final {
+			if err != nil {
+				log.Printf("[webhooks] delivery %s to %s failed: %v", delivery.ID, subscription.URL, err)
+			}
+			return
+		}
+
+		select {
+		case <-s.ctx.Done():
+			s.recordAttempt(delivery, statusCode, errors.New("delivery cancelled during shutdown"), true)
+			return
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// post sends one signed delivery attempt and returns the response status code
+func (s *WebhookService) post(subscription models.WebhookSubscription, payload models.WebhookPayload, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "financial-aggregator-webhooks/1.0")
+	req.Header.Set("X-Webhook-ID", payload.ID)
+	req.Header.Set("X-Webhook-Event", payload.Type)
+	req.Header.Set("X-Webhook-Timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X-Webhook-Signature", "sha256="+SignWebhookPayload(subscription.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	return resp.StatusCode, nil
+}
+
+// recordAttempt updates the delivery log after an attempt
+func (s *WebhookService) recordAttempt(delivery *models.WebhookDelivery, statusCode int, err error, final bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	delivery.Attempts++
+	delivery.LastAttemptAt = &now
+	delivery.ResponseCode = statusCode
+	delivery.Error = ""
+	if err != nil {
+		delivery.Error = err.Error()
+	}
+
+	switch {
+	case err == nil:
+		delivery.Status = models.DeliverySucceeded
+	case final:
+		delivery.Status = models.DeliveryFailed
+	}
+}
+
+// checkDialAddress refuses connections to addresses that are not public, unless private
+// hosts are allowed
+func (s *WebhookService) checkDialAddress(network, address string, _ syscall.RawConn) error {
+	s.mutex.RLock()
+	allow := s.allowPrivateHosts
+	s.mutex.RUnlock()
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); !allow && (ip == nil || isPrivateIP(ip)) {
+		return errPrivateWebhookHost
+	}
+	return nil
+}
+
+// validateWebhookRequest checks the URL and event types of a subscription request. Hosts
+// named as a private address, or as localhost, are refused unless private hosts are allowed.
+func (s *WebhookService) validateWebhookRequest(request *models.WebhookSubscriptionRequest) error {
+	validation := &ValidationError{}
+	if request == nil {
+		validation.Add("body", CodeRequired, "request body is required")
+		return validation
+	}
+
+	s.mutex.RLock()
+	allowPrivate := s.allowPrivateHosts
+	s.mutex.RUnlock()
+
+	if request.URL == "" {
+		validation.Add("url", CodeRequired, "url is required")
+	} else if parsed, err := url.Parse(request.URL); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		validation.Add("url", CodeInvalidFormat, "url must be an absolute http or https URL")
+	} else if !allowPrivate && isPrivateHost(parsed.Hostname()) {
+		validation.Add("url", CodeNotAllowed, "url must not point to a private, loopback or link-local address")
+	}
+
+	if len(request.EventTypes) == 0 {
+		validation.Add("event_types", CodeRequired, "at least one event type is required")
+	}
+
+	for i, eventType := range request.EventTypes {
+		if !webhookEventTypes[eventType] {
+			validation.Addf(fmt.Sprintf("event_types[%d]", i), CodeNotAllowed, "unsupported event type %q", eventType)
+		}
+	}
+
+	if containsString(request.EventTypes, models.EventBalanceThresholdCrossed) && len(request.BalanceThresholds) == 0 {
+		validation.Add("balance_thresholds", CodeRequired, "balance_thresholds are required for balance.threshold_crossed")
+	}
+
+	return validation.Err()
+}
+
+// isPrivateHost reports whether a URL host names localhost or a non-public address
+func isPrivateHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && isPrivateIP(ip)
+}
+
+// isPrivateIP reports whether ip is loopback, private, link-local (including the cloud
+// metadata address 169.254.169.254) or unspecified
+func isPrivateIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified()
+}
+
+// generateWebhookSecret returns a random 32-byte hex secret
+func generateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// redactSubscription returns a copy of subscription without its secret
+func redactSubscription(subscription *models.WebhookSubscription) *models.WebhookSubscription {
+	redacted := *subscription
+	redacted.Secret = ""
+	return &redacted
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFloat reports whether values contains value
+func containsFloat(values []float64, value float64) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
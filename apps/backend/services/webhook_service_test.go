@@ -0,0 +1,356 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"financial-aggregator-api/backend/models"
+)
+
+// webhookReceiver is a local endpoint that records verified payloads
+type webhookReceiver struct {
+	secret   string
+	failures int
+	payloads []models.WebhookPayload
+	mutex    sync.Mutex
+}
+
+func (rc *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	body, _ := io.ReadAll(r.Body)
+	timestamp, _ := strconv.ParseInt(r.Header.Get("X-Webhook-Timestamp"), 10, 64)
+	signature := strings.TrimPrefix(r.Header.Get("X-Webhook-Signature"), "sha256=")
+	if signature != SignWebhookPayload(rc.secret, timestamp, body) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if rc.failures > 0 {
+		rc.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	var payload models.WebhookPayload
+	_ = json.Unmarshal(body, &payload)
+	rc.payloads = append(rc.payloads, payload)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (rc *webhookReceiver) received() []models.WebhookPayload {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	return append([]models.WebhookPayload(nil), rc.payloads...)
+}
+
+func newTestWebhookService(eventBus *EventBus) *WebhookService {
+	service := NewWebhookService(eventBus)
+	service.baseDelay = time.Millisecond
+	service.SetAllowPrivateHosts(true)
+	return service
+}
+
+// waitForDelivery polls until the latest delivery of a subscription is no longer pending
+func waitForDelivery(t *testing.T, service *WebhookService, id string) *models.WebhookDelivery {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		deliveries, err := service.GetDeliveries(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(deliveries) > 0 && deliveries[0].Status != models.DeliveryPending {
+			return deliveries[0]
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatal("Timed out waiting for webhook delivery")
+	return nil
+}
+
+func TestWebhookService_SignedDeliveryWithRetries(t *testing.T) {
+	receiver := &webhookReceiver{secret: "s3cret", failures: 2}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	service := newTestWebhookService(nil)
+	subscription, err := service.CreateSubscription(&models.WebhookSubscriptionRequest{
+		URL:        server.URL,
+		EventTypes: []string{models.EventTransactionCreated},
+		Secret:     "s3cret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.SendTestEvent(subscription.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	delivery := waitForDelivery(t, service, subscription.ID)
+	if delivery.Status != models.DeliverySucceeded {
+		t.Errorf("Expected delivery to succeed, got %v (%v)", delivery.Status, delivery.Error)
+	}
+	if delivery.Attempts != 3 {
+		t.Errorf("Expected 3 attempts, got %v", delivery.Attempts)
+	}
+
+	payloads := receiver.received()
+	if len(payloads) != 1 || payloads[0].Type != models.EventWebhookTest {
+		t.Errorf("Expected one webhook.test payload, got %v", payloads)
+	}
+}
+
+func TestWebhookService_GivesUpAfterMaxAttempts(t *testing.T) {
+	receiver := &webhookReceiver{secret: "s3cret", failures: 100}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	service := newTestWebhookService(nil)
+	service.maxAttempts = 3
+	subscription, err := service.CreateSubscription(&models.WebhookSubscriptionRequest{
+		URL:        server.URL,
+		EventTypes: []string{models.EventRefreshFailed},
+		Secret:     "s3cret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := service.SendTestEvent(subscription.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	delivery := waitForDelivery(t, service, subscription.ID)
+	if delivery.Status != models.DeliveryFailed || delivery.Attempts != 3 {
+		t.Errorf("Expected failed delivery after 3 attempts, got %v after %v", delivery.Status, delivery.Attempts)
+	}
+	if delivery.ResponseCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected last response code 503, got %v", delivery.ResponseCode)
+	}
+}
+
+func TestWebhookService_StopWhileQueueing(t *testing.T) {
+	receiver := &webhookReceiver{secret: "s3cret"}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	service := newTestWebhookService(nil)
+	subscription, err := service.CreateSubscription(&models.WebhookSubscriptionRequest{
+		URL:        server.URL,
+		EventTypes: []string{models.EventRefreshFailed},
+		Secret:     "s3cret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test events keep arriving while the service stops
+	var senders sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		senders.Add(1)
+		go func() {
+			defer senders.Done()
+			for j := 0; j < 50; j++ {
+				if _, err := service.SendTestEvent(subscription.ID); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	time.Sleep(time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := service.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+	senders.Wait()
+
+	delivery, err := service.SendTestEvent(subscription.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if delivery.Status != models.DeliveryFailed || delivery.Error == "" {
+		t.Errorf("Expected a delivery queued after Stop to fail, got %+v", delivery)
+	}
+}
+
+func TestWebhookService_DispatchesBusEventsAndThresholds(t *testing.T) {
+	receiver := &webhookReceiver{secret: "s3cret"}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	eventBus := NewEventBus(16)
+	service := newTestWebhookService(eventBus)
+	service.SeedBalances([]*models.Account{{ID: "acc_001", Balance: 450}})
+
+	subscription, err := service.CreateSubscription(&models.WebhookSubscriptionRequest{
+		URL:               server.URL,
+		EventTypes:        []string{models.EventTransactionCreated, models.EventBalanceThresholdCrossed},
+		Secret:            "s3cret",
+		BalanceThresholds: []float64{500},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service.Start()
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = service.Stop(ctx)
+	}()
+
+	// Give the consumer a moment to subscribe
+	time.Sleep(20 * time.Millisecond)
+	eventBus.Publish(models.EventAccountUpdated, models.Account{ID: "acc_001", Balance: 520})
+	eventBus.Publish(models.EventRefreshCompleted, models.AccountRefreshResponse{AccountID: "acc_001"})
+	eventBus.Publish(models.EventTransactionCreated, models.Transaction{ID: "txn_100"})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(receiver.received()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	types := make(map[string]bool)
+	for _, payload := range receiver.received() {
+		types[payload.Type] = true
+	}
+	if len(types) != 2 || !types[models.EventBalanceThresholdCrossed] || !types[models.EventTransactionCreated] {
+		t.Errorf("Expected threshold and transaction deliveries only, got %v", types)
+	}
+	if _, err := service.GetDeliveries(subscription.ID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWebhookService_Validation(t *testing.T) {
+	service := newTestWebhookService(nil)
+
+	invalid := []*models.WebhookSubscriptionRequest{
+		{URL: "not a url", EventTypes: []string{models.EventAccountUpdated}},
+		{URL: "https://example.com/hook"},
+		{URL: "https://example.com/hook", EventTypes: []string{"account.deleted"}},
+		{URL: "https://example.com/hook", EventTypes: []string{models.EventBalanceThresholdCrossed}},
+	}
+	for _, request := range invalid {
+		if _, err := service.CreateSubscription(request); err == nil {
+			t.Errorf("Expected request %+v to be rejected", request)
+		}
+	}
+
+	subscription, err := service.CreateSubscription(&models.WebhookSubscriptionRequest{
+		URL:        "https://example.com/hook",
+		EventTypes: []string{models.EventAccountUpdated},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subscription.Secret == "" {
+		t.Error("Expected a generated secret on creation")
+	}
+
+	stored, err := service.GetSubscriptionByID(subscription.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Secret != "" {
+		t.Error("Expected secret to be redacted after creation")
+	}
+}
+
+func TestWebhookService_RefusesPrivateHosts(t *testing.T) {
+	service := NewWebhookService(nil)
+
+	var validation *ValidationError
+	for _, hook := range []string{
+		"http://127.0.0.1:8080/hook",
+		"http://localhost/hook",
+		"http://api.localhost/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://10.1.2.3/hook",
+		"http://192.168.0.10/hook",
+		"http://[::1]/hook",
+		"http://[fe80::1]/hook",
+		"http://0.0.0.0/hook",
+	} {
+		_, err := service.CreateSubscription(&models.WebhookSubscriptionRequest{URL: hook, EventTypes: []string{models.EventAccountUpdated}})
+		if !errors.As(err, &validation) || len(validation.Fields) != 1 || validation.Fields[0].Code != CodeNotAllowed {
+			t.Errorf("Expected %s to be refused, got %v", hook, err)
+		}
+	}
+
+	// A host that only turns out to be private when dialled is refused without retries
+	receiver := &webhookReceiver{secret: "s3cret"}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	service.SetAllowPrivateHosts(true)
+	subscription, err := service.CreateSubscription(&models.WebhookSubscriptionRequest{URL: server.URL, EventTypes: []string{models.EventAccountUpdated}, Secret: "s3cret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.SetAllowPrivateHosts(false)
+
+	if _, err := service.SendTestEvent(subscription.ID); err != nil {
+		t.Fatal(err)
+	}
+	delivery := waitForDelivery(t, service, subscription.ID)
+	if delivery.Status != models.DeliveryFailed || delivery.Attempts != 1 || !strings.Contains(delivery.Error, "private") {
+		t.Errorf("Expected one refused attempt, got %+v", delivery)
+	}
+	if len(receiver.received()) != 0 {
+		t.Errorf("Expected nothing delivered, got %v", receiver.received())
+	}
+}
+
+func TestWebhookService_BoundsQueuedDeliveries(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+
+	service := newTestWebhookService(nil)
+	subscription, err := service.CreateSubscription(&models.WebhookSubscriptionRequest{URL: server.URL, EventTypes: []string{models.EventAccountUpdated}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// With every worker stuck, at most the queue and the workers' deliveries are pending
+	failed := 0
+	for i := 0; i < webhookWorkers+maxQueuedDeliveries+10; i++ {
+		delivery, err := service.SendTestEvent(subscription.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if delivery.Status == models.DeliveryFailed {
+			failed++
+		}
+	}
+	if failed < 10 {
+		t.Errorf("Expected deliveries beyond the queue to fail, got %d failures", failed)
+	}
+
+	close(release)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := service.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+}